@@ -0,0 +1,383 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// relativeGetter returns a function retrieving the value of a relative JSONPath, i.e. `@.price`, out of an array
+// element. The path `@` stands for the element itself.
+func relativeGetter(relativePath string) (func(item any) any, error) {
+	if relativePath == "@" {
+		return func(item any) any { return item }, nil
+	}
+
+	if !strings.HasPrefix(relativePath, "@.") {
+		return nil, &Error{Code: ErrCodeInvalidPath, Message: "Relative JSONPath should start with '@.'", Path: relativePath}
+	}
+
+	jsonPath := "$" + strings.TrimPrefix(relativePath, "@")
+	if _, err := parseJsonPath(jsonPath); err != nil {
+		return nil, err
+	}
+
+	return func(item any) any {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil
+		}
+		return getOrNil(itemMap, jsonPath)
+	}, nil
+}
+
+// typeRank returns the order of the type of a value when values of different types are compared.
+func typeRank(value any) int {
+	switch {
+	case isNumber(value):
+		return 0
+	case gu.IsString(value):
+		return 1
+	}
+
+	if _, ok := value.(bool); ok {
+		return 2
+	}
+
+	return 3
+}
+
+// compareValues returns -1, 0 or 1 depending on whether a is less than, equal to or greater than b. Numbers and
+// strings are compared by value and false is less than true whereas values of different types are compared by type
+// in the order number, string, boolean and any other.
+func compareValues(a, b any) int {
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+
+	switch ra {
+	case 0:
+		fa, _ := gu.ToFloat64(a)
+		fb, _ := gu.ToFloat64(b)
+		if fa < fb {
+			return -1
+		}
+		if fa > fb {
+			return 1
+		}
+	case 1:
+		return strings.Compare(a.(string), b.(string))
+	case 2:
+		if a == b {
+			return 0
+		}
+		if !a.(bool) {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// findArrays returns the matches of the JSONPath which must all be arrays.
+func findArrays(data map[string]any, arrayPath string) ([]match, error) {
+	matches, err := findMatches(data, arrayPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, &Error{Code: ErrCodeKeyNotFound, Message: "Array not found.", Path: arrayPath}
+	}
+
+	for _, m := range matches {
+		if _, ok := m.value.([]any); !ok {
+			return nil, &Error{Code: ErrCodeNotArray, Message: fmt.Sprintf("Value of %v is not an array.", m.path), Path: arrayPath}
+		}
+	}
+
+	return matches, nil
+}
+
+// SortArray sorts in place the array(s) described by the provided JSONPath, i.e. `$.books`, by the value of the
+// relative JSONPath of their elements, i.e. `@.price`, or by the elements themselves if it is `@`.
+//
+// Numbers and strings are compared by value. The sorting is stable and the elements without a value are placed last
+// in both directions.
+func SortArray(data map[string]any, arrayPath string, byRelativePath string, desc bool) error {
+	get, err := relativeGetter(byRelativePath)
+	if err != nil {
+		return err
+	}
+
+	matches, err := findArrays(data, arrayPath)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		items := m.value.([]any)
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := get(items[i]), get(items[j])
+			if a == nil || b == nil {
+				return a != nil
+			}
+			if desc {
+				return compareValues(a, b) > 0
+			}
+			return compareValues(a, b) < 0
+		})
+	}
+
+	return nil
+}
+
+// GroupBy groups the elements of the array(s) of the provided JSONPath by the value of the relative JSONPath of
+// each element, i.e. `@.author`, converted to string. The elements keep their order within the groups and the ones
+// without a value are skipped.
+func GroupBy(data map[string]any, arrayPath string, keyRelativePath string) (map[string][]any, error) {
+	get, err := relativeGetter(keyRelativePath)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := findArrays(data, arrayPath)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]any)
+	for _, m := range matches {
+		for _, item := range m.value.([]any) {
+			key := get(item)
+			if key == nil {
+				continue
+			}
+			groupKey := fmt.Sprintf("%v", key)
+			groups[groupKey] = append(groups[groupKey], item)
+		}
+	}
+
+	return groups, nil
+}
+
+// GroupByTo works like GroupBy but it puts the grouping in the data as an object under the destination JSONPath.
+// The grouped elements are copied.
+func GroupByTo(data map[string]any, arrayPath string, keyRelativePath string, dstJsonPath string) error {
+	groups, err := GroupBy(data, arrayPath, keyRelativePath)
+	if err != nil {
+		return err
+	}
+
+	grouping := make(map[string]any, len(groups))
+	for key, items := range groups {
+		grouping[key] = deepCopy(items)
+	}
+
+	return Put(data, dstJsonPath, grouping)
+}
+
+// insertIntoArray inserts the values at the start or the end of the array(s) of the provided JSONPath. If the path
+// does not exist the array is created.
+func insertIntoArray(data map[string]any, arrayPath string, values []any, prepend bool) error {
+	matches, err := findMatches(data, arrayPath)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		return Put(data, arrayPath, append([]any{}, values...))
+	}
+
+	if _, err := findArrays(data, arrayPath); err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		if m.parent == nil {
+			return &Error{Code: ErrCodeNotArray, Message: "Root is not an array.", Path: arrayPath}
+		}
+
+		items := m.value.([]any)
+		if prepend {
+			m.set(append(append([]any{}, values...), items...))
+		} else {
+			m.set(append(items, values...))
+		}
+	}
+
+	return nil
+}
+
+// Append adds the values at the end of the array(s) of the provided JSONPath, i.e. `$.books`, in place.
+// If the path does not exist the array is created.
+func Append(data map[string]any, arrayPath string, values ...any) error {
+	return insertIntoArray(data, arrayPath, values, false)
+}
+
+// Prepend adds the values at the start of the array(s) of the provided JSONPath, i.e. `$.books`, in place.
+// If the path does not exist the array is created.
+func Prepend(data map[string]any, arrayPath string, values ...any) error {
+	return insertIntoArray(data, arrayPath, values, true)
+}
+
+// arrayOperands returns the elements of the arrays of the two JSONPaths and the identity getter of the elements.
+func arrayOperands(data map[string]any, leftPath, rightPath, identity string) ([]any, []any, func(any) any, error) {
+	get, err := relativeGetter(identity)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var operands [2][]any
+	for i, arrayPath := range []string{leftPath, rightPath} {
+		matches, err := findArrays(data, arrayPath)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, m := range matches {
+			operands[i] = append(operands[i], m.value.([]any)...)
+		}
+	}
+
+	return operands[0], operands[1], get, nil
+}
+
+// containsIdentity returns whether any of the items has the provided identity.
+func containsIdentity(items []any, identity any, get func(any) any) bool {
+	for _, item := range items {
+		if valuesEqual(get(item), identity) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnionArrays returns the elements of the left array followed by the elements of the right array, each identity
+// occuring once. The identity of the elements is either the elements themselves, with `@`, or the value of a relative
+// JSONPath, i.e. `@.id`.
+func UnionArrays(data map[string]any, leftPath, rightPath, identity string) ([]any, error) {
+	left, right, get, err := arrayOperands(data, leftPath, rightPath, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []any{}
+	for _, item := range append(left, right...) {
+		if !containsIdentity(result, get(item), get) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+// IntersectArrays returns the elements of the left array whose identity occurs in the right array as well.
+// See UnionArrays for the identity of the elements.
+func IntersectArrays(data map[string]any, leftPath, rightPath, identity string) ([]any, error) {
+	left, right, get, err := arrayOperands(data, leftPath, rightPath, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []any{}
+	for _, item := range left {
+		if containsIdentity(right, get(item), get) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+// DiffArrays returns the elements of the left array whose identity does not occur in the right array.
+// See UnionArrays for the identity of the elements.
+func DiffArrays(data map[string]any, leftPath, rightPath, identity string) ([]any, error) {
+	left, right, get, err := arrayOperands(data, leftPath, rightPath, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []any{}
+	for _, item := range left {
+		if !containsIdentity(right, get(item), get) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+// UpsertArrayElement replaces the elements of the array(s) of the provided JSONPath, i.e. `$.books`, whose value of
+// the match key, i.e. `isbn`, is equal to the one of the new element. If there is none the new element is appended
+// instead. If the path does not exist the array is created.
+//
+// The new element must hold the match key. A copy of it is put in each array.
+func UpsertArrayElement(data map[string]any, arrayPath string, matchKey string, newElement map[string]any) error {
+	key, ok := newElement[matchKey]
+	if !ok {
+		return &Error{Code: ErrCodeKeyNotFound, Message: fmt.Sprintf("New element has no '%v' key.", matchKey), Path: arrayPath, Segment: matchKey}
+	}
+
+	matches, err := findMatches(data, arrayPath)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		return Append(data, arrayPath, deepCopy(newElement))
+	}
+
+	if _, err := findArrays(data, arrayPath); err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		items := m.value.([]any)
+
+		replaced := false
+		for i, item := range items {
+			itemMap, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if itemKey, ok := itemMap[matchKey]; ok && valuesEqual(itemKey, key) {
+				items[i] = deepCopy(newElement)
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			m.set(append(items, deepCopy(newElement)))
+		}
+	}
+
+	return nil
+}
+
+// IndicesOf returns the positions of the array elements matched by the provided JSONPath, i.e. `$.books[?(@.isbn)]`,
+// within their arrays, so that they can later be targeted with indexed paths, i.e. `$.books[2]`. The indices are
+// returned in the order the elements are matched. All the matched values must be array elements.
+func IndicesOf(data map[string]any, jsonPath string) ([]int, error) {
+	matches, err := findMatches(data, jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var indices []int
+	for _, m := range matches {
+		if _, ok := m.parent.([]any); !ok {
+			return nil, &Error{Code: ErrCodeNotArray, Message: fmt.Sprintf("Value of %v is not an array element.", m.path), Path: jsonPath}
+		}
+		indices = append(indices, m.index)
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,379 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type SortArrayTestCase struct {
+	arrayPath            string
+	byRelativePath       string
+	desc                 bool
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestSortArray(t *testing.T) {
+	data := func() map[string]any {
+		return map[string]any{
+			"books": []any{
+				map[string]any{"title": "Book1", "price": 15, "author": map[string]any{"name": "Stirner"}},
+				map[string]any{"title": "Book2", "author": map[string]any{"name": "Nietzsche"}},
+				map[string]any{"title": "Book3", "price": 5.5, "author": map[string]any{"name": "Kant"}},
+				map[string]any{"title": "Book4", "price": 15, "author": map[string]any{"name": "Hegel"}},
+			},
+			"stores": []any{
+				map[string]any{"tags": []any{"b", "c", "a"}},
+				map[string]any{"tags": []any{3, "x", 1, true}},
+			},
+			"title": "Books",
+		}
+	}
+	books := data()["books"].([]any)
+
+	testCases := []SortArrayTestCase{
+		{
+			arrayPath:      "$.books",
+			byRelativePath: "@.price",
+			expectedData: func() map[string]any {
+				d := data()
+				d["books"] = []any{books[2], books[0], books[3], books[1]}
+				return d
+			}(),
+		},
+		{
+			arrayPath:      "$.books",
+			byRelativePath: "@.price",
+			desc:           true,
+			expectedData: func() map[string]any {
+				d := data()
+				d["books"] = []any{books[0], books[3], books[2], books[1]}
+				return d
+			}(),
+		},
+		{
+			arrayPath:      "$.books",
+			byRelativePath: "@.author.name",
+			expectedData: func() map[string]any {
+				d := data()
+				d["books"] = []any{books[3], books[2], books[1], books[0]}
+				return d
+			}(),
+		},
+		{
+			arrayPath:      "$.stores[*].tags",
+			byRelativePath: "@",
+			expectedData: func() map[string]any {
+				d := data()
+				d["stores"] = []any{
+					map[string]any{"tags": []any{"a", "b", "c"}},
+					map[string]any{"tags": []any{1, 3, "x", true}},
+				}
+				return d
+			}(),
+		},
+		{
+			arrayPath:            "$.books",
+			byRelativePath:       "price",
+			expectedData:         data(),
+			expectedErrorMessage: "Relative JSONPath should start with '@.'",
+		},
+		{
+			arrayPath:            "$.title",
+			byRelativePath:       "@",
+			expectedData:         data(),
+			expectedErrorMessage: "Value of $.title is not an array.",
+		},
+		{
+			arrayPath:            "$.missing",
+			byRelativePath:       "@",
+			expectedData:         data(),
+			expectedErrorMessage: "Array not found.",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - SortArray(%v, %v, %v)=%v", i, tc.arrayPath, tc.byRelativePath, tc.desc, tc.expectedErrorMessage), func(t *testing.T) {
+			d := data()
+			err := SortArray(d, tc.arrayPath, tc.byRelativePath, tc.desc)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, d) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(d))
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	data := map[string]any{
+		"books": []any{
+			map[string]any{"title": "Book1", "author": "Nietzsche", "year": 1883},
+			map[string]any{"title": "Book2", "author": "Stirner", "year": 1844},
+			map[string]any{"title": "Book3", "author": "Nietzsche", "year": 1886},
+			map[string]any{"title": "Book4"},
+		},
+	}
+	books := data["books"].([]any)
+
+	groups, err := GroupBy(data, "$.books", "@.author")
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	expectedGroups := map[string][]any{
+		"Nietzsche": {books[0], books[2]},
+		"Stirner":   {books[1]},
+	}
+	if !cmp.Equal(expectedGroups, groups) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedGroups), gu.Prettify(groups))
+	}
+
+	if _, err := GroupBy(data, "$.books", "author"); err == nil || err.Error() != "Relative JSONPath should start with '@.'" {
+		t.Errorf("Expected an invalid relative path error, but got '%v'", err)
+	}
+
+	if err := GroupByTo(data, "$.books", "@.year", "$.byYear"); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	expectedByYear := map[string]any{
+		"1883": []any{books[0]},
+		"1844": []any{books[1]},
+		"1886": []any{books[2]},
+	}
+	if !cmp.Equal(expectedByYear, data["byYear"]) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedByYear), gu.Prettify(data["byYear"]))
+	}
+}
+
+type InsertIntoArrayTestCase struct {
+	insert               func(map[string]any, string, ...any) error
+	arrayPath            string
+	values               []any
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestAppendPrepend(t *testing.T) {
+	data := func() map[string]any {
+		return map[string]any{
+			"tags":   []any{"b"},
+			"stores": []any{map[string]any{"books": []any{"Book1"}}, map[string]any{"books": []any{}}},
+			"title":  "Books",
+		}
+	}
+
+	testCases := []InsertIntoArrayTestCase{
+		{
+			insert:    Append,
+			arrayPath: "$.tags",
+			values:    []any{"c", "d"},
+			expectedData: map[string]any{
+				"tags":   []any{"b", "c", "d"},
+				"stores": []any{map[string]any{"books": []any{"Book1"}}, map[string]any{"books": []any{}}},
+				"title":  "Books",
+			},
+		},
+		{
+			insert:    Prepend,
+			arrayPath: "$.tags",
+			values:    []any{"a"},
+			expectedData: map[string]any{
+				"tags":   []any{"a", "b"},
+				"stores": []any{map[string]any{"books": []any{"Book1"}}, map[string]any{"books": []any{}}},
+				"title":  "Books",
+			},
+		},
+		{
+			insert:    Append,
+			arrayPath: "$.stores[*].books",
+			values:    []any{"Book2"},
+			expectedData: map[string]any{
+				"tags":   []any{"b"},
+				"stores": []any{map[string]any{"books": []any{"Book1", "Book2"}}, map[string]any{"books": []any{"Book2"}}},
+				"title":  "Books",
+			},
+		},
+		{
+			insert:    Append,
+			arrayPath: "$.meta.ids",
+			values:    []any{1},
+			expectedData: map[string]any{
+				"tags":   []any{"b"},
+				"stores": []any{map[string]any{"books": []any{"Book1"}}, map[string]any{"books": []any{}}},
+				"title":  "Books",
+				"meta":   map[string]any{"ids": []any{1}},
+			},
+		},
+		{
+			insert:               Prepend,
+			arrayPath:            "$.title",
+			values:               []any{"a"},
+			expectedData:         data(),
+			expectedErrorMessage: "Value of $.title is not an array.",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - %v(%v)=%v", i, tc.arrayPath, tc.values, tc.expectedErrorMessage), func(t *testing.T) {
+			d := data()
+			err := tc.insert(d, tc.arrayPath, tc.values...)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, d) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(d))
+			}
+		})
+	}
+}
+
+type ArraySetOperationTestCase struct {
+	operation            func(map[string]any, string, string, string) ([]any, error)
+	identity             string
+	expectedResult       []any
+	expectedErrorMessage string
+}
+
+func TestArraySetOperations(t *testing.T) {
+	data := map[string]any{
+		"left":  []any{map[string]any{"id": 1, "v": "a"}, map[string]any{"id": 2, "v": "b"}, map[string]any{"id": 2, "v": "c"}},
+		"right": []any{map[string]any{"id": 2.0, "v": "b"}, map[string]any{"id": 3, "v": "d"}},
+	}
+	left, right := data["left"].([]any), data["right"].([]any)
+
+	testCases := []ArraySetOperationTestCase{
+		{operation: UnionArrays, identity: "@.id", expectedResult: []any{left[0], left[1], right[1]}},
+		{operation: UnionArrays, identity: "@", expectedResult: []any{left[0], left[1], left[2], right[0], right[1]}},
+		{operation: IntersectArrays, identity: "@.id", expectedResult: []any{left[1], left[2]}},
+		{operation: IntersectArrays, identity: "@", expectedResult: []any{}},
+		{operation: DiffArrays, identity: "@.id", expectedResult: []any{left[0]}},
+		{operation: DiffArrays, identity: "@.v", expectedResult: []any{left[0], left[2]}},
+		{operation: DiffArrays, identity: "id", expectedErrorMessage: "Relative JSONPath should start with '@.'"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - %v=%v", i, tc.identity, tc.expectedErrorMessage), func(t *testing.T) {
+			result, err := tc.operation(data, "$.left", "$.right", tc.identity)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedResult), gu.Prettify(result))
+			}
+		})
+	}
+}
+
+type UpsertArrayElementTestCase struct {
+	arrayPath            string
+	newElement           map[string]any
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestUpsertArrayElement(t *testing.T) {
+	data := func() map[string]any {
+		return map[string]any{
+			"books": []any{
+				map[string]any{"isbn": 1, "title": "Book1"},
+				"invalid",
+				map[string]any{"isbn": "2", "title": "Book2"},
+			},
+		}
+	}
+
+	testCases := []UpsertArrayElementTestCase{
+		{
+			arrayPath:  "$.books",
+			newElement: map[string]any{"isbn": 1.0, "title": "Book1 (2nd edition)"},
+			expectedData: map[string]any{
+				"books": []any{
+					map[string]any{"isbn": 1.0, "title": "Book1 (2nd edition)"},
+					"invalid",
+					map[string]any{"isbn": "2", "title": "Book2"},
+				},
+			},
+		},
+		{
+			arrayPath:  "$.books",
+			newElement: map[string]any{"isbn": 2, "title": "Book3"},
+			expectedData: map[string]any{
+				"books": []any{
+					map[string]any{"isbn": 1, "title": "Book1"},
+					"invalid",
+					map[string]any{"isbn": "2", "title": "Book2"},
+					map[string]any{"isbn": 2, "title": "Book3"},
+				},
+			},
+		},
+		{
+			arrayPath:  "$.store.books",
+			newElement: map[string]any{"isbn": 1},
+			expectedData: map[string]any{
+				"books": data()["books"],
+				"store": map[string]any{"books": []any{map[string]any{"isbn": 1}}},
+			},
+		},
+		{
+			arrayPath:            "$.books",
+			newElement:           map[string]any{"title": "Book4"},
+			expectedData:         data(),
+			expectedErrorMessage: "New element has no 'isbn' key.",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - UpsertArrayElement(%v, %v)=%v", i, tc.arrayPath, tc.newElement, tc.expectedErrorMessage), func(t *testing.T) {
+			d := data()
+			err := UpsertArrayElement(d, tc.arrayPath, "isbn", tc.newElement)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, d) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(d))
+			}
+		})
+	}
+}
+
+type IndicesOfTestCase struct {
+	jsonPath             string
+	expectedIndices      []int
+	expectedErrorMessage string
+}
+
+func TestIndicesOf(t *testing.T) {
+	data := map[string]any{
+		"books": []any{
+			map[string]any{"isbn": 1, "price": 10},
+			map[string]any{"price": 20},
+			"invalid",
+			map[string]any{"isbn": 3, "price": 5},
+		},
+		"store": map[string]any{"name": "Store"},
+	}
+
+	testCases := []IndicesOfTestCase{
+		{jsonPath: "$.books[?(@.isbn)]", expectedIndices: []int{0, 3}},
+		{jsonPath: "$.books[?(@.price >= 10)]", expectedIndices: []int{0, 1}},
+		{jsonPath: "$.books[1:]", expectedIndices: []int{1, 2, 3}},
+		{jsonPath: "$.books[?(@.price > 100)]"},
+		{jsonPath: "$.store.name", expectedErrorMessage: "Value of $.store.name is not an array element."},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - IndicesOf(%v)=%v", i, tc.jsonPath, tc.expectedIndices), func(t *testing.T) {
+			indices, err := IndicesOf(data, tc.jsonPath)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedIndices, indices) {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedIndices, indices)
+			}
+		})
+	}
+}
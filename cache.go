@@ -0,0 +1,113 @@
+package jsonmanu
+
+import (
+	"sync"
+)
+
+// cachedQuery is the memoized result of a JSONPath query along with its parsed nodes.
+type cachedQuery struct {
+	nodes []nodeDataAccessor
+	value any
+	err   error
+}
+
+// queryCache memoizes the results of Document.Get by JSONPath. It is safe for concurrent use.
+type queryCache struct {
+	mu      sync.RWMutex
+	queries map[string]cachedQuery
+}
+
+// get returns the memoized result of the JSONPath if any.
+func (c *queryCache) get(jsonPath string) (cachedQuery, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	query, ok := c.queries[jsonPath]
+	return query, ok
+}
+
+// set memoizes the result of the JSONPath.
+func (c *queryCache) set(jsonPath string, query cachedQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queries[jsonPath] = query
+}
+
+// invalidate drops the memoized results which changes in the JSONPaths may affect. All the results are dropped if any
+// of the JSONPaths is invalid.
+func (c *queryCache) invalidate(jsonPaths ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, jsonPath := range jsonPaths {
+		change, err := parseJsonPath(jsonPath)
+		if err != nil {
+			c.queries = make(map[string]cachedQuery)
+			return
+		}
+
+		for key, query := range c.queries {
+			if pathsOverlap(query.nodes, change) {
+				delete(c.queries, key)
+			}
+		}
+	}
+}
+
+// clear drops all the memoized results.
+func (c *queryCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queries = make(map[string]cachedQuery)
+}
+
+// EnableQueryCache turns on the memoization of the results of Get by JSONPath, dropping any previously memoized ones.
+// The results are invalidated by the changes of Put, Delete, Map, transactions and snapshots whose paths overlap with
+// theirs, so it pays off when the same paths are queried repeatedly out of a document which rarely changes.
+//
+// Get is safe for concurrent use while the cache is on, as long as the document is not changed at the same time.
+// The memoized values are shared with the data so they must not be modified. Changes applied on the data returned by
+// Data are not tracked; EnableQueryCache should be called again after them.
+func (d *Document) EnableQueryCache() {
+	d.cache = &queryCache{queries: make(map[string]cachedQuery)}
+}
+
+// DisableQueryCache turns off the memoization of the results of Get and drops the memoized ones.
+func (d *Document) DisableQueryCache() {
+	d.cache = nil
+}
+
+// cachedGet retrieves a value out of the document through the query cache.
+func (d *Document) cachedGet(jsonPath string) (any, error) {
+	query, ok := d.cache.get(jsonPath)
+	metrics.QueryCacheAccessed(ok)
+	if ok {
+		return query.value, query.err
+	}
+
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := Get(d.data, jsonPath)
+	d.cache.set(jsonPath, cachedQuery{nodes: nodes, value: value, err: err})
+
+	return value, err
+}
+
+// invalidateCache drops the memoized results which changes in the JSONPaths may affect.
+func (d *Document) invalidateCache(jsonPaths ...string) {
+	if d.cache != nil {
+		d.cache.invalidate(jsonPaths...)
+	}
+}
+
+// clearCache drops all the memoized results.
+func (d *Document) clearCache() {
+	if d.cache != nil {
+		d.cache.clear()
+	}
+}
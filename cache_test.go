@@ -0,0 +1,71 @@
+package jsonmanu
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDocumentQueryCache(t *testing.T) {
+	doc := NewDocument(map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 80},
+		"name":   "config",
+	})
+	doc.EnableQueryCache()
+
+	assertGet := func(jsonPath string, expected any) {
+		t.Helper()
+		if value, _ := doc.Get(jsonPath); value != expected {
+			t.Errorf("%v: expected '%v', but got '%v'", jsonPath, expected, value)
+		}
+	}
+
+	assertGet("$.server.port", 80)
+	assertGet("$.name", "config")
+
+	// the memoized results are not aware of direct changes
+	doc.Data()["name"] = "direct"
+	assertGet("$.name", "config")
+
+	doc.Put("$.server.host", "example.com")
+	assertGet("$.name", "config")
+	assertGet("$.server.port", 80)
+
+	doc.Put("$.server", map[string]any{"port": 8080})
+	assertGet("$.server.port", 8080)
+
+	doc.Map(map[string]any{"port": 443}, []Mapper{{SrcJsonPath: "$.port", DstJsonPath: "$.server.port"}})
+	assertGet("$.server.port", 443)
+
+	doc.Delete("$.server")
+	if _, err := doc.Get("$.server.port"); err == nil {
+		t.Errorf("Expected an error")
+	}
+	assertGet("$.name", "config")
+
+	doc.EnableQueryCache()
+	assertGet("$.name", "direct")
+
+	doc.DisableQueryCache()
+	doc.Data()["name"] = "other"
+	assertGet("$.name", "other")
+}
+
+func TestDocumentQueryCacheConcurrentGet(t *testing.T) {
+	doc := NewDocument(map[string]any{"a": map[string]any{"b": 1}, "c": 2})
+	doc.EnableQueryCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if value, _ := doc.Get("$.a.b"); value != 1 {
+					t.Errorf("Expected '1', but got '%v'", value)
+				}
+				doc.Get("$.c")
+			}
+		}()
+	}
+	wg.Wait()
+}
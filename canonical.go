@@ -0,0 +1,179 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// MarshalCanonical serializes the value to canonical JSON as specified by RFC 8785 (JSON Canonicalization Scheme), so
+// that equal values always serialize to the same bytes regardless of the order their keys were inserted in, i.e. to
+// hash or sign mapped documents.
+//
+// The keys of the objects are sorted by their UTF-16 code units, the strings escape only the characters that must
+// be escaped and the numbers are serialized as IEEE 754 doubles in their shortest form, i.e. `1e+21` or `0.1`.
+// Values other than the ones produced by Decode are converted to their generic form through encoding/json first.
+//
+// An error is returned if the value contains NaN or infinite numbers or strings which are not valid UTF-8.
+func MarshalCanonical(value any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := writeCanonical(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeCanonical writes the canonical JSON of the value to the buffer.
+func writeCanonical(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case string:
+		return writeCanonicalString(buf, v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return &Error{Code: ErrCodeInvalidDocument, Message: fmt.Sprintf("Unsupported number %v", v)}
+		}
+		return writeCanonicalNumber(buf, f)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalString(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		if isNumber(v) {
+			f, _ := gu.ToFloat64(v)
+			return writeCanonicalNumber(buf, f)
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		return writeCanonical(buf, generic)
+	}
+
+	return nil
+}
+
+// lessUTF16 compares two strings by their UTF-16 code units.
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+
+	return len(ua) < len(ub)
+}
+
+// writeCanonicalString writes the string to the buffer escaping only the quotes, the backslashes and the control
+// characters.
+func writeCanonicalString(buf *bytes.Buffer, s string) error {
+	if !utf8.ValidString(s) {
+		return &Error{Code: ErrCodeInvalidDocument, Message: fmt.Sprintf("Invalid UTF-8 string %q", s)}
+	}
+
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+
+	return nil
+}
+
+// writeCanonicalNumber writes the number to the buffer the way ECMAScript serializes numbers.
+func writeCanonicalNumber(buf *bytes.Buffer, f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return &Error{Code: ErrCodeInvalidDocument, Message: fmt.Sprintf("Unsupported number %v", f)}
+	}
+
+	if f == 0 {
+		buf.WriteString("0")
+		return nil
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+
+	s := strconv.FormatFloat(f, format, -1, 64)
+	if format == 'e' {
+		// the exponent has no leading zeros, i.e. 1e-7 instead of 1e-07
+		if n := len(s); n >= 4 && s[n-4] == 'e' && s[n-2] == '0' {
+			s = s[:n-2] + s[n-1:]
+		}
+	}
+
+	buf.WriteString(s)
+
+	return nil
+}
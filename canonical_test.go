@@ -0,0 +1,64 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+type MarshalCanonicalTestCase struct {
+	value                any
+	expectedResult       string
+	expectedErrorMessage string
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	testCases := []MarshalCanonicalTestCase{
+		{
+			value:          map[string]any{"b": []any{1, 2.5, "<a&b>"}, "a": map[string]any{"z": nil, "y": true}},
+			expectedResult: `{"a":{"y":true,"z":null},"b":[1,2.5,"<a&b>"]}`,
+		},
+		{
+			value:          map[string]any{"a": map[string]any{"y": true, "z": nil}, "b": []any{1.0, 2.5, "<a&b>"}},
+			expectedResult: `{"a":{"y":true,"z":null},"b":[1,2.5,"<a&b>"]}`,
+		},
+		{
+			// RFC 8785, section 3.2.3
+			value:          map[string]any{"\u20ac": "Euro Sign", "\r": "Carriage Return", "\ufb33": "Hebrew Letter Dalet With Dagesh", "1": "One", "\U0001f600": "Emoji: Grinning Face", "\u0080": "Control", "\u00f6": "Latin Small Letter O With Diaeresis"},
+			expectedResult: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			// RFC 8785, section 3.2.2.3
+			value:          []any{333333333.33333329, 1e30, 4.50, 2e-3, 0.000000000000000000000000001, -0.0, 1e21, 1e-7, 123456789012345680000.0},
+			expectedResult: `[333333333.3333333,1e+30,4.5,0.002,1e-27,0,1e+21,1e-7,123456789012345680000]`,
+		},
+		{
+			value:          "\u2028\"\\\x01\t\u00e9",
+			expectedResult: `"` + "\u2028" + `\"\\\u0001\t` + "\u00e9" + `"`,
+		},
+		{
+			value:          []map[string]any{{"b": uint8(2), "a": int64(-1)}},
+			expectedResult: `[{"a":-1,"b":2}]`,
+		},
+		{
+			value:                []any{math.Inf(1)},
+			expectedErrorMessage: "Unsupported number +Inf",
+		},
+		{
+			value:                map[string]any{"a": "\xff"},
+			expectedErrorMessage: `Invalid UTF-8 string "\xff"`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - MarshalCanonical(%v)=%v", i, tc.value, tc.expectedResult), func(t *testing.T) {
+			result, err := MarshalCanonical(tc.value)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if string(result) != tc.expectedResult {
+				t.Errorf("Expected '%v', but got '%s'", tc.expectedResult, result)
+			}
+		})
+	}
+}
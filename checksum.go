@@ -0,0 +1,76 @@
+package jsonmanu
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+)
+
+// The algorithms of ChecksumTransformer.
+const (
+	ChecksumSHA256 = "sha256"
+	ChecksumSHA1   = "sha1"
+	ChecksumMD5    = "md5"
+	ChecksumFNV64  = "fnv64"
+)
+
+// checksumHashes holds the constructors of the hashes of the algorithms of ChecksumTransformer.
+var checksumHashes = map[string]func() hash.Hash{
+	ChecksumSHA256: sha256.New,
+	ChecksumSHA1:   sha1.New,
+	ChecksumMD5:    md5.New,
+	ChecksumFNV64:  func() hash.Hash { return fnv.New64a() },
+}
+
+// ChecksumTransformer computes a stable content hash of a value, i.e. to give the mapped records a key to
+// deduplicate them by. Equal values have the same hash regardless of the order of their keys.
+type ChecksumTransformer struct {
+
+	// Algorithm is the hash algorithm. It can be one of ChecksumSHA256, ChecksumSHA1, ChecksumMD5 and ChecksumFNV64
+	// and it defaults to ChecksumSHA256.
+	Algorithm string
+
+	// Keys, if not empty, are the only keys of an object value that are hashed, i.e. the ones identifying a record.
+	Keys []string
+}
+
+// ChecksumTransformer Transform applies the checksum transformation.
+//
+// It expects any value that can be serialized to JSON. The value is hashed in the form of MarshalCanonical and the
+// hash is returned as a hexadecimal string. Arrays should be hashed as a whole by configuring the transformation with
+// AsArray.
+func (t ChecksumTransformer) Transform(value any) (any, error) {
+	algorithm := t.Algorithm
+	if algorithm == "" {
+		algorithm = ChecksumSHA256
+	}
+
+	newHash, ok := checksumHashes[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("Unknown checksum algorithm '%v'.", t.Algorithm)
+	}
+
+	if len(t.Keys) > 0 {
+		valueMap, ok := value.(map[string]any)
+		if !ok {
+			return nil, errors.New("Value is not an object.")
+		}
+		picked, _ := PickTransformer{Keys: t.Keys}.Transform(valueMap)
+		value = picked
+	}
+
+	data, err := MarshalCanonical(value)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't serialize value: %w", err)
+	}
+
+	h := newHash()
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
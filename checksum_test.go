@@ -0,0 +1,79 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChecksumTransformer(t *testing.T) {
+	record := map[string]any{"id": 1, "name": "Nietzsche", "seen": "2024-01-01"}
+
+	cases := []TransformerTestCase{
+		{
+			transformer:              ChecksumTransformer{},
+			value:                    map[string]any{"a": 1},
+			expectedTransformedValue: "015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ChecksumTransformer{Algorithm: ChecksumMD5},
+			value:                    "abc",
+			expectedTransformedValue: "ebd9f4c7b06cb0aaf5d13d80e49d8b90",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ChecksumTransformer{Algorithm: ChecksumFNV64, Keys: []string{"id", "name"}},
+			value:                    record,
+			expectedTransformedValue: mustChecksum(t, ChecksumTransformer{Algorithm: ChecksumFNV64}, map[string]any{"name": "Nietzsche", "id": 1.0}),
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ChecksumTransformer{Keys: []string{"id"}},
+			value:                    []any{record},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an object.",
+		},
+		{
+			transformer:              ChecksumTransformer{Algorithm: "crc32"},
+			value:                    record,
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Unknown checksum algorithm 'crc32'.",
+		},
+		{
+			transformer:              ChecksumTransformer{},
+			value:                    math.NaN(),
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't serialize value: Unsupported number NaN",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("ChecksumTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func mustChecksum(t *testing.T, transformer ChecksumTransformer, value any) any {
+	t.Helper()
+
+	checksum, err := transformer.Transform(value)
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	return checksum
+}
@@ -0,0 +1,258 @@
+// Command jsonmanu exposes the querying, updating and mapping functionality of the jsonmanu library
+// on the command line.
+//
+// Usage:
+//
+//	jsonmanu get [-f format] PATH [FILE]
+//	jsonmanu put [-f format] PATH VALUE [FILE]
+//	jsonmanu delete [-f format] PATH [FILE]
+//	jsonmanu map [-f format] -c CONFIG [-v NAME=VALUE]... [FILE]
+//
+// The input is read from FILE or from the standard input if FILE is omitted. The result is written as JSON
+// to the standard output. The VALUE of put is parsed as JSON and if that fails it is used as a plain string.
+// The `${NAME}` references of the mapping configuration are replaced by the variables provided with -v.
+//
+// The flags may come before or after the positional arguments. Arguments starting with a dash are taken as flags
+// unless they are numbers, i.e. `-5`, so other such values should follow `--`.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	jm "github.com/antavelos/jsonmanu"
+)
+
+const usage = `Usage:
+  jsonmanu get [-f format] PATH [FILE]
+  jsonmanu put [-f format] PATH VALUE [FILE]
+  jsonmanu delete [-f format] PATH [FILE]
+  jsonmanu map [-f format] -c CONFIG [-v NAME=VALUE]... [FILE]`
+
+// formatFromFilename guesses the format of a file by its extension falling back to JSON.
+func formatFromFilename(filename string) jm.Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".jsonc":
+		return jm.FormatJSONC
+	case ".yaml", ".yml":
+		return jm.FormatYAML
+	case ".toml":
+		return jm.FormatTOML
+	case ".xml":
+		return jm.FormatXML
+	}
+
+	return jm.FormatJSON
+}
+
+// readInput decodes the data found in the provided file or in stdin if no file is provided.
+func readInput(args []string, format string, stdin io.Reader) (map[string]any, error) {
+	if len(args) == 0 {
+		if format == "" {
+			format = string(jm.FormatJSON)
+		}
+		return jm.Decode(stdin, jm.Format(format))
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if format == "" {
+		format = string(formatFromFilename(args[0]))
+	}
+
+	return jm.Decode(file, jm.Format(format))
+}
+
+// parseValue parses a command line value as JSON falling back to a plain string.
+func parseValue(s string) any {
+	var value any
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return s
+	}
+
+	return value
+}
+
+// isNegativeNumber returns whether the argument is a number starting with a dash, i.e. `-5`, which is not a flag.
+func isNegativeNumber(arg string) bool {
+	_, err := strconv.ParseFloat(arg, 64)
+
+	return err == nil && strings.HasPrefix(arg, "-")
+}
+
+// parseArgs parses the flags found anywhere among the arguments and returns the positional arguments in their order.
+// Negative numbers, as well as all the arguments after `--`, are positional.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for len(args) > 0 {
+		if !strings.HasPrefix(args[0], "-") || args[0] == "-" || isNegativeNumber(args[0]) {
+			positional, args = append(positional, args[0]), args[1:]
+			continue
+		}
+
+		// the flag package would take a negative number for an undefined flag
+		end := 1
+		for end < len(args) && !isNegativeNumber(args[end]) {
+			end++
+		}
+		if err := flags.Parse(args[:end]); err != nil {
+			return nil, err
+		}
+		args = append(flags.Args(), args[end:]...)
+	}
+
+	return append(positional, rest...), nil
+}
+
+// writeOutput writes the provided value as indented JSON.
+func writeOutput(stdout io.Writer, value any) error {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+// run executes the command described by the provided arguments.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	command := args[0]
+
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("f", "", "the input format: json, jsonc, yaml, toml, xml, msgpack or cbor")
+	configFile := flags.String("c", "", "the mapping configuration file")
+	vars := make(map[string]string)
+	flags.Func("v", "a variable of the mapping configuration as NAME=VALUE", func(s string) error {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("invalid variable '%v'", s)
+		}
+		vars[name] = value
+		return nil
+	})
+
+	positional, err := parseArgs(flags, args[1:])
+	if err != nil {
+		return fmt.Errorf("%v\n%v", err, usage)
+	}
+
+	switch command {
+	case "get":
+		if len(positional) < 1 {
+			return errors.New(usage)
+		}
+		data, err := readInput(positional[1:], *format, stdin)
+		if err != nil {
+			return err
+		}
+		value, err := jm.Get(data, positional[0])
+		if err != nil {
+			return err
+		}
+		return writeOutput(stdout, value)
+
+	case "put":
+		if len(positional) < 2 {
+			return errors.New(usage)
+		}
+		data, err := readInput(positional[2:], *format, stdin)
+		if err != nil {
+			return err
+		}
+		if err := jm.Put(data, positional[0], parseValue(positional[1])); err != nil {
+			return err
+		}
+		return writeOutput(stdout, data)
+
+	case "delete":
+		if len(positional) < 1 {
+			return errors.New(usage)
+		}
+		data, err := readInput(positional[1:], *format, stdin)
+		if err != nil {
+			return err
+		}
+		if err := jm.Delete(data, positional[0]); err != nil {
+			return err
+		}
+		return writeOutput(stdout, data)
+
+	case "map":
+		if *configFile == "" {
+			return errors.New(usage)
+		}
+		config, err := os.Open(*configFile)
+		if err != nil {
+			return err
+		}
+		defer config.Close()
+
+		mappingConfig, err := jm.LoadMappingConfig(config, formatFromFilename(*configFile))
+		if err != nil {
+			return err
+		}
+		mappingConfig, err = mappingConfig.WithVariables(vars)
+		if err != nil {
+			return withSpecLocation(*configFile, err)
+		}
+		mappers, err := mappingConfig.ToMappers()
+		if err != nil {
+			return withSpecLocation(*configFile, err)
+		}
+		data, err := readInput(positional, *format, stdin)
+		if err != nil {
+			return err
+		}
+
+		dst := make(map[string]any)
+		if errs := jm.MapWithOptions(data, dst, mappers, mappingConfig.ToMapOptions()); len(errs) > 0 {
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, withSpecLocation(*configFile, mappingConfig.Locate(err)).Error())
+			}
+			return errors.New(strings.Join(messages, "\n"))
+		}
+		return writeOutput(stdout, dst)
+	}
+
+	return fmt.Errorf("Unknown command '%v'\n%v", command, usage)
+}
+
+// withSpecLocation prefixes the error with the file, the line and the column of the configuration entry that caused it, if known.
+func withSpecLocation(configFile string, err error) error {
+	var specErr *jm.SpecError
+	if !errors.As(err, &specErr) || specErr.Location.Line == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%v:%v:%v: %w", configFile, specErr.Location.Line, specErr.Location.Column, err)
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
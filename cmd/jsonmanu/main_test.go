@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type RunTestCase struct {
+	args                 []string
+	stdin                string
+	expectedOutput       string
+	expectedErrorMessage string
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	dataFile := filepath.Join(dir, "data.yaml")
+	os.WriteFile(dataFile, []byte("books:\n  - author: Nietzsche\n    title: Book1\n  - author: Stirner\n    title: Book2\n"), 0644)
+
+	configFile := filepath.Join(dir, "mappers.yaml")
+	os.WriteFile(configFile, []byte("mappers:\n  - src: $.books.author\n    dst: $.authors\n    transformations:\n      - type: substr\n        start: 0\n        end: 3\n"), 0644)
+
+	varsConfigFile := filepath.Join(dir, "vars.yaml")
+	os.WriteFile(varsConfigFile, []byte("mappers:\n  - src: $.books.${FIELD}\n    dst: $.values\n"), 0644)
+
+	badConfigFile := filepath.Join(dir, "bad.json")
+	os.WriteFile(badConfigFile, []byte(`{"mappers": [{"src": "$.books.author", "dst": "$.authors", "transformations": [{"type": "upper"}]}]}`), 0644)
+
+	testCases := []RunTestCase{
+		{
+			args:           []string{"get", "$.books[*].title", dataFile},
+			expectedOutput: "[\n  \"Book1\",\n  \"Book2\"\n]\n",
+		},
+		{
+			args:           []string{"get", "$.book.author"},
+			stdin:          `{"book": {"author": "Nietzsche"}}`,
+			expectedOutput: "\"Nietzsche\"\n",
+		},
+		{
+			args:           []string{"get", "-f", "yaml", "$.book.author"},
+			stdin:          "book:\n  author: Nietzsche\n",
+			expectedOutput: "\"Nietzsche\"\n",
+		},
+		{
+			args:           []string{"put", "$.book.price", "10"},
+			stdin:          `{"book": {"author": "Nietzsche"}}`,
+			expectedOutput: "{\n  \"book\": {\n    \"author\": \"Nietzsche\",\n    \"price\": 10\n  }\n}\n",
+		},
+		{
+			args:           []string{"put", "$.book.author", "Stirner"},
+			stdin:          `{"book": {"author": "Nietzsche"}}`,
+			expectedOutput: "{\n  \"book\": {\n    \"author\": \"Stirner\"\n  }\n}\n",
+		},
+		{
+			args:           []string{"delete", "$.book.author"},
+			stdin:          `{"book": {"author": "Nietzsche"}}`,
+			expectedOutput: "{\n  \"book\": {}\n}\n",
+		},
+		{
+			args:           []string{"map", "-c", configFile, dataFile},
+			expectedOutput: "{\n  \"authors\": [\n    \"Nie\",\n    \"Sti\"\n  ]\n}\n",
+		},
+		{
+			args:           []string{"map", "-c", varsConfigFile, "-v", "FIELD=title", dataFile},
+			expectedOutput: "{\n  \"values\": [\n    \"Book1\",\n    \"Book2\"\n  ]\n}\n",
+		},
+		{
+			args:                 []string{"map", "-c", varsConfigFile, dataFile},
+			expectedErrorMessage: varsConfigFile + ":2:5: Mapper[0]: Missing variable 'FIELD'",
+		},
+		{
+			args:                 []string{"map", "-c", badConfigFile, dataFile},
+			expectedErrorMessage: badConfigFile + ":1:81: Mapper[0]: Transformation[0]: Unknown transformer type 'upper'",
+		},
+		{
+			args:           []string{"get", "$.book.author", "-f", "yaml"},
+			stdin:          "book:\n  author: Nietzsche\n",
+			expectedOutput: "\"Nietzsche\"\n",
+		},
+		{
+			args:           []string{"put", "$.book.price", "-5", "-f", "yaml"},
+			stdin:          "book:\n  price: 10\n",
+			expectedOutput: "{\n  \"book\": {\n    \"price\": -5\n  }\n}\n",
+		},
+		{
+			args:           []string{"put", "-f", "yaml", "--", "$.book.author", "-x"},
+			stdin:          "book: {}\n",
+			expectedOutput: "{\n  \"book\": {\n    \"author\": \"-x\"\n  }\n}\n",
+		},
+		{
+			args:           []string{"map", dataFile, "-c", configFile},
+			expectedOutput: "{\n  \"authors\": [\n    \"Nie\",\n    \"Sti\"\n  ]\n}\n",
+		},
+		{
+			args:                 []string{"get", "$.book.author", "-x"},
+			stdin:                `{}`,
+			expectedErrorMessage: "flag provided but not defined: -x\n" + usage,
+		},
+		{
+			args:                 []string{"map", dataFile},
+			expectedErrorMessage: usage,
+		},
+		{
+			args:                 []string{"get", "books"},
+			stdin:                `{}`,
+			expectedErrorMessage: "JSONPath should start with '$.'",
+		},
+		{
+			args:                 []string{"select", "$.books"},
+			expectedErrorMessage: "Unknown command 'select'\n" + usage,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - run(%v)=%v", i, tc.args, tc.expectedErrorMessage), func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run(tc.args, strings.NewReader(tc.stdin), &stdout)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if stdout.String() != tc.expectedOutput {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedOutput, stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package jsonmanu
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the serialization format of a document.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatJSONC Format = "jsonc"
+	FormatYAML  Format = "yaml"
+	FormatTOML  Format = "toml"
+	FormatXML   Format = "xml"
+
+	FormatMsgPack Format = "msgpack"
+	FormatCBOR    Format = "cbor"
+)
+
+// normalizeDecoded converts the maps with non string keys occured by decoders like the YAML one to
+// maps with string keys so that the decoded data can be handled by Get, Put and Map.
+func normalizeDecoded(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			v[key] = normalizeDecoded(val)
+		}
+		return v
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for key, val := range v {
+			result[fmt.Sprintf("%v", key)] = normalizeDecoded(val)
+		}
+		return result
+	case []any:
+		for i, val := range v {
+			v[i] = normalizeDecoded(val)
+		}
+		return v
+	case []map[string]any:
+		result := make([]any, len(v))
+		for i, val := range v {
+			result[i] = normalizeDecoded(val)
+		}
+		return result
+	}
+
+	return value
+}
+
+// Decode reads a document of the provided format out of `r`.
+//
+// The document's root must be an object.
+func Decode(r io.Reader, format Format) (map[string]any, error) {
+	var data any
+
+	switch format {
+	case FormatJSON:
+		if err := json.NewDecoder(r).Decode(&data); err != nil {
+			return nil, err
+		}
+	case FormatJSONC:
+		dataMap, _, err := DecodeJSONC(r)
+		return dataMap, err
+	case FormatYAML:
+		if err := yaml.NewDecoder(r).Decode(&data); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		var tomlData map[string]any
+		if _, err := toml.NewDecoder(r).Decode(&tomlData); err != nil {
+			return nil, err
+		}
+		data = tomlData
+	case FormatXML:
+		return DecodeXML(r, XMLOptions{})
+	case FormatMsgPack:
+		if err := msgpack.NewDecoder(r).Decode(&data); err != nil {
+			return nil, err
+		}
+	case FormatCBOR:
+		if err := cbor.NewDecoder(r).Decode(&data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, &Error{Code: ErrCodeUnsupportedFormat, Message: fmt.Sprintf("Unsupported format '%v'", format)}
+	}
+
+	dataMap, ok := normalizeDecoded(data).(map[string]any)
+	if !ok {
+		return nil, &Error{Code: ErrCodeNotMap, Message: fmt.Sprintf("Document root should be an object, got %T", data), Path: "$"}
+	}
+
+	return dataMap, nil
+}
+
+// XMLOptions holds the conventions used when converting an XML document to a map.
+type XMLOptions struct {
+
+	// AttributePrefix is prepended to the names of the attributes so they can be distinguished from the child
+	// elements. Defaults to `_` so that attributes can be addressed by JSONPath.
+	AttributePrefix string
+
+	// TextKey is the key under which the text of an element is placed when the element has attributes or child
+	// elements as well. Defaults to `__text`.
+	TextKey string
+}
+
+// xmlElement is an intermediate representation of a decoded XML element.
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+// toValue converts the element to a string if it contains only text, otherwise to a map.
+// Repeated child elements are grouped in an array.
+func (e *xmlElement) toValue(opts XMLOptions) any {
+	text := strings.TrimSpace(e.text.String())
+
+	if len(e.attrs) == 0 && len(e.children) == 0 {
+		return text
+	}
+
+	result := make(map[string]any)
+	for _, attr := range e.attrs {
+		result[opts.AttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	for _, child := range e.children {
+		value := child.toValue(opts)
+
+		existing, ok := result[child.name]
+		if !ok {
+			result[child.name] = value
+			continue
+		}
+
+		if existingSlice, ok := existing.([]any); ok {
+			result[child.name] = append(existingSlice, value)
+		} else {
+			result[child.name] = []any{existing, value}
+		}
+	}
+
+	if len(text) > 0 {
+		result[opts.TextKey] = text
+	}
+
+	return result
+}
+
+// DecodeXML reads an XML document out of `r` and converts it to a map.
+//
+// The root element becomes the single key of the returned map. Elements containing only text are converted
+// to strings while the rest to maps holding their attributes, child elements and text according to the provided
+// options. Repeated child elements are grouped in arrays. Namespaces are ignored.
+func DecodeXML(r io.Reader, opts XMLOptions) (map[string]any, error) {
+	if opts.AttributePrefix == "" {
+		opts.AttributePrefix = "_"
+	}
+	if opts.TextKey == "" {
+		opts.TextKey = "__text"
+	}
+
+	decoder := xml.NewDecoder(r)
+
+	var root *xmlElement
+	var stack []*xmlElement
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: t.Name.Local, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+			} else if root == nil {
+				root = element
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, &Error{Code: ErrCodeInvalidDocument, Message: "XML document has no root element"}
+	}
+
+	return map[string]any{root.name: root.toValue(opts)}, nil
+}
+
+// Encode writes the provided data to `w` in the provided format.
+//
+// TOML and XML formats are not supported for encoding.
+func Encode(w io.Writer, data map[string]any, format Format) error {
+	switch format {
+	case FormatJSON, FormatJSONC:
+		return json.NewEncoder(w).Encode(data)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		if err := encoder.Encode(data); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case FormatMsgPack:
+		return msgpack.NewEncoder(w).Encode(data)
+	case FormatCBOR:
+		return cbor.NewEncoder(w).Encode(data)
+	}
+
+	return &Error{Code: ErrCodeUnsupportedFormat, Message: fmt.Sprintf("Unsupported format '%v'", format)}
+}
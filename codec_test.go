@@ -0,0 +1,195 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type DecodeTestCase struct {
+	input                string
+	format               Format
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestDecode(t *testing.T) {
+	testCases := []DecodeTestCase{
+		{
+			input:  `{"store": {"books": [{"author": "Nietzsche", "price": 15}]}}`,
+			format: FormatJSON,
+			expectedData: map[string]any{
+				"store": map[string]any{
+					"books": []any{
+						map[string]any{"author": "Nietzsche", "price": 15.0},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:  "{\n  // the store\n  \"store\": {\"books\": [{\"author\": \"Nietzsche\", \"price\": 15,},], /* no owner */},\n}\n",
+			format: FormatJSONC,
+			expectedData: map[string]any{
+				"store": map[string]any{
+					"books": []any{
+						map[string]any{"author": "Nietzsche", "price": 15.0},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:  "store:\n  books:\n    - author: Nietzsche\n      price: 15\n  1: one\n",
+			format: FormatYAML,
+			expectedData: map[string]any{
+				"store": map[string]any{
+					"books": []any{
+						map[string]any{"author": "Nietzsche", "price": 15},
+					},
+					"1": "one",
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:  "[store]\nname = \"Store1\"\n\n[[store.books]]\nauthor = \"Nietzsche\"\nprice = 15\n",
+			format: FormatTOML,
+			expectedData: map[string]any{
+				"store": map[string]any{
+					"name": "Store1",
+					"books": []any{
+						map[string]any{"author": "Nietzsche", "price": int64(15)},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:  `<store name="Store1"><book id="1">Book1</book><book>Book2</book><owner>Someone</owner></store>`,
+			format: FormatXML,
+			expectedData: map[string]any{
+				"store": map[string]any{
+					"_name": "Store1",
+					"book": []any{
+						map[string]any{"_id": "1", "__text": "Book1"},
+						"Book2",
+					},
+					"owner": "Someone",
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:                "",
+			format:               FormatXML,
+			expectedData:         nil,
+			expectedErrorMessage: "XML document has no root element",
+		},
+		{
+			input:                "- Nietzsche\n- Stirner\n",
+			format:               FormatYAML,
+			expectedData:         nil,
+			expectedErrorMessage: "Document root should be an object, got []interface {}",
+		},
+		{
+			input:                "{}",
+			format:               "ini",
+			expectedData:         nil,
+			expectedErrorMessage: "Unsupported format 'ini'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Decode(%v, %v)=%v", i, tc.input, tc.format, tc.expectedErrorMessage), func(t *testing.T) {
+			data, err := Decode(strings.NewReader(tc.input), tc.format)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedData), gu.Prettify(data))
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "title": "Book1"},
+			},
+		},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatMsgPack, FormatCBOR} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, data, format); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			decoded, err := Decode(&buf, format)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(data, decoded) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(data), gu.Prettify(decoded))
+			}
+		})
+	}
+}
+
+func TestDecodeXMLWithOptions(t *testing.T) {
+	input := `<book xmlns:x="urn:x" x:id="1">Book1<author>Nietzsche</author></book>`
+
+	data, err := DecodeXML(strings.NewReader(input), XMLOptions{AttributePrefix: "attr_", TextKey: "content"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedData := map[string]any{
+		"book": map[string]any{
+			"attr_x":  "urn:x",
+			"attr_id": "1",
+			"content": "Book1",
+			"author":  "Nietzsche",
+		},
+	}
+	if !cmp.Equal(expectedData, data) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(data))
+	}
+
+	id, err := Get(data, "$.book.attr_id")
+	if err != nil || id != "1" {
+		t.Errorf("Expected '1', but got '%v' (%v)", id, err)
+	}
+}
+
+func TestDecodeBinaryNumbers(t *testing.T) {
+	data := map[string]any{"book": map[string]any{"price": 15, "rating": 4.5}}
+
+	for _, format := range []Format{FormatMsgPack, FormatCBOR} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, data, format); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			decoded, err := Decode(&buf, format)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			price, err := Get(decoded, "$.book.price")
+			if fprice, _ := gu.ToFloat64(price); err != nil || fprice != 15 {
+				t.Errorf("Expected '15', but got '%v' (%v)", price, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// dateLayouts are the layouts tried when coercing a string to a date without a layout.
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// CoercionRule pairs a JSONPath with the type its values are coerced to.
+type CoercionRule struct {
+
+	// JsonPath describes the values to be coerced. It may match multiple values, i.e. `$.items[*].price`.
+	JsonPath string `json:"path"`
+
+	// Type is the target type. It can be one of `number`, `boolean`, `string` and `date`. Dates are converted to
+	// RFC 3339 strings.
+	Type string `json:"type"`
+
+	// Layout is the layout, as accepted by time.Parse, of the string values coerced to `date`. If empty a few common
+	// layouts are tried, i.e. RFC 3339 and `2006-01-02`. Numbers are considered Unix timestamps in seconds.
+	Layout string `json:"layout,omitempty"`
+}
+
+// coercionError returns the error of a failed conversion.
+func coercionError(format string, args ...any) error {
+	return &Error{Code: ErrCodeTransformation, Message: fmt.Sprintf(format, args...)}
+}
+
+// coerceNumber converts a string or a boolean to float64. Strings such as `NaN` and `Inf` are rejected since they
+// cannot be encoded to JSON as numbers.
+func coerceNumber(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		fv, err := parseFiniteFloat(strings.TrimSpace(v))
+		if err != nil {
+			return nil, coercionError("Couldn't convert '%v' to number.", v)
+		}
+		return fv, nil
+	case bool:
+		if v {
+			return float64(1), nil
+		}
+		return float64(0), nil
+	}
+
+	if isNumber(value) {
+		return value, nil
+	}
+
+	return nil, coercionError("Couldn't convert %T to number.", value)
+}
+
+// coerceBoolean converts a string or a number to bool.
+func coerceBoolean(value any) (any, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		bv, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, coercionError("Couldn't convert '%v' to boolean.", v)
+		}
+		return bv, nil
+	}
+
+	if isNumber(value) {
+		fv, _ := gu.ToFloat64(value)
+		return fv != 0, nil
+	}
+
+	return nil, coercionError("Couldn't convert %T to boolean.", value)
+}
+
+// coerceString converts a scalar to string.
+func coerceString(value any) (any, error) {
+	switch value.(type) {
+	case map[string]any, []any:
+		return nil, coercionError("Couldn't convert %T to string.", value)
+	case float64, float32:
+		fv, _ := gu.ToFloat64(value)
+		return strconv.FormatFloat(fv, 'f', -1, 64), nil
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
+
+// coerceDate converts a string of the provided layout or a Unix timestamp to an RFC 3339 string.
+func coerceDate(value any, layout string) (any, error) {
+	if isNumber(value) {
+		fv, _ := gu.ToFloat64(value)
+		return time.Unix(int64(fv), 0).UTC().Format(time.RFC3339), nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, coercionError("Couldn't convert %T to date.", value)
+	}
+
+	layouts := dateLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+
+	for _, l := range layouts {
+		if t, err := time.Parse(l, strings.TrimSpace(s)); err == nil {
+			return t.Format(time.RFC3339), nil
+		}
+	}
+
+	return nil, coercionError("Couldn't convert '%v' to date.", s)
+}
+
+// coerce converts the value to the type of the rule. Nulls remain as they are.
+func (r CoercionRule) coerce(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch r.Type {
+	case "number":
+		return coerceNumber(value)
+	case "boolean":
+		return coerceBoolean(value)
+	case "string":
+		return coerceString(value)
+	case "date":
+		return coerceDate(value, r.Layout)
+	}
+
+	return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Unknown coercion type '%v'", r.Type)}
+}
+
+// coercionTarget identifies the location of a coerced value, so that a value staged by a rule is seen by the next
+// rules. Coercions replace scalars only, so the locations remain valid until the staged values are written back.
+type coercionTarget struct {
+	object uintptr
+	item   *any
+	key    string
+}
+
+// stagedCoercion is a coerced value waiting to be written back to the location of its match.
+type stagedCoercion struct {
+	m     match
+	value any
+}
+
+// targetOf returns the location of the matched value.
+func targetOf(m match) coercionTarget {
+	switch parent := m.parent.(type) {
+	case map[string]any:
+		return coercionTarget{object: objectID(parent), key: m.key}
+	case []any:
+		return coercionTarget{item: &parent[m.index]}
+	}
+
+	return coercionTarget{}
+}
+
+// CoerceTypes converts in place the values matched by the JSONPath of each rule to the type of the rule, i.e. numbers
+// emitted as strings by their producer to actual numbers. The rules apply in order and null values remain as they are.
+//
+// The conversions are staged and written back only if all of them succeed, otherwise `data` remains untouched and
+// the first occured error is returned.
+func CoerceTypes(data map[string]any, rules []CoercionRule) error {
+	staged := make(map[coercionTarget]int)
+	var coercions []stagedCoercion
+
+	for i, rule := range rules {
+		matches, err := findMatches(data, rule.JsonPath)
+		if err != nil {
+			return fmt.Errorf("Rule[%v]: %w", i, err)
+		}
+
+		for _, m := range matches {
+			if m.parent == nil {
+				return fmt.Errorf("Rule[%v]: %w", i, &Error{Code: ErrCodeInvalidOperation, Message: "Root cannot be coerced.", Path: rule.JsonPath})
+			}
+
+			target := targetOf(m)
+			current := m.value
+			if j, ok := staged[target]; ok {
+				current = coercions[j].value
+			}
+
+			value, err := rule.coerce(current)
+			if err != nil {
+				if e, ok := err.(*Error); ok {
+					e.Path = m.path
+				}
+				return fmt.Errorf("Rule[%v]: %v: %w", i, m.path, err)
+			}
+
+			if j, ok := staged[target]; ok {
+				coercions[j].value = value
+				continue
+			}
+			staged[target] = len(coercions)
+			coercions = append(coercions, stagedCoercion{m: m, value: value})
+		}
+	}
+
+	for _, c := range coercions {
+		c.m.set(c.value)
+	}
+
+	return nil
+}
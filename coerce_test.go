@@ -0,0 +1,131 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type CoerceTypesTestCase struct {
+	rules                []CoercionRule
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestCoerceTypes(t *testing.T) {
+	data := func() map[string]any {
+		return map[string]any{
+			"id":        "42",
+			"available": "true",
+			"published": "1883-01-01",
+			"isbn":      9780140441185.0,
+			"ratio":     "NaN",
+			"items": []any{
+				map[string]any{"price": "15.5", "inStock": 1, "date": "01/02/2023"},
+				map[string]any{"price": nil, "inStock": 0, "date": "03/04/2023"},
+			},
+			"store": map[string]any{"book": map[string]any{"price": "5"}, "updated": 1700000000},
+		}
+	}
+
+	testCases := []CoerceTypesTestCase{
+		{
+			rules: []CoercionRule{
+				{JsonPath: "$.id", Type: "number"},
+				{JsonPath: "$.available", Type: "boolean"},
+				{JsonPath: "$.published", Type: "date"},
+				{JsonPath: "$.isbn", Type: "string"},
+				{JsonPath: "$.items[*].price", Type: "number"},
+				{JsonPath: "$.items.inStock", Type: "boolean"},
+				{JsonPath: "$.items[*].date", Type: "date", Layout: "01/02/2006"},
+				{JsonPath: "$..price", Type: "string"},
+				{JsonPath: "$.store.updated", Type: "date"},
+				{JsonPath: "$.missing", Type: "number"},
+			},
+			expectedData: map[string]any{
+				"id":        float64(42),
+				"available": true,
+				"published": "1883-01-01T00:00:00Z",
+				"isbn":      "9780140441185",
+				"ratio":     "NaN",
+				"items": []any{
+					map[string]any{"price": "15.5", "inStock": true, "date": "2023-01-02T00:00:00Z"},
+					map[string]any{"price": nil, "inStock": false, "date": "2023-03-04T00:00:00Z"},
+				},
+				"store": map[string]any{"book": map[string]any{"price": "5"}, "updated": "2023-11-14T22:13:20Z"},
+			},
+		},
+		{
+			rules: []CoercionRule{
+				{JsonPath: "$.id", Type: "number"},
+				{JsonPath: "$.available", Type: "number"},
+			},
+			expectedData:         data(),
+			expectedErrorMessage: "Rule[1]: $.available: Couldn't convert 'true' to number.",
+		},
+		{
+			rules: []CoercionRule{
+				{JsonPath: "$.id", Type: "string"},
+				{JsonPath: "$.id", Type: "number"},
+				{JsonPath: "$.published", Type: "number"},
+			},
+			expectedData:         data(),
+			expectedErrorMessage: "Rule[2]: $.published: Couldn't convert '1883-01-01' to number.",
+		},
+		{
+			rules:                []CoercionRule{{JsonPath: "$.ratio", Type: "number"}},
+			expectedData:         data(),
+			expectedErrorMessage: "Rule[0]: $.ratio: Couldn't convert 'NaN' to number.",
+		},
+		{
+			rules:                []CoercionRule{{JsonPath: "$.items", Type: "string"}},
+			expectedData:         data(),
+			expectedErrorMessage: "Rule[0]: $.items: Couldn't convert []interface {} to string.",
+		},
+		{
+			rules:                []CoercionRule{{JsonPath: "$.id", Type: "integer"}},
+			expectedData:         data(),
+			expectedErrorMessage: "Rule[0]: $.id: Unknown coercion type 'integer'",
+		},
+		{
+			rules:                []CoercionRule{{JsonPath: "id", Type: "number"}},
+			expectedData:         data(),
+			expectedErrorMessage: "Rule[0]: JSONPath should start with '$.'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - CoerceTypes(%v)=%v", i, tc.rules, tc.expectedErrorMessage), func(t *testing.T) {
+			d := data()
+			err := CoerceTypes(d, tc.rules)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, d) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(d))
+			}
+		})
+	}
+}
+
+func TestCoerceTypesInPlace(t *testing.T) {
+	item := map[string]any{"price": "15.5", "count": "2"}
+	data := map[string]any{"items": []any{item}}
+
+	err := CoerceTypes(data, []CoercionRule{{JsonPath: "$.items[*].price", Type: "number"}, {JsonPath: "$.items[*].count", Type: "boolean"}})
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+	if item["price"] != "15.5" {
+		t.Errorf("Expected the item to remain untouched, but got '%v'", item)
+	}
+
+	if err := CoerceTypes(data, []CoercionRule{{JsonPath: "$.items[*].price", Type: "number"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if item["price"] != 15.5 || data["items"].([]any)[0].(map[string]any)["price"] != 15.5 {
+		t.Errorf("Expected the referenced item to be coerced, but got '%v'", item)
+	}
+}
@@ -0,0 +1,119 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// EmailNormalizeTransformer normalizes email addresses so that the same address is always written the same way, i.e.
+// ` Friedrich.N+books@Example.com` to `friedrich.n@example.com`.
+type EmailNormalizeTransformer struct {
+
+	// StripPlusTag removes the tag following a `+` in the local part of the address, i.e. `+books`.
+	StripPlusTag bool
+}
+
+// EmailNormalizeTransformer Transform applies the email normalization.
+//
+// It expects a string value holding a single `@` with a non empty local part and domain. The address is trimmed
+// and lowercased.
+func (t EmailNormalizeTransformer) Transform(value any) (any, error) {
+	if !gu.IsString(value) {
+		return nil, errors.New("Value is not a string.")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(value.(string)))
+
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" || domain == "" || strings.Contains(domain, "@") || strings.ContainsAny(email, " \t\n") {
+		return nil, fmt.Errorf("Value '%v' is not an email address.", value)
+	}
+
+	if t.StripPlusTag {
+		if i := strings.Index(local, "+"); i > 0 {
+			local = local[:i]
+		}
+	}
+
+	return local + "@" + domain, nil
+}
+
+// regionCallingCodes maps the ISO 3166 codes of the regions PhoneNormalizeTransformer supports to their calling codes.
+var regionCallingCodes = map[string]string{
+	"US": "1", "CA": "1", "MX": "52", "BR": "55", "AR": "54",
+	"GB": "44", "IE": "353", "DE": "49", "FR": "33", "IT": "39", "ES": "34", "PT": "351", "NL": "31", "BE": "32",
+	"CH": "41", "AT": "43", "GR": "30", "CY": "357", "SE": "46", "NO": "47", "DK": "45", "FI": "358", "PL": "48",
+	"CZ": "420", "RO": "40", "TR": "90",
+	"AU": "61", "NZ": "64", "IN": "91", "JP": "81", "CN": "86", "KR": "82", "SG": "65", "ZA": "27",
+}
+
+// PhoneNormalizeTransformer normalizes phone numbers to the E.164 format, i.e. `(202) 555-0143` to `+12025550143`
+// for the US region.
+type PhoneNormalizeTransformer struct {
+
+	// DefaultRegion is the ISO 3166 code of the region of the numbers without a country code, i.e. `US` or `GR`.
+	// Numbers without a country code cause an error if it is empty.
+	DefaultRegion string
+}
+
+// PhoneNormalizeTransformer Transform applies the phone normalization.
+//
+// It expects a string or a number value. Spaces, dashes, dots, slashes and parentheses are ignored. A number is
+// taken as international if it starts with `+` or with the international call prefix `00` (`011` in the US and Canada)
+// otherwise the national trunk prefix, `0` or `1` in the US and Canada, is removed and the country code of the
+// DefaultRegion is prepended. The result must have from 8 to 15 digits and the national numbers of the US and Canada
+// must have 10 of them.
+func (t PhoneNormalizeTransformer) Transform(value any) (any, error) {
+	if !gu.IsString(value) && !isNumber(value) {
+		return nil, errors.New("Value is not a string or a number.")
+	}
+
+	phone, ok := value.(string)
+	if !ok {
+		f, _ := gu.ToFloat64(value)
+		phone = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	phone = strings.NewReplacer(" ", "", "-", "", ".", "", "/", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+
+	international := strings.HasPrefix(phone, "+")
+	digits := strings.TrimPrefix(phone, "+")
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return nil, fmt.Errorf("Couldn't convert '%v' to phone number.", value)
+	}
+
+	region := strings.ToUpper(t.DefaultRegion)
+	callingCode, known := regionCallingCodes[region]
+	if region != "" && !known {
+		return nil, fmt.Errorf("Unknown region '%v'.", t.DefaultRegion)
+	}
+
+	nanp := callingCode == "1"
+	switch {
+	case international:
+	case nanp && strings.HasPrefix(digits, "011"):
+		digits = digits[3:]
+	case !nanp && strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	case region == "":
+		return nil, fmt.Errorf("Phone number '%v' has no country code.", value)
+	case nanp:
+		if digits = strings.TrimPrefix(digits, "1"); len(digits) != 10 {
+			return nil, fmt.Errorf("Couldn't convert '%v' to phone number.", value)
+		}
+		digits = callingCode + digits
+	case region == "IT":
+		digits = callingCode + digits
+	default:
+		digits = callingCode + strings.TrimPrefix(digits, "0")
+	}
+
+	if len(digits) < 8 || len(digits) > 15 {
+		return nil, fmt.Errorf("Couldn't convert '%v' to phone number.", value)
+	}
+
+	return "+" + digits, nil
+}
@@ -0,0 +1,158 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEmailNormalizeTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              EmailNormalizeTransformer{},
+			value:                    " Friedrich.N+books@Example.COM ",
+			expectedTransformedValue: "friedrich.n+books@example.com",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              EmailNormalizeTransformer{StripPlusTag: true},
+			value:                    "Friedrich.N+books@Example.COM",
+			expectedTransformedValue: "friedrich.n@example.com",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              EmailNormalizeTransformer{StripPlusTag: true},
+			value:                    "+books@example.com",
+			expectedTransformedValue: "+books@example.com",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              EmailNormalizeTransformer{},
+			value:                    "friedrich@example@com",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value 'friedrich@example@com' is not an email address.",
+		},
+		{
+			transformer:              EmailNormalizeTransformer{},
+			value:                    "friedrich",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value 'friedrich' is not an email address.",
+		},
+		{
+			transformer:              EmailNormalizeTransformer{},
+			value:                    1,
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not a string.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("EmailNormalizeTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestPhoneNormalizeTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "US"},
+			value:                    "(202) 555-0143",
+			expectedTransformedValue: "+12025550143",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "us"},
+			value:                    "1-202-555-0143",
+			expectedTransformedValue: "+12025550143",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "US"},
+			value:                    "011 44 20 7946 0958",
+			expectedTransformedValue: "+442079460958",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "GB"},
+			value:                    "020 7946 0958",
+			expectedTransformedValue: "+442079460958",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "GR"},
+			value:                    "0030 210 1234567",
+			expectedTransformedValue: "+302101234567",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "IT"},
+			value:                    "06 1234 5678",
+			expectedTransformedValue: "+390612345678",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{},
+			value:                    "+49 30/123456",
+			expectedTransformedValue: "+4930123456",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "DE"},
+			value:                    30123456.0,
+			expectedTransformedValue: "+4930123456",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{},
+			value:                    "030 123456",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Phone number '030 123456' has no country code.",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "XX"},
+			value:                    "030 123456",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Unknown region 'XX'.",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "US"},
+			value:                    "555-0143 ext 2",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '555-0143 ext 2' to phone number.",
+		},
+		{
+			transformer:              PhoneNormalizeTransformer{DefaultRegion: "US"},
+			value:                    "555-0143",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '555-0143' to phone number.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("PhoneNormalizeTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package jsonmanu
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// CSVOptions holds the configuration of the CSV ingestion.
+type CSVOptions struct {
+
+	// Comma is the field delimiter, i.e. `,` or `\t` for TSV. Defaults to `,`.
+	Comma rune
+
+	// DetectTypes determines whether numerical and boolean values will be converted to float64 and bool respectively.
+	// Otherwise all values are kept as strings unless a type is provided in ColumnTypes.
+	DetectTypes bool
+
+	// ColumnTypes holds the type of specific columns by their header name. Supported types are `string`, `number`
+	// and `boolean`. Column types take precedence over the auto detection.
+	ColumnTypes map[string]string
+}
+
+// parseFiniteFloat converts a string to a float64 which can be encoded to JSON, i.e. `NaN` and `Inf` are rejected.
+func parseFiniteFloat(s string) (float64, error) {
+	fv, err := strconv.ParseFloat(s, 64)
+	if err == nil && (math.IsNaN(fv) || math.IsInf(fv, 0)) {
+		err = fmt.Errorf("Value '%v' is not a finite number.", s)
+	}
+
+	return fv, err
+}
+
+// detectCSVValue converts a CSV value to float64 or bool if possible, otherwise it returns it as is. Values such as
+// `NaN` and `Inf` are kept as strings since they cannot be encoded to JSON as numbers.
+func detectCSVValue(value string) any {
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+
+	if fv, err := parseFiniteFloat(value); err == nil {
+		return fv
+	}
+
+	return value
+}
+
+// convertCSVValue converts a CSV value to the provided type.
+func convertCSVValue(value string, valueType string) (any, error) {
+	switch valueType {
+	case "string":
+		return value, nil
+	case "number":
+		fv, err := parseFiniteFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't convert value '%v' to number.", value)
+		}
+		return fv, nil
+	case "boolean":
+		bv, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't convert value '%v' to boolean.", value)
+		}
+		return bv, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported column type '%v'", valueType)
+}
+
+// FromCSV reads CSV data out of `r` and converts each row to a map keyed by the column names found in the header row.
+//
+// By default all values are strings. Use the options to have them converted to numbers or booleans.
+//
+// An error is returned if the data is malformed or if a value cannot be converted to the type of its column.
+func FromCSV(r io.Reader, opts CSVOptions) ([]map[string]any, error) {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []map[string]any
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]any, len(header))
+		for j, column := range header {
+			var value any = record[j]
+
+			if valueType, ok := opts.ColumnTypes[column]; ok {
+				if value, err = convertCSVValue(record[j], valueType); err != nil {
+					return nil, fmt.Errorf("Row[%v] column '%v': %w", i, column, err)
+				}
+			} else if opts.DetectTypes {
+				value = detectCSVValue(record[j])
+			}
+
+			row[column] = value
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,108 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type FromCSVTestCase struct {
+	input                string
+	opts                 CSVOptions
+	expectedRows         []map[string]any
+	expectedErrorMessage string
+}
+
+func TestFromCSV(t *testing.T) {
+	testCases := []FromCSVTestCase{
+		{
+			input: "author,price,available\nNietzsche,15,true\nStirner,10.5,false\n",
+			opts:  CSVOptions{},
+			expectedRows: []map[string]any{
+				{"author": "Nietzsche", "price": "15", "available": "true"},
+				{"author": "Stirner", "price": "10.5", "available": "false"},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input: "author,price,available\nNietzsche,15,true\nStirner,10.5,false\n",
+			opts:  CSVOptions{DetectTypes: true},
+			expectedRows: []map[string]any{
+				{"author": "Nietzsche", "price": 15.0, "available": true},
+				{"author": "Stirner", "price": 10.5, "available": false},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input: "isbn\tprice\n0001\t15\n",
+			opts:  CSVOptions{Comma: '\t', DetectTypes: true, ColumnTypes: map[string]string{"isbn": "string"}},
+			expectedRows: []map[string]any{
+				{"isbn": "0001", "price": 15.0},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input: "author,available\nNietzsche,1\nStirner,F\n",
+			opts:  CSVOptions{ColumnTypes: map[string]string{"available": "boolean"}},
+			expectedRows: []map[string]any{
+				{"author": "Nietzsche", "available": true},
+				{"author": "Stirner", "available": false},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input: "name,score\nNaN,nan\nInf,-Infinity\n",
+			opts:  CSVOptions{DetectTypes: true},
+			expectedRows: []map[string]any{
+				{"name": "NaN", "score": "nan"},
+				{"name": "Inf", "score": "-Infinity"},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:                "author,price\nNietzsche,Inf\n",
+			opts:                 CSVOptions{ColumnTypes: map[string]string{"price": "number"}},
+			expectedRows:         nil,
+			expectedErrorMessage: "Row[0] column 'price': Couldn't convert value 'Inf' to number.",
+		},
+		{
+			input:                "author,price\nNietzsche,cheap\n",
+			opts:                 CSVOptions{ColumnTypes: map[string]string{"price": "number"}},
+			expectedRows:         nil,
+			expectedErrorMessage: "Row[0] column 'price': Couldn't convert value 'cheap' to number.",
+		},
+		{
+			input:                "author,price\nNietzsche,15\n",
+			opts:                 CSVOptions{ColumnTypes: map[string]string{"price": "money"}},
+			expectedRows:         nil,
+			expectedErrorMessage: "Row[0] column 'price': Unsupported column type 'money'",
+		},
+		{
+			input:                "author,price\nNietzsche\n",
+			opts:                 CSVOptions{},
+			expectedRows:         nil,
+			expectedErrorMessage: "record on line 2: wrong number of fields",
+		},
+		{
+			input:                "",
+			opts:                 CSVOptions{},
+			expectedRows:         nil,
+			expectedErrorMessage: "",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - FromCSV(%v)=%v", i, tc.input, tc.expectedErrorMessage), func(t *testing.T) {
+			rows, err := FromCSV(strings.NewReader(tc.input), tc.opts)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedRows, rows) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedRows), gu.Prettify(rows))
+			}
+		})
+	}
+}
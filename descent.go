@@ -0,0 +1,210 @@
+package jsonmanu
+
+import (
+	"sync"
+)
+
+// deepSearchTasksPerWorker is the number of the subtrees a parallel recursive descent is split to per worker so that
+// the workers stay busy even if the subtrees differ in size.
+const deepSearchTasksPerWorker = 4
+
+// deepCollector appends a value found by a recursive descent to the result.
+type deepCollector func(result []any, found any) []any
+
+// collectFlattened appends the value found, or its elements flattened if it is an array, to the result.
+func collectFlattened(result []any, found any) []any {
+	if items, ok := found.([]any); ok {
+		return append(result, flattenSlices(items)...)
+	}
+
+	return append(result, found)
+}
+
+// planDeepSearch returns how the values found by a recursive descent for the node are collected. Filters are applied
+// while collecting so that only the matching elements are kept instead of collecting all of them and filtering
+// afterwards, in which case it returns true since nothing is left for the node to apply.
+func planDeepSearch(n nodeDataAccessor) (deepCollector, bool) {
+	filter, ok := n.(arrayFilteredNode)
+	if !ok {
+		return collectFlattened, false
+	}
+
+	collect := func(result []any, found any) []any {
+		items, ok := found.([]any)
+		if !ok {
+			items = []any{found}
+		}
+
+		for _, item := range flattenSlices(items) {
+			itemMap, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if value, ok := itemMap[filter.key]; ok && filter.satisfiedBy(itemMap, value) {
+				result = append(result, item)
+			}
+		}
+
+		return result
+	}
+
+	return collect, true
+}
+
+// deepSearch collects to the result the values of the provided key found in the value or nested in it. The objects
+// having the key are not searched any further and the keys of the rest are visited in lexicographical order.
+func deepSearch(value any, key string, collect deepCollector, result []any) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		if found, ok := v[key]; ok {
+			return collect(result, found)
+		}
+		for _, k := range sortedKeys(v) {
+			result = deepSearch(v[k], key, collect, result)
+		}
+	case []any:
+		for _, item := range v {
+			result = deepSearch(item, key, collect, result)
+		}
+	}
+
+	return result
+}
+
+// visitFlattened reports the value, or its elements flattened if it is an array, to the visit function. It stops as
+// soon as the visit function returns false, in which case it returns false as well.
+func visitFlattened(value any, visit func(any) bool) bool {
+	items, ok := value.([]any)
+	if !ok {
+		return visit(value)
+	}
+
+	for _, item := range items {
+		if !visitFlattened(item, visit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// visitDeep reports the values of the provided key found in the value or nested in it to the visit function, in the
+// order deepSearch collects them with collectFlattened, without collecting them first. It stops as soon as the visit
+// function returns false, in which case it returns false as well.
+func visitDeep(value any, key string, visit func(any) bool) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		if found, ok := v[key]; ok {
+			return visitFlattened(found, visit)
+		}
+		for _, k := range sortedKeys(v) {
+			if !visitDeep(v[k], key, visit) {
+				return false
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if !visitDeep(item, key, visit) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// deepSearchTask is a subtree searched by a worker of a parallel recursive descent, or a value already found.
+type deepSearchTask struct {
+	value any
+	found bool
+}
+
+// search returns the values of the provided key found in the subtree.
+func (t deepSearchTask) search(key string, collect deepCollector) []any {
+	if t.found {
+		return collect(nil, t.value)
+	}
+
+	return deepSearch(t.value, key, collect, nil)
+}
+
+// splitDeepSearchTasks replaces the objects and the arrays of the tasks with their values in the order deepSearch
+// visits them. It returns false if there was nothing to split.
+func splitDeepSearchTasks(tasks []deepSearchTask, key string) ([]deepSearchTask, bool) {
+	var split []deepSearchTask
+	splitAny := false
+
+	add := func(value any) {
+		switch value.(type) {
+		case map[string]any, []any:
+			split = append(split, deepSearchTask{value: value})
+		}
+	}
+
+	for _, task := range tasks {
+		if task.found {
+			split = append(split, task)
+			continue
+		}
+
+		switch v := task.value.(type) {
+		case map[string]any:
+			splitAny = true
+			if found, ok := v[key]; ok {
+				split = append(split, deepSearchTask{value: found, found: true})
+				continue
+			}
+			for _, k := range sortedKeys(v) {
+				add(v[k])
+			}
+		case []any:
+			splitAny = true
+			for _, item := range v {
+				add(item)
+			}
+		}
+	}
+
+	return split, splitAny
+}
+
+// parallelDeepSearch works like deepSearch but the value is split to subtrees which are searched by the provided
+// number of workers. The values found are merged in the order deepSearch finds them so the result does not depend on
+// the scheduling of the workers.
+func parallelDeepSearch(value any, key string, collect deepCollector, workers int) []any {
+	tasks := []deepSearchTask{{value: value}}
+	for len(tasks) < workers*deepSearchTasksPerWorker {
+		split, ok := splitDeepSearchTasks(tasks, key)
+		if !ok {
+			break
+		}
+		tasks = split
+	}
+
+	results := make([][]any, len(tasks))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(tasks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				results[index] = tasks[index].search(key, collect)
+			}
+		}()
+	}
+
+	for index := range tasks {
+		indices <- index
+	}
+	close(indices)
+	wg.Wait()
+
+	var merged []any
+	for _, result := range results {
+		merged = append(merged, result...)
+	}
+
+	return merged
+}
@@ -0,0 +1,119 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+// parallelTestData generates a tree of stores with nested books, some of them with prices nested in their prices.
+func parallelTestData() map[string]any {
+	stores := []any{}
+	for i := 0; i < 50; i++ {
+		books := []any{}
+		for j := 0; j < 20; j++ {
+			book := map[string]any{"title": fmt.Sprintf("Book%v-%v", i, j), "price": float64(i*100 + j)}
+			if j%5 == 0 {
+				book["price"] = map[string]any{"price": -1.0, "amount": []any{float64(j), []any{float64(-j)}}}
+			}
+			books = append(books, book)
+		}
+		stores = append(stores, map[string]any{"name": fmt.Sprintf("Store%v", i), "books": books, "meta": map[string]any{"tags": []any{"a", "b"}}})
+	}
+
+	return map[string]any{"stores": stores, "owner": map[string]any{"name": "Someone"}}
+}
+
+func TestGetWithWorkers(t *testing.T) {
+	data := parallelTestData()
+
+	testCases := []struct {
+		jsonPath string
+		workers  int
+	}{
+		{jsonPath: "$..price", workers: 2},
+		{jsonPath: "$..price", workers: 8},
+		{jsonPath: "$..title", workers: 3},
+		{jsonPath: "$..tags", workers: 4},
+		{jsonPath: "$..missing", workers: 4},
+		{jsonPath: "$..books[0]", workers: 4},
+		{jsonPath: "$..books[?(@.title == 'Book7-3')].price", workers: 16},
+		{jsonPath: "$..name", workers: 1000},
+		{jsonPath: "$..stores", workers: 4},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - GetWithOptions(%v, Workers: %v)", i, tc.jsonPath, tc.workers), func(t *testing.T) {
+			expected, expectedErr := Get(data, tc.jsonPath)
+			result, err := GetWithOptions(data, tc.jsonPath, GetOptions{Workers: tc.workers})
+			if fmt.Sprint(expectedErr) != fmt.Sprint(err) {
+				t.Fatalf("Expected error '%v', but got '%v'", expectedErr, err)
+			}
+			if !cmp.Equal(expected, result) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expected), gu.Prettify(result))
+			}
+
+			again, _ := GetWithOptions(data, tc.jsonPath, GetOptions{Workers: tc.workers})
+			if !cmp.Equal(result, again) {
+				t.Errorf("Expected the same order, but got '%v' and '%v'", result, again)
+			}
+		})
+	}
+
+	// the values are merged in the order of a sequential search
+	result, err := GetWithOptions(data, "$..price", GetOptions{Workers: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := (deepSearch(data, "price", collectFlattened, nil)); !cmp.Equal(expected, result) {
+		t.Errorf("Expected '%v', but got '%v'", expected, result)
+	}
+}
+
+func TestGetWithFilteredRecursiveDescent(t *testing.T) {
+	data := parallelTestData()
+
+	jsonPaths := []string{
+		"$..books[?(@.price < 430)]",
+		"$..books[?(@.price >= 4915)].title",
+		"$..books[?(@.isbn)]",
+		"$..books[?(@.title ~= 'Book7-3')]",
+		"$..books[?(@.price)].price",
+		"$..amount[?(@.price)]",
+		"$.owner..books[?(@.price)]",
+	}
+
+	for i, jsonPath := range jsonPaths {
+		t.Run(fmt.Sprintf("(%v) - Get(%v)", i, jsonPath), func(t *testing.T) {
+			// the values are matched one by one without the planning of Get
+			expected, err := GetWithOptions(data, jsonPath, GetOptions{Limit: 1 << 30})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for _, workers := range []int{1, 4} {
+				result, err := GetWithOptions(data, jsonPath, GetOptions{Workers: workers})
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				// Limit returns an empty list whereas the filters return nil if nothing matches
+				if items, ok := result.([]any); ok && items == nil {
+					result = []any{}
+				}
+				if !cmp.Equal(expected, result) {
+					t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expected), gu.Prettify(result))
+				}
+			}
+		})
+	}
+
+	_, trace, err := GetWithTrace(data, "$..books[?(@.price < 10)]")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if last := trace[len(trace)-1]; last.Branch != traceBranchFilteredDescent || last.Matches != 8 {
+		t.Errorf("Expected a filtered recursive descent with 8 matches, but got %+v", last)
+	}
+}
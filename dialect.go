@@ -0,0 +1,205 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dialect is a notation of paths other than JSONPath which is converted to JSONPath before use.
+type Dialect string
+
+const (
+	// DialectJsonPath is the JSONPath notation, i.e. `$.store.books[0].author`. It is the default one.
+	DialectJsonPath Dialect = "jsonpath"
+
+	// DialectDot is the JSONPath notation without the leading `$.`, i.e. `store.books[0].author`.
+	DialectDot Dialect = "dot"
+
+	// DialectGJSON is the notation of gjson where array indices are keys, i.e. `store.books.0.author`, and `#`
+	// stands for all the elements of an array, i.e. `store.books.#.author`.
+	DialectGJSON Dialect = "gjson"
+
+	// DialectXPath is a subset of XPath where the steps are separated by `/`, `//` stands for recursive descent and
+	// predicates are either 1-based indices, i.e. `/store/books[1]`, or conditions on a child of the elements, i.e.
+	// `/store/books[price>10]/author` or `/store/books[isbn]`.
+	DialectXPath Dialect = "xpath"
+)
+
+// gjsonIndexPattern matches the segments of a gjson path which refer to array elements.
+var gjsonIndexPattern = regexp.MustCompile(`^(\d+|#)$`)
+
+// gjsonToJsonPath converts a path of DialectGJSON to JSONPath.
+func gjsonToJsonPath(path string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("$")
+
+	indexable := false
+	for _, segment := range strings.Split(path, ".") {
+		switch {
+		case segment == "":
+			return "", &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("Empty segment in path '%v'", path), Path: path}
+		case gjsonIndexPattern.MatchString(segment):
+			if !indexable {
+				return "", &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("Index '%v' must follow a key", segment), Path: path, Segment: segment}
+			}
+			if segment == "#" {
+				segment = "*"
+			}
+			sb.WriteString("[" + segment + "]")
+			indexable = false
+		default:
+			sb.WriteString("." + segment)
+			indexable = segment != "*"
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// The patterns of the steps of a path of DialectXPath and their predicates.
+var (
+	xpathStepPattern            = regexp.MustCompile(`^(?P<name>\*|\w+)(\[(?P<predicate>.*)\])?$`)
+	xpathIndexPredicatePattern  = regexp.MustCompile(`^\s*(\d+)\s*$`)
+	xpathFilterPredicatePattern = regexp.MustCompile(`^\s*@?(\w+)\s*(?:(!=|<=|>=|=|<|>)\s*('[^']*'|"[^"]*"|-?[\d.]+|@?\w+)\s*)?$`)
+	xpathNumberPattern          = regexp.MustCompile(`^-?[\d.]+$`)
+)
+
+// splitXPath splits a path of DialectXPath in its steps ignoring the `/` within predicates. The empty steps stand
+// for `//`.
+func splitXPath(path string) []string {
+	var steps []string
+
+	start, depth, quote := 0, 0, rune(0)
+	for i, c := range path {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '/' && depth == 0:
+			steps = append(steps, path[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(steps, path[start:])
+}
+
+// xpathPredicate converts the predicate of a step of DialectXPath to the corresponding JSONPath array node suffix.
+func xpathPredicate(predicate string) (string, bool) {
+	if m := xpathIndexPredicatePattern.FindStringSubmatch(predicate); m != nil {
+		index, err := strconv.Atoi(m[1])
+		if err != nil || index < 1 {
+			return "", false
+		}
+		return fmt.Sprintf("[%v]", index-1), true
+	}
+
+	m := xpathFilterPredicatePattern.FindStringSubmatch(predicate)
+	if m == nil {
+		return "", false
+	}
+
+	key, op, value := m[1], m[2], m[3]
+	if op == "" {
+		return fmt.Sprintf("[?(@.%v)]", key), true
+	}
+
+	if op == "=" {
+		op = "=="
+	}
+
+	// unquoted names refer to children of the same element as in XPath
+	if !strings.HasPrefix(value, "'") && !strings.HasPrefix(value, `"`) && !xpathNumberPattern.MatchString(value) {
+		value = "@." + strings.TrimPrefix(value, "@")
+	}
+
+	return fmt.Sprintf("[?(@.%v %v %v)]", key, op, value), true
+}
+
+// xpathToJsonPath converts a path of DialectXPath to JSONPath.
+func xpathToJsonPath(path string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("$")
+
+	steps := splitXPath(path)
+	if len(steps) > 1 && steps[0] == "" {
+		steps = steps[1:]
+	}
+
+	recursive := false
+	for i, step := range steps {
+		if step == "" {
+			if recursive || i == len(steps)-1 {
+				return "", &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("Empty step in path '%v'", path), Path: path}
+			}
+			recursive = true
+			continue
+		}
+
+		invalidStep := &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("Unsupported step '%v'", step), Path: path, Segment: step}
+
+		m := xpathStepPattern.FindStringSubmatch(step)
+		if m == nil {
+			return "", invalidStep
+		}
+
+		if recursive {
+			sb.WriteString("..")
+		} else {
+			sb.WriteString(".")
+		}
+		sb.WriteString(m[1])
+
+		if m[2] != "" {
+			predicate, ok := xpathPredicate(m[3])
+			if !ok || m[1] == "*" {
+				return "", invalidStep
+			}
+			sb.WriteString(predicate)
+		}
+
+		recursive = false
+	}
+
+	return sb.String(), nil
+}
+
+// ToJsonPath converts a path of the provided dialect to the equivalent JSONPath so that it can be used with the rest
+// of the API. The resulting JSONPath is validated.
+func ToJsonPath(path string, dialect Dialect) (string, error) {
+	var jsonPath string
+
+	switch dialect {
+	case "", DialectJsonPath:
+		jsonPath = path
+	case DialectDot:
+		jsonPath = "$." + path
+	case DialectGJSON:
+		var err error
+		if jsonPath, err = gjsonToJsonPath(path); err != nil {
+			return "", err
+		}
+	case DialectXPath:
+		var err error
+		if jsonPath, err = xpathToJsonPath(path); err != nil {
+			return "", err
+		}
+	default:
+		return "", &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("Unknown path dialect '%v'", dialect), Path: path}
+	}
+
+	if _, err := parseJsonPath(jsonPath); err != nil {
+		return "", err
+	}
+
+	return jsonPath, nil
+}
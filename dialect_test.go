@@ -0,0 +1,95 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type ToJsonPathTestCase struct {
+	path                 string
+	dialect              Dialect
+	expectedJsonPath     string
+	expectedErrorMessage string
+}
+
+func TestToJsonPath(t *testing.T) {
+	testCases := []ToJsonPathTestCase{
+		{path: "$.store.books[0].author", expectedJsonPath: "$.store.books[0].author"},
+		{path: "$.store.books[0].author", dialect: DialectJsonPath, expectedJsonPath: "$.store.books[0].author"},
+		{path: "store.books[0].author", dialect: DialectDot, expectedJsonPath: "$.store.books[0].author"},
+		{path: "store.books.0.author", dialect: DialectGJSON, expectedJsonPath: "$.store.books[0].author"},
+		{path: "store.books.#.author", dialect: DialectGJSON, expectedJsonPath: "$.store.books[*].author"},
+		{path: "store.books.#", dialect: DialectGJSON, expectedJsonPath: "$.store.books[*]"},
+		{path: "store.*.name", dialect: DialectGJSON, expectedJsonPath: "$.store.*.name"},
+		{path: "0.author", dialect: DialectGJSON, expectedErrorMessage: "Index '0' must follow a key"},
+		{path: "matrix.0.1", dialect: DialectGJSON, expectedErrorMessage: "Index '1' must follow a key"},
+		{path: "store..books", dialect: DialectGJSON, expectedErrorMessage: "Empty segment in path 'store..books'"},
+		{path: "store.books[0", dialect: DialectDot, expectedErrorMessage: "Couldn't parse JSONPath substring 1: 'books[0'"},
+		{path: "/store/books[price>10]/author", dialect: DialectXPath, expectedJsonPath: "$.store.books[?(@.price > 10)].author"},
+		{path: "store/books[@author = 'F. Nietzsche']/title", dialect: DialectXPath, expectedJsonPath: "$.store.books[?(@.author == 'F. Nietzsche')].title"},
+		{path: "/store/books[price < listPrice]", dialect: DialectXPath, expectedJsonPath: "$.store.books[?(@.price < @.listPrice)]"},
+		{path: "/store/books[isbn]", dialect: DialectXPath, expectedJsonPath: "$.store.books[?(@.isbn)]"},
+		{path: "/store/books[1]/author", dialect: DialectXPath, expectedJsonPath: "$.store.books[0].author"},
+		{path: "//books[url='http://a/b']/title", dialect: DialectXPath, expectedJsonPath: "$..books[?(@.url == 'http://a/b')].title"},
+		{path: "/store/*/name", dialect: DialectXPath, expectedJsonPath: "$.store.*.name"},
+		{path: "/store//author", dialect: DialectXPath, expectedJsonPath: "$.store..author"},
+		{path: "/store/books[0]", dialect: DialectXPath, expectedErrorMessage: "Unsupported step 'books[0]'"},
+		{path: "/store/books[last()]", dialect: DialectXPath, expectedErrorMessage: "Unsupported step 'books[last()]'"},
+		{path: "/store/", dialect: DialectXPath, expectedErrorMessage: "Empty step in path '/store/'"},
+		{path: "store.books", dialect: "jq", expectedErrorMessage: "Unknown path dialect 'jq'"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ToJsonPath(%v, %v)=%v", i, tc.path, tc.dialect, tc.expectedJsonPath), func(t *testing.T) {
+			jsonPath, err := ToJsonPath(tc.path, tc.dialect)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if jsonPath != tc.expectedJsonPath {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedJsonPath, jsonPath)
+			}
+		})
+	}
+}
+
+func TestDialectOptions(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche"},
+				map[string]any{"author": "Stirner"},
+			},
+		},
+	}
+
+	result, err := GetWithOptions(data, "store.books.#.author", GetOptions{Dialect: DialectGJSON})
+	if expected := []any{"Nietzsche", "Stirner"}; err != nil || !cmp.Equal(expected, result) {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, result, err)
+	}
+
+	config := MappingConfig{
+		Dialect: DialectGJSON,
+		Mappers: []MapperConfig{{Src: "store.books.1.author", Dst: "authors.first"}},
+	}
+	mappers, err := config.ToMappers()
+	if expected := []Mapper{{SrcJsonPath: "$.store.books[1].author", DstJsonPath: "$.authors.first"}}; err != nil || !cmp.Equal(expected, mappers) {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, mappers, err)
+	}
+
+	config = MappingConfig{
+		Dialect: DialectXPath,
+		Mappers: []MapperConfig{{Src: "/store/books[author='Stirner']/author", Dst: "/authors"}},
+	}
+	mappers, err = config.ToMappers()
+	if expected := []Mapper{{SrcJsonPath: "$.store.books[?(@.author == 'Stirner')].author", DstJsonPath: "$.authors"}}; err != nil || !cmp.Equal(expected, mappers) {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, mappers, err)
+	}
+
+	config.Dialect = DialectGJSON
+	config.Mappers[0] = MapperConfig{Src: "store", Dst: "0"}
+	if _, err := config.ToMappers(); err == nil || err.Error() != "Mapper[0]: Index '0' must follow a key" {
+		t.Errorf("Expected error message 'Mapper[0]: Index '0' must follow a key', but got '%v'", err)
+	}
+}
@@ -0,0 +1,140 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// diffKeyPattern matches the keys which can be addressed by a JSONPath node.
+var diffKeyPattern = regexp.MustCompile(`^\w+$`)
+
+// addressableKeys returns whether all the keys of the objects can be addressed by a JSONPath node.
+func addressableKeys(objs ...map[string]any) bool {
+	for _, obj := range objs {
+		for key := range obj {
+			if !diffKeyPattern.MatchString(key) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// diffObjects appends to the changeset the operations which turn the old object of the path into the new one.
+func diffObjects(path string, old, new map[string]any, changeset Changeset) Changeset {
+	keys := make(map[string]any, len(old)+len(new))
+	for key := range old {
+		keys[key] = nil
+	}
+	for key := range new {
+		keys[key] = nil
+	}
+
+	for _, key := range sortedKeys(keys) {
+		keyPath := path + "." + key
+		oldValue, oldExists := old[key]
+		newValue, newExists := new[key]
+
+		switch {
+		case !newExists:
+			changeset = append(changeset, Operation{Path: keyPath, Op: OperationDelete, Old: oldValue, OldExists: true})
+		case !oldExists:
+			changeset = append(changeset, Operation{Path: keyPath, Op: OperationPut, New: newValue})
+		default:
+			changeset = diffValues(keyPath, oldValue, newValue, true, changeset)
+		}
+	}
+
+	return changeset
+}
+
+// diffValues appends to the changeset the operations which turn the old value of the path into the new one.
+// The elements of arrays are compared one by one if the path ends with a key and the arrays are of the same length.
+func diffValues(path string, old, new any, indexable bool, changeset Changeset) Changeset {
+	oldObj, oldIsObj := old.(map[string]any)
+	newObj, newIsObj := new.(map[string]any)
+	if oldIsObj && newIsObj && addressableKeys(oldObj, newObj) {
+		return diffObjects(path, oldObj, newObj, changeset)
+	}
+
+	oldItems, oldIsArray := old.([]any)
+	newItems, newIsArray := new.([]any)
+	if indexable && oldIsArray && newIsArray && len(oldItems) == len(newItems) {
+		for i := range oldItems {
+			changeset = diffValues(fmt.Sprintf("%v[%v]", path, i), oldItems[i], newItems[i], false, changeset)
+		}
+		return changeset
+	}
+
+	if valuesEqual(old, new) {
+		return changeset
+	}
+
+	return append(changeset, Operation{Path: path, Op: OperationPut, Old: old, OldExists: true, New: new})
+}
+
+// Diff returns the changeset which turns the old data into the new data when applied. The operations are as fine
+// grained as possible, i.e. a changed leaf is put rather than its parent object, and they are ordered by path.
+//
+// Arrays of different length are put as a whole and so are objects with keys which cannot be addressed by a
+// JSONPath node, i.e. `first name`. The values of the changeset are shared with the data.
+func Diff(old, new map[string]any) Changeset {
+	return diffValues("$", old, new, false, nil)
+}
+
+// pathsOverlap returns whether a change in the JSONPath of the change nodes, as found in a Changeset, may affect the
+// values retrieved by the JSONPath of the query nodes. It errs on the side of overlapping, i.e. on recursive descent.
+func pathsOverlap(query []nodeDataAccessor, change []nodeDataAccessor) bool {
+	if len(query) == 0 || len(change) == 0 {
+		return true
+	}
+
+	q := query[0]
+	if q.getName() == "*" {
+		return pathsOverlap(query[1:], change)
+	}
+	if q.getName() == "" {
+		return true
+	}
+
+	c := change[0]
+	if c.getName() == "*" {
+		return pathsOverlap(query, change[1:])
+	}
+	if c.getName() == "" {
+		return true
+	}
+	if q.getName() != c.getName() {
+		return false
+	}
+
+	// a change within an element may change whether it is filtered
+	if _, ok := q.(arrayFilteredNode); ok && len(change) > 1 {
+		return true
+	}
+
+	return pathsOverlap(query[1:], change[1:])
+}
+
+// ChangedPathsOverlap returns whether the JSONPath may retrieve different values after the changes of the changeset.
+// It errs on the side of overlapping, i.e. a JSONPath with recursive descent overlaps with any change.
+func ChangedPathsOverlap(jsonPath string, changeset Changeset) (bool, error) {
+	query, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return false, err
+	}
+
+	for _, op := range changeset {
+		change, err := parseJsonPath(op.Path)
+		if err != nil {
+			return true, nil
+		}
+
+		if pathsOverlap(query, change) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
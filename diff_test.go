@@ -0,0 +1,148 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type DiffTestCase struct {
+	old               map[string]any
+	new               map[string]any
+	expectedChangeset Changeset
+}
+
+func TestDiff(t *testing.T) {
+	testCases := []DiffTestCase{
+		{
+			old:               map[string]any{"a": 1, "b": map[string]any{"c": "x"}},
+			new:               map[string]any{"a": 1.0, "b": map[string]any{"c": "x"}},
+			expectedChangeset: nil,
+		},
+		{
+			old: map[string]any{"a": 1, "b": map[string]any{"c": "x", "d": true}, "e": "gone"},
+			new: map[string]any{"a": 2, "b": map[string]any{"c": "y", "d": true}, "f": "added"},
+			expectedChangeset: Changeset{
+				{Path: "$.a", Op: OperationPut, Old: 1, OldExists: true, New: 2},
+				{Path: "$.b.c", Op: OperationPut, Old: "x", OldExists: true, New: "y"},
+				{Path: "$.e", Op: OperationDelete, Old: "gone", OldExists: true},
+				{Path: "$.f", Op: OperationPut, New: "added"},
+			},
+		},
+		{
+			old: map[string]any{"books": []any{map[string]any{"price": 10}, map[string]any{"price": 20}}, "tags": []any{"a"}},
+			new: map[string]any{"books": []any{map[string]any{"price": 10}, map[string]any{"price": 25}}, "tags": []any{"a", "b"}},
+			expectedChangeset: Changeset{
+				{Path: "$.books[1].price", Op: OperationPut, Old: 20, OldExists: true, New: 25},
+				{Path: "$.tags", Op: OperationPut, Old: []any{"a"}, OldExists: true, New: []any{"a", "b"}},
+			},
+		},
+		{
+			old: map[string]any{"names": map[string]any{"first name": "F"}},
+			new: map[string]any{"names": map[string]any{"first name": "M"}},
+			expectedChangeset: Changeset{
+				{Path: "$.names", Op: OperationPut, Old: map[string]any{"first name": "F"}, OldExists: true, New: map[string]any{"first name": "M"}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Diff(%v, %v)", i, tc.old, tc.new), func(t *testing.T) {
+			changeset := Diff(tc.old, tc.new)
+			if !cmp.Equal(tc.expectedChangeset, changeset) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedChangeset), gu.Prettify(changeset))
+			}
+
+			data := deepCopy(tc.old).(map[string]any)
+			if err := changeset.Apply(data); err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+			if len(Diff(data, tc.new)) > 0 {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.new), gu.Prettify(data))
+			}
+		})
+	}
+}
+
+type ChangedPathsOverlapTestCase struct {
+	jsonPath        string
+	changedPath     string
+	expectedOverlap bool
+}
+
+func TestChangedPathsOverlap(t *testing.T) {
+	testCases := []ChangedPathsOverlapTestCase{
+		{"$.store.name", "$.store.name", true},
+		{"$.store", "$.store.name", true},
+		{"$.store.name", "$.store", true},
+		{"$.store.name", "$.store.owner", false},
+		{"$.store.books.title", "$.store.books[1].title", true},
+		{"$.store.books.title", "$.store.books[1].price", false},
+		{"$.store.books[0].title", "$.store.books[1].title", true},
+		{"$.store.books[?(@.price < 10)].title", "$.store.books[1].price", true},
+		{"$..title", "$.store.owner", true},
+		{"$.store.*.name", "$.store.name", true},
+		{"$.store.owner", "$..name", true},
+		{"$.store.owner", "$.store.*.name", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ChangedPathsOverlap(%v, %v)=%v", i, tc.jsonPath, tc.changedPath, tc.expectedOverlap), func(t *testing.T) {
+			overlap, err := ChangedPathsOverlap(tc.jsonPath, Changeset{{Path: tc.changedPath, Op: OperationPut}})
+			if err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+			if overlap != tc.expectedOverlap {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedOverlap, overlap)
+			}
+		})
+	}
+}
+
+// countingTransformer counts the values it transforms.
+type countingTransformer struct {
+	count *int
+}
+
+func (t countingTransformer) Transform(value any) (any, error) {
+	*t.count++
+	return value, nil
+}
+
+func TestMapIncremental(t *testing.T) {
+	var titles, prices int
+	mappers := []Mapper{
+		{SrcJsonPath: "$.books.title", DstJsonPath: "$.titles", Transformations: []Transformation{{Trsnfmr: countingTransformer{&titles}, AsArray: true}}},
+		{SrcJsonPath: "$.books.price", DstJsonPath: "$.prices", Transformations: []Transformation{{Trsnfmr: countingTransformer{&prices}, AsArray: true}}},
+	}
+
+	prevSrc := map[string]any{"books": []any{map[string]any{"title": "Book1", "price": 10}}}
+	dst := make(map[string]any)
+	if errs := Map(prevSrc, dst, mappers); len(errs) > 0 {
+		t.Fatalf("Expected no errors, but got '%v'", errs)
+	}
+
+	src := map[string]any{"books": []any{map[string]any{"title": "Book1", "price": 12}}}
+	if errs := MapIncremental(prevSrc, src, dst, mappers); len(errs) > 0 {
+		t.Fatalf("Expected no errors, but got '%v'", errs)
+	}
+
+	expectedDst := map[string]any{"titles": []any{"Book1"}, "prices": []any{12}}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+	if titles != 1 || prices != 2 {
+		t.Errorf("Expected the titles mapper to run once and the prices one twice, but got %v and %v", titles, prices)
+	}
+
+	if errs := MapIncremental(src, src, dst, mappers); len(errs) > 0 || titles != 1 || prices != 2 {
+		t.Errorf("Expected no mapper to run, but got '%v' (%v, %v)", errs, titles, prices)
+	}
+
+	errs := MapIncremental(src, map[string]any{"books": []any{map[string]any{"title": "Book1"}}}, dst, mappers)
+	if len(errs) != 1 || errs[0].(*MapperError).Index != 1 {
+		t.Errorf("Expected one error of Mapper[1], but got '%v'", errs)
+	}
+}
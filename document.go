@@ -0,0 +1,432 @@
+package jsonmanu
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// OperationType is the type of a recorded mutation.
+type OperationType string
+
+const (
+	OperationPut    OperationType = "put"
+	OperationDelete OperationType = "delete"
+)
+
+// Operation describes a single mutation applied on a Document.
+type Operation struct {
+
+	// Path is the JSONPath the mutation applied on.
+	Path string `json:"path"`
+
+	// Op is the type of the mutation.
+	Op OperationType `json:"op"`
+
+	// Old is the value found in Path before the mutation.
+	Old any `json:"old,omitempty"`
+
+	// OldExists determines whether Path existed before the mutation.
+	OldExists bool `json:"oldExists"`
+
+	// New is the value put in Path. It is always nil for delete operations.
+	New any `json:"new,omitempty"`
+
+	// Created is the JSONPath of the outermost value a put on a missing Path changed in order to create it, i.e. `$.a`
+	// for a put on `$.a.b` when `$.a` was missing as well or `$.items` for a put on `$.items[1]` appending to it. It is
+	// empty if Path existed or if it matches multiple values.
+	Created string `json:"created,omitempty"`
+
+	// CreatedOld is the value found in Created before the mutation, i.e. the array an element was appended to.
+	CreatedOld any `json:"createdOld,omitempty"`
+
+	// CreatedExists determines whether Created existed before the mutation.
+	CreatedExists bool `json:"createdExists,omitempty"`
+}
+
+// Changeset is an ordered list of operations.
+type Changeset []Operation
+
+// Apply replays the operations of the changeset on the provided data in their order.
+//
+// It stops and returns the error of the first operation that fails.
+func (c Changeset) Apply(data map[string]any) error {
+	for i, op := range c {
+		var err error
+
+		switch op.Op {
+		case OperationPut:
+			err = Put(data, op.Path, deepCopy(op.New))
+		case OperationDelete:
+			err = Delete(data, op.Path)
+		default:
+			err = &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Unknown operation type '%v'", op.Op), Path: op.Path}
+		}
+
+		if err != nil {
+			return fmt.Errorf("Operation[%v]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Invert returns a changeset that undoes the operations of the changeset when applied.
+//
+// The inversion is exact for paths that address a single value. Paths matching multiple values
+// (i.e. array wildcards or filters) will have all of them restored with the grouped old value.
+//
+// The values created by a put along with its path, i.e. the missing parent objects or the elements appended to an
+// array, are removed by restoring the value of Created.
+func (c Changeset) Invert() Changeset {
+	inverted := make(Changeset, 0, len(c))
+
+	for i := len(c) - 1; i >= 0; i-- {
+		op := c[i]
+
+		if !op.OldExists && op.Op == OperationPut && op.Created != "" {
+			if op.CreatedExists {
+				inverted = append(inverted, Operation{Path: op.Created, Op: OperationPut, OldExists: true, New: op.CreatedOld})
+			} else {
+				inverted = append(inverted, Operation{Path: op.Created, Op: OperationDelete, OldExists: true})
+			}
+			continue
+		}
+
+		if op.OldExists {
+			inverted = append(inverted, Operation{Path: op.Path, Op: OperationPut, Old: op.New, OldExists: op.Op == OperationPut, New: op.Old})
+		} else {
+			inverted = append(inverted, Operation{Path: op.Path, Op: OperationDelete, Old: op.New, OldExists: op.Op == OperationPut})
+		}
+	}
+
+	return inverted
+}
+
+// creationPoint returns the JSONPath of the outermost value a put on the missing JSONPath changes in order to create
+// it, along with its current value and whether it exists. The value is the array itself if the put appends elements
+// to an array. An empty path is returned if the JSONPath exists or does not address a single value.
+func creationPoint(data map[string]any, jsonPath string) (string, any, bool) {
+	tokens := splitJsonPath(jsonPath)
+	if tokens[0] != "$" {
+		return "", nil, false
+	}
+
+	prefix := "$"
+	for i, token := range tokens[1:] {
+		last := i == len(tokens)-2
+
+		name, index := token, -1
+		if match := dstArrayNodePattern.FindStringSubmatch(token); match != nil {
+			n, err := strconv.Atoi(match[2])
+			if err != nil {
+				return "", nil, false
+			}
+			name, index = match[1], n
+		} else if !diffKeyPattern.MatchString(token) {
+			return "", nil, false
+		}
+
+		value, ok := data[name]
+		if !ok && last && index < 0 {
+			// the put creates the value of the JSONPath alone
+			return "", nil, false
+		}
+		if !ok {
+			return prefix + "." + name, nil, false
+		}
+		if value == nil && (index >= 0 || !last) {
+			return prefix + "." + name, nil, true
+		}
+
+		if index >= 0 {
+			items, ok := value.([]any)
+			if !ok {
+				return "", nil, false
+			}
+			if index >= len(items) || (items[index] == nil && !last) {
+				return prefix + "." + name, deepCopy(items), true
+			}
+			value = items[index]
+		}
+
+		if last {
+			break
+		}
+
+		if data, ok = value.(map[string]any); !ok {
+			return "", nil, false
+		}
+		prefix += "." + token
+	}
+
+	return "", nil, false
+}
+
+// putOperation returns the operation of a put of the value on the JSONPath of the data. It must be called before the
+// put takes place.
+func putOperation(data map[string]any, jsonPath string, value any) Operation {
+	old, oldErr := Get(data, jsonPath)
+	op := Operation{Path: jsonPath, Op: OperationPut, Old: deepCopy(old), OldExists: oldErr == nil, New: deepCopy(value)}
+
+	// Get returns no error for the missing elements of an existing array so the creations are found separately
+	if op.Created, op.CreatedOld, op.CreatedExists = creationPoint(data, jsonPath); op.Created != "" {
+		op.Old, op.OldExists = nil, false
+	}
+
+	return op
+}
+
+// Document wraps JSON data and provides the querying and updating functionality on it.
+type Document struct {
+	data map[string]any
+
+	recording bool
+
+	changeset Changeset
+
+	subscriptions []*subscription
+
+	snapshots []*snapshot
+
+	lastSnapshotID SnapshotID
+
+	cache *queryCache
+}
+
+// subscription is a callback notified about the changes of the value(s) of a JSONPath.
+type subscription struct {
+	jsonPath string
+	fn       func(old, new any)
+}
+
+// NewDocument creates a new Document out of the provided data.
+//
+// If `data` is nil an empty map will be used instead.
+func NewDocument(data map[string]any) *Document {
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	return &Document{data: data}
+}
+
+// Data returns the underlying data of the document.
+func (d *Document) Data() map[string]any { return d.data }
+
+// Get retrieves a value out of the document as it is described in the provided JSONPath.
+//
+// The result is memoized if the query cache is on (see EnableQueryCache).
+func (d *Document) Get(jsonPath string) (any, error) {
+	if d.cache != nil {
+		return d.cachedGet(jsonPath)
+	}
+
+	return Get(d.data, jsonPath)
+}
+
+// Put updates the document as it is described in the provided JSONPath with a new value.
+func (d *Document) Put(jsonPath string, value any) error {
+	op := putOperation(d.data, jsonPath, value)
+
+	watched := d.watched()
+	d.preserve(jsonPath)
+	err := Put(d.data, jsonPath, value)
+	d.invalidateCache(jsonPath)
+	if err != nil {
+		return err
+	}
+
+	d.record(op)
+	d.notify(watched)
+
+	return nil
+}
+
+// Delete removes the branch(es) of the document as it is described in the provided JSONPath.
+func (d *Document) Delete(jsonPath string) error {
+	old, oldErr := Get(d.data, jsonPath)
+	old = deepCopy(old)
+
+	watched := d.watched()
+	d.preserve(jsonPath)
+	err := Delete(d.data, jsonPath)
+	d.invalidateCache(jsonPath)
+	if err != nil {
+		return err
+	}
+
+	if oldErr == nil {
+		d.record(Operation{Path: jsonPath, Op: OperationDelete, Old: old, OldExists: true})
+	}
+	d.notify(watched)
+
+	return nil
+}
+
+// Map applies the mappers on the source data with the document as the destination.
+//
+// While recording, the changes of the document are recorded as the operations computed by Diff.
+func (d *Document) Map(src map[string]any, mappers []Mapper) []error {
+	var before map[string]any
+	if d.recording {
+		before = deepCopy(d.data).(map[string]any)
+	}
+
+	watched := d.watched()
+	jsonPaths, ok := mappedPaths(mappers)
+	if ok {
+		d.preserve(jsonPaths...)
+	} else {
+		d.preserveAll()
+	}
+
+	errors := Map(src, d.data, mappers)
+
+	if ok {
+		d.invalidateCache(jsonPaths...)
+	} else {
+		d.clearCache()
+	}
+
+	if d.recording {
+		d.changeset = append(d.changeset, Diff(before, d.data)...)
+	}
+	d.notify(watched)
+
+	return errors
+}
+
+// mappedPaths returns the destination JSONPaths of the mappers. It returns false if any of the mappers has an After
+// hook since it can change any path.
+func mappedPaths(mappers []Mapper) ([]string, bool) {
+	jsonPaths := make([]string, len(mappers))
+	for i, mapper := range mappers {
+		if mapper.After != nil {
+			return nil, false
+		}
+		jsonPaths[i] = mapper.DstJsonPath
+	}
+
+	return jsonPaths, true
+}
+
+// Subscribe registers a callback which is notified with the old and the new value of the provided JSONPath whenever
+// Put, Delete or Map changes it. JSONPaths matching multiple values, i.e. `$.servers[*].port`, notify with the
+// lists of the matched values. Missing values are nil. The notified values are copies.
+//
+// The callbacks are called synchronously after the change in the order they were registered. It returns a function
+// which cancels the subscription.
+func (d *Document) Subscribe(jsonPath string, fn func(old, new any)) (unsubscribe func()) {
+	s := &subscription{jsonPath: jsonPath, fn: fn}
+	d.subscriptions = append(d.subscriptions, s)
+
+	return func() {
+		for i, other := range d.subscriptions {
+			if other == s {
+				d.subscriptions = append(d.subscriptions[:i:i], d.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// watchedValue is the value of a subscribed JSONPath before a change.
+type watchedValue struct {
+	subscription *subscription
+	value        any
+}
+
+// watched returns copies of the current values of the subscribed JSONPaths.
+func (d *Document) watched() []watchedValue {
+	values := make([]watchedValue, len(d.subscriptions))
+	for i, s := range d.subscriptions {
+		value, _ := Get(d.data, s.jsonPath)
+		values[i] = watchedValue{subscription: s, value: deepCopy(value)}
+	}
+
+	return values
+}
+
+// notify calls the subscriptions whose values differ from the watched ones.
+func (d *Document) notify(watched []watchedValue) {
+	for _, w := range watched {
+		value, _ := Get(d.data, w.subscription.jsonPath)
+		if !reflect.DeepEqual(w.value, value) {
+			w.subscription.fn(w.value, deepCopy(value))
+		}
+	}
+}
+
+// StartRecording starts capturing all the mutations applied on the document discarding any previously recorded ones.
+func (d *Document) StartRecording() {
+	d.recording = true
+	d.changeset = nil
+}
+
+// StopRecording stops capturing mutations and returns the ones recorded so far.
+func (d *Document) StopRecording() Changeset {
+	changeset := d.changeset
+
+	d.recording = false
+	d.changeset = nil
+
+	return changeset
+}
+
+// record appends the operation in the changeset if recording is on.
+func (d *Document) record(op Operation) {
+	if d.recording {
+		d.changeset = append(d.changeset, op)
+	}
+}
+
+// Scan implements the sql.Scanner interface so that a document can be read directly out of a JSON database column.
+//
+// A NULL column results in an empty document. Any previously recorded mutations and snapshots are discarded.
+func (d *Document) Scan(src any) error {
+	var raw []byte
+
+	switch v := src.(type) {
+	case nil:
+		d.data = make(map[string]any)
+		d.changeset = nil
+		d.snapshots = nil
+		d.clearCache()
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return &Error{Code: ErrCodeUnsupportedFormat, Message: fmt.Sprintf("Cannot scan %T into Document", src)}
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return err
+	}
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	d.data = data
+	d.changeset = nil
+	d.snapshots = nil
+	d.clearCache()
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface so that a document can be written directly in a JSON database column.
+//
+// A nil document results in NULL.
+func (d *Document) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(d.data)
+}
@@ -0,0 +1,271 @@
+package jsonmanu
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDocumentRecording(t *testing.T) {
+	original := map[string]any{
+		"book": map[string]any{"author": "Nietzsche", "title": "Book1"},
+	}
+	doc := NewDocument(deepCopy(original).(map[string]any))
+
+	doc.Put("$.book.price", 10)
+	doc.StartRecording()
+	doc.Put("$.book.author", "Stirner")
+	doc.Put("$.book.year", 1844)
+	doc.Delete("$.book.title")
+	doc.Delete("$.book.missing")
+	changeset := doc.StopRecording()
+	doc.Put("$.book.price", 20)
+
+	expectedChangeset := Changeset{
+		{Path: "$.book.author", Op: OperationPut, Old: "Nietzsche", OldExists: true, New: "Stirner"},
+		{Path: "$.book.year", Op: OperationPut, New: 1844},
+		{Path: "$.book.title", Op: OperationDelete, Old: "Book1", OldExists: true},
+	}
+	if !cmp.Equal(expectedChangeset, changeset) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedChangeset, changeset)
+	}
+
+	expectedData := map[string]any{
+		"book": map[string]any{"author": "Stirner", "year": 1844, "price": 20},
+	}
+	if !cmp.Equal(expectedData, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(doc.Data()))
+	}
+
+	if err := changeset.Invert().Apply(doc.Data()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expectedData = map[string]any{
+		"book": map[string]any{"author": "Nietzsche", "title": "Book1", "price": 20},
+	}
+	if !cmp.Equal(expectedData, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(doc.Data()))
+	}
+
+	replayed := deepCopy(original).(map[string]any)
+	if err := changeset.Apply(replayed); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expectedData = map[string]any{
+		"book": map[string]any{"author": "Stirner", "year": 1844},
+	}
+	if !cmp.Equal(expectedData, replayed) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(replayed))
+	}
+}
+
+func TestChangesetSerialization(t *testing.T) {
+	changeset := Changeset{
+		{Path: "$.book.author", Op: OperationPut, Old: "Nietzsche", OldExists: true, New: "Stirner"},
+		{Path: "$.book.title", Op: OperationDelete, Old: "Book1", OldExists: true},
+	}
+
+	serialized, err := json.Marshal(changeset)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var deserialized Changeset
+	if err := json.Unmarshal(serialized, &deserialized); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !cmp.Equal(changeset, deserialized) {
+		t.Errorf("Expected '%#v', but got '%#v'", changeset, deserialized)
+	}
+}
+
+func TestChangesetInvertCreations(t *testing.T) {
+	original := map[string]any{
+		"items": []any{map[string]any{"name": "a"}},
+		"empty": []any{},
+		"extra": nil,
+	}
+	doc := NewDocument(deepCopy(original).(map[string]any))
+
+	doc.StartRecording()
+	doc.Put("$.items[1].name", "b")
+	doc.Put("$.items[0].kind", "book")
+	doc.Put("$.empty[0]", "c")
+	doc.Put("$.meta.tags[0]", "d")
+	doc.Put("$.extra.note", "e")
+	changeset := doc.StopRecording()
+
+	expectedChangeset := Changeset{
+		{Path: "$.items[1].name", Op: OperationPut, New: "b", Created: "$.items", CreatedOld: []any{map[string]any{"name": "a"}}, CreatedExists: true},
+		{Path: "$.items[0].kind", Op: OperationPut, New: "book"},
+		{Path: "$.empty[0]", Op: OperationPut, New: "c", Created: "$.empty", CreatedOld: []any{}, CreatedExists: true},
+		{Path: "$.meta.tags[0]", Op: OperationPut, New: "d", Created: "$.meta"},
+		{Path: "$.extra.note", Op: OperationPut, New: "e", Created: "$.extra", CreatedExists: true},
+	}
+	if !cmp.Equal(expectedChangeset, changeset) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedChangeset, changeset)
+	}
+
+	// the changeset is serialized so that the inversion does not depend on the recorded values being shared
+	serialized, _ := json.Marshal(changeset)
+	var deserialized Changeset
+	if err := json.Unmarshal(serialized, &deserialized); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := deserialized.Invert().Apply(doc.Data()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cmp.Equal(original, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(original), gu.Prettify(doc.Data()))
+	}
+}
+
+func TestChangesetApplyUnknownOperation(t *testing.T) {
+	changeset := Changeset{{Path: "$.book", Op: "move"}}
+
+	err := changeset.Apply(map[string]any{})
+	expectedErrorMessage := "Operation[0]: Unknown operation type 'move'"
+	if err == nil || err.Error() != expectedErrorMessage {
+		t.Errorf("Expected error message '%#v', but got '%#v'", expectedErrorMessage, err)
+	}
+}
+
+var (
+	_ sql.Scanner   = (*Document)(nil)
+	_ driver.Valuer = (*Document)(nil)
+)
+
+type DocumentScanTestCase struct {
+	src                  any
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestDocumentScan(t *testing.T) {
+	testCases := []DocumentScanTestCase{
+		{
+			src:                  []byte(`{"book": {"author": "Nietzsche"}}`),
+			expectedData:         map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+			expectedErrorMessage: "",
+		},
+		{
+			src:                  `{"book": {"author": "Nietzsche"}}`,
+			expectedData:         map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+			expectedErrorMessage: "",
+		},
+		{
+			src:                  nil,
+			expectedData:         map[string]any{},
+			expectedErrorMessage: "",
+		},
+		{
+			src:                  []byte(`null`),
+			expectedData:         map[string]any{},
+			expectedErrorMessage: "",
+		},
+		{
+			src:                  10,
+			expectedData:         map[string]any{"old": true},
+			expectedErrorMessage: "Cannot scan int into Document",
+		},
+		{
+			src:                  []byte(`[1, 2]`),
+			expectedData:         map[string]any{"old": true},
+			expectedErrorMessage: "json: cannot unmarshal array into Go value of type map[string]interface {}",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Scan(%v)=%v", i, tc.src, tc.expectedErrorMessage), func(t *testing.T) {
+			doc := NewDocument(map[string]any{"old": true})
+
+			err := doc.Scan(tc.src)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, doc.Data()) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedData), gu.Prettify(doc.Data()))
+			}
+		})
+	}
+}
+
+func TestDocumentValue(t *testing.T) {
+	doc := NewDocument(map[string]any{"book": map[string]any{"author": "Nietzsche"}})
+
+	value, err := doc.Value()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"book":{"author":"Nietzsche"}}`
+	if string(value.([]byte)) != expected {
+		t.Errorf("Expected '%v', but got '%s'", expected, value)
+	}
+
+	var nilDoc *Document
+	if value, err := nilDoc.Value(); value != nil || err != nil {
+		t.Errorf("Expected nil value, but got '%v' (%v)", value, err)
+	}
+}
+
+// notification is a call of a subscription callback.
+type notification struct {
+	old any
+	new any
+}
+
+func TestDocumentSubscribe(t *testing.T) {
+	doc := NewDocument(map[string]any{
+		"services": map[string]any{
+			"api": map[string]any{"port": 80},
+		},
+		"servers": []any{map[string]any{"port": 80}, map[string]any{"port": 81}},
+		"name":    "config",
+	})
+
+	var apiPort, serverPorts []notification
+	doc.Subscribe("$.services.api.port", func(old, new any) { apiPort = append(apiPort, notification{old, new}) })
+	unsubscribe := doc.Subscribe("$.servers[*].port", func(old, new any) { serverPorts = append(serverPorts, notification{old, new}) })
+
+	doc.Put("$.services.api.port", 8080)
+	doc.Put("$.name", "other")
+	doc.Put("$.servers[1].port", 8081)
+	doc.Delete("$.services.api")
+	doc.Map(map[string]any{"port": 443}, []Mapper{{SrcJsonPath: "$.port", DstJsonPath: "$.services.api.port"}})
+	unsubscribe()
+	doc.Put("$.servers[0].port", 8080)
+	doc.Put("$.services.api.port", 8443)
+
+	expectedApiPort := []notification{{80, 8080}, {8080, nil}, {nil, 443}, {443, 8443}}
+	if !cmp.Equal(expectedApiPort, apiPort, cmp.AllowUnexported(notification{})) {
+		t.Errorf("Expected '%v', but got '%v'", expectedApiPort, apiPort)
+	}
+	expectedServerPorts := []notification{{[]any{80, 81}, []any{80, 8081}}}
+	if !cmp.Equal(expectedServerPorts, serverPorts, cmp.AllowUnexported(notification{})) {
+		t.Errorf("Expected '%v', but got '%v'", expectedServerPorts, serverPorts)
+	}
+}
+
+func TestDocumentMapRecording(t *testing.T) {
+	doc := NewDocument(map[string]any{"book": map[string]any{"title": "Book1"}})
+
+	doc.StartRecording()
+	errs := doc.Map(map[string]any{"author": "Nietzsche"}, []Mapper{{SrcJsonPath: "$.author", DstJsonPath: "$.book.author"}})
+	changeset := doc.StopRecording()
+
+	if len(errs) > 0 {
+		t.Fatalf("Expected no errors, but got '%v'", errs)
+	}
+	expectedChangeset := Changeset{{Path: "$.book.author", Op: OperationPut, New: "Nietzsche"}}
+	if !cmp.Equal(expectedChangeset, changeset) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedChangeset, changeset)
+	}
+}
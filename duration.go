@@ -0,0 +1,145 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// The units of DurationTransformer.
+const (
+	DurationUnitSeconds      = "s"
+	DurationUnitMilliseconds = "ms"
+)
+
+// The formats of DurationTransformer.
+const (
+	DurationFormatGo  = "go"
+	DurationFormatISO = "iso8601"
+)
+
+// isoDurationPattern matches the ISO 8601 durations of weeks, days, hours, minutes and seconds, i.e. `PT1H30M`.
+var isoDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// isoDurationUnits are the durations of the units of isoDurationPattern in their order.
+var isoDurationUnits = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// parseISODuration converts an ISO 8601 duration to a time.Duration. Years and months are not supported since their
+// duration varies.
+func parseISODuration(s string) (time.Duration, error) {
+	submatches := isoDurationPattern.FindStringSubmatch(s)
+	if submatches == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("Couldn't convert '%v' to duration.", s)
+	}
+
+	var duration time.Duration
+	for i, unit := range isoDurationUnits {
+		if submatches[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.ParseFloat(strings.Replace(submatches[i+2], ",", ".", 1), 64)
+		duration += time.Duration(n * float64(unit))
+	}
+
+	if submatches[1] == "-" {
+		duration = -duration
+	}
+
+	return duration, nil
+}
+
+// formatISODuration converts a time.Duration to an ISO 8601 duration of hours, minutes and seconds, i.e. `PT1H30M`.
+func formatISODuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := d % time.Minute
+
+	s := sign + "PT"
+	if hours > 0 {
+		s += fmt.Sprintf("%dH", hours)
+	}
+	if minutes > 0 {
+		s += fmt.Sprintf("%dM", minutes)
+	}
+	if seconds > 0 || d == 0 {
+		s += strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64) + "S"
+	}
+
+	return s
+}
+
+// DurationTransformer converts durations between strings, i.e. `1h30m` or `PT1H30M`, and numbers of seconds or
+// milliseconds, i.e. `5400`.
+type DurationTransformer struct {
+
+	// Unit is the unit of the numbers. It can be one of DurationUnitSeconds and DurationUnitMilliseconds and it
+	// defaults to DurationUnitSeconds.
+	Unit string
+
+	// Format is the format numbers are converted to. It can be one of DurationFormatGo, i.e. `1h30m0s`, and
+	// DurationFormatISO, i.e. `PT1H30M`, and it defaults to DurationFormatGo.
+	Format string
+}
+
+// DurationTransformer Transform applies the duration transformation.
+//
+// It expects either a string value in the format accepted by time.ParseDuration or in the ISO 8601 format, which
+// is converted to a number of the Unit, or a number value of the Unit, which is converted to a string of the Format.
+// ISO 8601 durations of years or months are not supported since their duration varies.
+func (t DurationTransformer) Transform(value any) (any, error) {
+	unit := time.Second
+	switch t.Unit {
+	case "", DurationUnitSeconds:
+	case DurationUnitMilliseconds:
+		unit = time.Millisecond
+	default:
+		return nil, fmt.Errorf("Unknown duration unit '%v'.", t.Unit)
+	}
+
+	if t.Format != "" && t.Format != DurationFormatGo && t.Format != DurationFormatISO {
+		return nil, fmt.Errorf("Unknown duration format '%v'.", t.Format)
+	}
+
+	if isNumber(value) {
+		n, _ := gu.ToFloat64(value)
+		if math.Abs(n*float64(unit)) > math.MaxInt64 {
+			return nil, fmt.Errorf("Duration %v out of range.", n)
+		}
+
+		d := time.Duration(math.Round(n * float64(unit)))
+		if t.Format == DurationFormatISO {
+			return formatISODuration(d), nil
+		}
+		return d.String(), nil
+	}
+
+	if !gu.IsString(value) {
+		return nil, errors.New("Value is not a string or a number.")
+	}
+
+	s := strings.TrimSpace(value.(string))
+
+	var d time.Duration
+	var err error
+	if strings.Contains(strings.ToUpper(s), "P") {
+		d, err = parseISODuration(strings.ToUpper(s))
+	} else if d, err = time.ParseDuration(s); err != nil {
+		err = fmt.Errorf("Couldn't convert '%v' to duration.", s)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return float64(d) / float64(unit), nil
+}
@@ -0,0 +1,107 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDurationTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              DurationTransformer{},
+			value:                    "1h30m",
+			expectedTransformedValue: 5400.0,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{Unit: DurationUnitMilliseconds},
+			value:                    "90s",
+			expectedTransformedValue: 90000.0,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{},
+			value:                    "PT1H30M",
+			expectedTransformedValue: 5400.0,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{},
+			value:                    "-P1DT0.5S",
+			expectedTransformedValue: -86400.5,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{},
+			value:                    "P1M",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert 'P1M' to duration.",
+		},
+		{
+			transformer:              DurationTransformer{},
+			value:                    "PT",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert 'PT' to duration.",
+		},
+		{
+			transformer:              DurationTransformer{},
+			value:                    "90",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '90' to duration.",
+		},
+		{
+			transformer:              DurationTransformer{},
+			value:                    5400,
+			expectedTransformedValue: "1h30m0s",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{Format: DurationFormatISO},
+			value:                    5400.5,
+			expectedTransformedValue: "PT1H30M0.5S",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{Unit: DurationUnitMilliseconds, Format: DurationFormatISO},
+			value:                    -90000,
+			expectedTransformedValue: "-PT1M30S",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{Format: DurationFormatISO},
+			value:                    0,
+			expectedTransformedValue: "PT0S",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DurationTransformer{Unit: "h"},
+			value:                    "1h",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Unknown duration unit 'h'.",
+		},
+		{
+			transformer:              DurationTransformer{},
+			value:                    true,
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not a string or a number.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("DurationTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
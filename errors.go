@@ -0,0 +1,110 @@
+package jsonmanu
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// The error codes of the library errors. They are stable so they can be safely returned to API clients.
+const (
+	ErrCodeUnknown            = "unknown"
+	ErrCodeInvalidPath        = "invalid_path"
+	ErrCodeNotMap             = "not_map"
+	ErrCodeKeyNotFound        = "key_not_found"
+	ErrCodeNotArray           = "not_array"
+	ErrCodeInvalidOperation   = "invalid_operation"
+	ErrCodeSchemaValidation   = "schema_validation"
+	ErrCodeUnknownTransformer = "unknown_transformer"
+	ErrCodeTransformation     = "transformation_failed"
+	ErrCodeUnsupportedFormat  = "unsupported_format"
+	ErrCodeInvalidExpression  = "invalid_expression"
+	ErrCodeUnsupportedVersion = "unsupported_version"
+	ErrCodeTimeout            = "timeout"
+	ErrCodeUnknownRecipe      = "unknown_recipe"
+	ErrCodeUnknownResolver    = "unknown_resolver"
+	ErrCodeInvalidDocument    = "invalid_document"
+)
+
+// coder is implemented by all the errors of the library.
+type coder interface {
+	ErrorCode() string
+}
+
+// Error is an error of the library along with a machine-readable code and the JSONPath that caused it, if any.
+type Error struct {
+	// Code is one of the ErrCode constants.
+	Code string
+
+	// Message is the human readable description of the error.
+	Message string
+
+	// Path is the JSONPath the error refers to.
+	Path string
+
+	// Segment is the part of the Path that caused the error.
+	Segment string
+
+	// Err is the underlying error, if any.
+	Err error
+}
+
+// errorPayload is the JSON representation of an error.
+type errorPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+	Segment string `json:"segment,omitempty"`
+}
+
+func (err *Error) Error() string { return err.Message }
+
+func (err *Error) Unwrap() error { return err.Err }
+
+// ErrorCode returns the code of the error.
+func (err *Error) ErrorCode() string { return err.Code }
+
+// MarshalJSON returns the error as a JSON object with the `code`, `message`, `path` and `segment` keys.
+func (err *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorPayload{Code: err.Code, Message: err.Message, Path: err.Path, Segment: err.Segment})
+}
+
+// ErrorCode returns the code of the first error of the library found in the chain of the provided error.
+// If there is none ErrCodeUnknown is returned.
+func ErrorCode(err error) string {
+	var c coder
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+
+	return ErrCodeUnknown
+}
+
+// AsError converts any error to an *Error so that it can be returned to an API client. The message is the one of
+// the provided error whereas the code, path and segment are taken from the first error of the library found in its chain.
+//
+// It returns nil if the provided error is nil.
+func AsError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	result := &Error{Code: ErrCodeUnknown, Message: err.Error(), Err: err}
+
+	var c coder
+	if !errors.As(err, &c) {
+		return result
+	}
+
+	result.Code = c.ErrorCode()
+
+	switch e := c.(type) {
+	case *Error:
+		result.Path, result.Segment = e.Path, e.Segment
+	case dataValidationError:
+		result.Segment = e.key
+	case schemaValidationError:
+		result.Path = e.path
+	}
+
+	return result
+}
@@ -0,0 +1,101 @@
+package jsonmanu
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+type ErrorCodeTestCase struct {
+	err          error
+	expectedCode string
+}
+
+func TestErrorCode(t *testing.T) {
+	data := map[string]any{"books": "none"}
+
+	_, parseErr := Get(data, "$.books[a]")
+	_, notFoundErr := Get(data, "$.authors")
+	_, notArrayErr := Get(data, "$.books[0]")
+	deleteErr := Delete(data, "$")
+	mapErrs := Map(data, map[string]any{}, []Mapper{{SrcJsonPath: "$.books", DstJsonPath: "$..books"}})
+	_, configErr := MappingConfig{Mappers: []MapperConfig{{Transformations: []TransformationConfig{{Type: "upper"}}}}}.ToMappers()
+	_, schemaErr := SchemaFromMappers([]Mapper{{DstJsonPath: "$.info", DstType: "string"}, {DstJsonPath: "$.info.count"}})
+	coerceErr := CoerceTypes(map[string]any{"id": "a"}, []CoercionRule{{JsonPath: "$.id", Type: "number"}})
+	applyErr := Changeset{{Op: "move", Path: "$.id"}}.Apply(map[string]any{})
+	_, _, trailingErr := DecodeOrdered(strings.NewReader(`{} {}`))
+	_, _, rootErr := DecodeJSONC(strings.NewReader(`[]`))
+	_, canonicalErr := MarshalCanonical(map[string]any{"n": math.NaN()})
+	_, overlapErr := PutRaw([]byte(`{"a": {}}`), map[string]any{"$.a": 1, "$.a.b": 2})
+	_, _, sourceMapErr := DecodeWithSourceMap(strings.NewReader(``), FormatTOML)
+
+	testCases := []ErrorCodeTestCase{
+		{err: parseErr, expectedCode: ErrCodeInvalidPath},
+		{err: notFoundErr, expectedCode: ErrCodeKeyNotFound},
+		{err: notArrayErr, expectedCode: ErrCodeNotArray},
+		{err: deleteErr, expectedCode: ErrCodeInvalidOperation},
+		{err: mapErrs[0], expectedCode: ErrCodeInvalidPath},
+		{err: configErr, expectedCode: ErrCodeUnknownTransformer},
+		{err: schemaErr, expectedCode: ErrCodeInvalidOperation},
+		{err: coerceErr, expectedCode: ErrCodeTransformation},
+		{err: applyErr, expectedCode: ErrCodeInvalidOperation},
+		{err: trailingErr, expectedCode: ErrCodeInvalidDocument},
+		{err: rootErr, expectedCode: ErrCodeNotMap},
+		{err: canonicalErr, expectedCode: ErrCodeInvalidDocument},
+		{err: overlapErr, expectedCode: ErrCodeInvalidPath},
+		{err: sourceMapErr, expectedCode: ErrCodeUnsupportedFormat},
+		{err: fmt.Errorf("other"), expectedCode: ErrCodeUnknown},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ErrorCode(%v)=%v", i, tc.err, tc.expectedCode), func(t *testing.T) {
+			if code := ErrorCode(tc.err); code != tc.expectedCode {
+				t.Errorf("Expected code '%v', but got '%v'", tc.expectedCode, code)
+			}
+		})
+	}
+}
+
+type ErrorMarshalJSONTestCase struct {
+	err          error
+	expectedJSON string
+}
+
+func TestErrorMarshalJSON(t *testing.T) {
+	_, parseErr := Get(map[string]any{}, "$.books[a]")
+	_, notFoundErr := Get(map[string]any{}, "$.authors")
+	mapErrs := Map(map[string]any{}, map[string]any{}, []Mapper{{SrcJsonPath: "$.books.", DstJsonPath: "$.books"}})
+
+	testCases := []ErrorMarshalJSONTestCase{
+		{
+			err:          parseErr,
+			expectedJSON: `{"code":"invalid_path","message":"Couldn't parse JSONPath substring 0: 'books[a]'","path":"$.books[a]","segment":"books[a]"}`,
+		},
+		{
+			err:          notFoundErr,
+			expectedJSON: `{"code":"key_not_found","message":"dataValidationError: Source key not found: 'authors'","segment":"authors"}`,
+		},
+		{
+			err:          AsError(mapErrs[0]),
+			expectedJSON: `{"code":"invalid_path","message":"Mapper[0]: Error while getting value from data: JSONPath should not end with '.'","path":"$.books."}`,
+		},
+		{
+			err:          AsError(fmt.Errorf("other")),
+			expectedJSON: `{"code":"unknown","message":"other"}`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - MarshalJSON(%v)", i, tc.err), func(t *testing.T) {
+			result, err := json.Marshal(tc.err)
+			if err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+			if string(result) != tc.expectedJSON {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedJSON, string(result))
+			}
+		})
+	}
+}
@@ -0,0 +1,693 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// exprParser evaluates an expression while parsing it in a recursive descent manner.
+//
+// The grammar in order of precedence:
+//
+//	or         = and { "||" and }
+//	and        = comparison { "&&" comparison }
+//	comparison = additive [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) additive ]
+//	additive   = term { ( "+" | "-" ) term }
+//	term       = unary { ( "*" | "/" | "%" ) unary }
+//	unary      = ( "!" | "-" ) unary | primary
+//	primary    = number | string | "true" | "false" | "null" | path | function "(" [ or { "," or } ] ")" | "(" or ")"
+type exprParser struct {
+	data map[string]any
+	expr string
+	pos  int
+
+	// skip is set while parsing an operand which does not affect the result, i.e. the right side of `false && ...`,
+	// so that it is only checked for syntax errors and not evaluated.
+	skip bool
+}
+
+// exprFunctions holds the functions available in expressions.
+var exprFunctions = map[string]func(args []any) (any, error){
+	"count":  exprCount,
+	"sum":    exprSum,
+	"avg":    exprAvg,
+	"min":    exprMin,
+	"max":    exprMax,
+	"len":    exprLen,
+	"exists": exprExists,
+}
+
+// exprMissing represents the value of a path that could not be resolved.
+type exprMissing struct{}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.expr) && unicode.IsSpace(rune(p.expr[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume advances the position if the upcoming input matches the provided token.
+func (p *exprParser) consume(token string) bool {
+	p.skipSpaces()
+
+	if strings.HasPrefix(p.expr[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+
+	return false
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return &Error{
+		Code:    ErrCodeInvalidExpression,
+		Message: fmt.Sprintf("Expression error at position %v: %v", p.pos, fmt.Sprintf(format, args...)),
+	}
+}
+
+func (p *exprParser) parseOr() (any, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume("||") {
+		right, err := p.parseSkipped(exprTruthy(left), p.parseAnd)
+		if err != nil {
+			return nil, err
+		}
+		left = exprTruthy(left) || exprTruthy(right)
+	}
+
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (any, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume("&&") {
+		right, err := p.parseSkipped(!exprTruthy(left), p.parseComparison)
+		if err != nil {
+			return nil, err
+		}
+		left = exprTruthy(left) && exprTruthy(right)
+	}
+
+	return left, nil
+}
+
+// parseSkipped parses an operand without evaluating it if skip is true, i.e. when the left side of a logical operator
+// already decides the result.
+func (p *exprParser) parseSkipped(skip bool, parse func() (any, error)) (any, error) {
+	if !skip || p.skip {
+		return parse()
+	}
+
+	p.skip = true
+	defer func() { p.skip = false }()
+
+	return parse()
+}
+
+// evalError returns the provided evaluation error unless the current operand is skipped.
+func (p *exprParser) evalError(format string, args ...any) error {
+	if p.skip {
+		return nil
+	}
+
+	return p.errorf(format, args...)
+}
+
+func (p *exprParser) parseComparison() (any, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return exprCompare(left, right, op), nil
+		}
+	}
+
+	return left, nil
+}
+
+func (p *exprParser) parseAdditive() (any, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var op string
+		if p.consume("+") {
+			op = "+"
+		} else if p.consume("-") {
+			op = "-"
+		} else {
+			return left, nil
+		}
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		if left, err = exprArithmetic(left, right, op); err != nil {
+			return nil, p.evalError("%v", err)
+		}
+	}
+}
+
+func (p *exprParser) parseTerm() (any, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var op string
+		if p.consume("*") {
+			op = "*"
+		} else if p.consume("/") {
+			op = "/"
+		} else if p.consume("%") {
+			op = "%"
+		} else {
+			return left, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		if left, err = exprArithmetic(left, right, op); err != nil {
+			return nil, p.evalError("%v", err)
+		}
+	}
+}
+
+func (p *exprParser) parseUnary() (any, error) {
+	if p.consume("!") {
+		value, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return !exprTruthy(value), nil
+	}
+
+	if p.consume("-") {
+		value, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if value, err = exprArithmetic(0.0, value, "-"); err != nil {
+			return nil, p.evalError("%v", err)
+		}
+		return value, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (any, error) {
+	p.skipSpaces()
+
+	if p.pos >= len(p.expr) {
+		return nil, p.errorf("unexpected end of expression")
+	}
+
+	c := p.expr[p.pos]
+
+	switch {
+	case c == '(':
+		p.pos++
+		value, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return value, nil
+	case c == '$':
+		return p.parsePath(), nil
+	case c == '"' || c == '\'':
+		return p.parseString(c)
+	case c >= '0' && c <= '9' || c == '.':
+		return p.parseNumber()
+	}
+
+	start := p.pos
+	for p.pos < len(p.expr) && (unicode.IsLetter(rune(p.expr[p.pos])) || unicode.IsDigit(rune(p.expr[p.pos])) || p.expr[p.pos] == '_') {
+		p.pos++
+	}
+	identifier := p.expr[start:p.pos]
+
+	switch identifier {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	case "":
+		return nil, p.errorf("unexpected character '%c'", c)
+	}
+
+	function, ok := exprFunctions[identifier]
+	if !ok {
+		return nil, p.errorf("unknown function '%v'", identifier)
+	}
+
+	if !p.consume("(") {
+		return nil, p.errorf("expected '(' after '%v'", identifier)
+	}
+
+	var args []any
+	if !p.consume(")") {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.consume(")") {
+				break
+			}
+			if !p.consume(",") {
+				return nil, p.errorf("expected ',' or ')'")
+			}
+		}
+	}
+
+	if p.skip {
+		return nil, nil
+	}
+
+	value, err := function(args)
+	if err != nil {
+		return nil, p.errorf("%v: %v", identifier, err)
+	}
+
+	return value, nil
+}
+
+// parsePath consumes a JSONPath and resolves it. Brackets are consumed as a whole so that filters may contain spaces and operators.
+func (p *exprParser) parsePath() any {
+	start := p.pos
+	depth := 0
+
+	for ; p.pos < len(p.expr); p.pos++ {
+		c := p.expr[p.pos]
+
+		if c == '[' {
+			depth++
+			continue
+		}
+		if c == ']' {
+			depth--
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		if unicode.IsSpace(rune(c)) || strings.ContainsRune("()!=<>+-/%,&|", rune(c)) {
+			break
+		}
+		// a `*` is a wildcard only right after a `.`, otherwise it is the multiplication operator
+		if c == '*' && p.expr[p.pos-1] != '.' {
+			break
+		}
+	}
+
+	if p.skip {
+		return exprMissing{}
+	}
+
+	value, err := Get(p.data, p.expr[start:p.pos])
+	if err != nil {
+		return exprMissing{}
+	}
+
+	return value
+}
+
+func (p *exprParser) parseString(quote byte) (any, error) {
+	p.pos++
+	start := p.pos
+
+	for p.pos < len(p.expr) && p.expr[p.pos] != quote {
+		p.pos++
+	}
+
+	if p.pos >= len(p.expr) {
+		return nil, p.errorf("unterminated string")
+	}
+
+	value := p.expr[start:p.pos]
+	p.pos++
+
+	return value, nil
+}
+
+func (p *exprParser) parseNumber() (any, error) {
+	start := p.pos
+
+	digits := func() {
+		for p.pos < len(p.expr) && (p.expr[p.pos] >= '0' && p.expr[p.pos] <= '9' || p.expr[p.pos] == '.') {
+			p.pos++
+		}
+	}
+
+	digits()
+
+	// an exponent, i.e. `1e3` or `2.5E-2`
+	if p.pos < len(p.expr) && (p.expr[p.pos] == 'e' || p.expr[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.expr) && (p.expr[p.pos] == '+' || p.expr[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+
+	value, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+	if err != nil {
+		return nil, p.errorf("invalid number '%v'", p.expr[start:p.pos])
+	}
+
+	return value, nil
+}
+
+// exprValue converts a missing path value to nil.
+func exprValue(value any) any {
+	if _, ok := value.(exprMissing); ok {
+		return nil
+	}
+
+	return value
+}
+
+// exprScalar unwraps single element arrays, i.e. the result of `$.books[0].price`, so they can be used as scalars.
+func exprScalar(value any) any {
+	value = exprValue(value)
+
+	if items, ok := value.([]any); ok && len(items) == 1 {
+		return items[0]
+	}
+
+	return value
+}
+
+// exprTruthy returns whether a value is considered true in a boolean context.
+func exprTruthy(value any) bool {
+	value = exprValue(value)
+
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	}
+
+	if isNumber(value) {
+		fv, _ := gu.ToFloat64(value)
+		return fv != 0
+	}
+
+	return true
+}
+
+// exprCompare compares two values. Equality applies on any type whereas ordering only on numbers and strings.
+//
+// Single element arrays are compared by their element.
+func exprCompare(left, right any, op string) bool {
+	left, right = exprScalar(left), exprScalar(right)
+
+	if op == "==" || op == "!=" {
+		var equal bool
+		if isNumber(left) && isNumber(right) {
+			fleft, _ := gu.ToFloat64(left)
+			fright, _ := gu.ToFloat64(right)
+			equal = fleft == fright
+		} else {
+			equal = reflect.DeepEqual(left, right)
+		}
+		return equal == (op == "==")
+	}
+
+	if isNumber(left) && isNumber(right) {
+		return assertCondition(left, right, op, getFilterOptions())
+	}
+
+	leftStr, leftOk := left.(string)
+	rightStr, rightOk := right.(string)
+	if !leftOk || !rightOk {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return leftStr < rightStr
+	case "<=":
+		return leftStr <= rightStr
+	case ">":
+		return leftStr > rightStr
+	case ">=":
+		return leftStr >= rightStr
+	}
+
+	return false
+}
+
+// exprArithmetic applies an arithmetic operator on two numbers. The `+` operator concatenates strings as well.
+//
+// Single element arrays are treated as their element.
+func exprArithmetic(left, right any, op string) (any, error) {
+	left, right = exprScalar(left), exprScalar(right)
+
+	if op == "+" && gu.IsString(left) && gu.IsString(right) {
+		return left.(string) + right.(string), nil
+	}
+
+	if !isNumber(left) || !isNumber(right) {
+		return nil, fmt.Errorf("operator '%v' expects numbers, got %#v and %#v", op, left, right)
+	}
+
+	fleft, _ := gu.ToFloat64(left)
+	fright, _ := gu.ToFloat64(right)
+
+	switch op {
+	case "+":
+		return fleft + fright, nil
+	case "-":
+		return fleft - fright, nil
+	case "*":
+		return fleft * fright, nil
+	case "/":
+		if fright == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return fleft / fright, nil
+	case "%":
+		if fright == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return math.Mod(fleft, fright), nil
+	}
+
+	return nil, fmt.Errorf("unknown operator '%v'", op)
+}
+
+// exprSingleArg validates that exactly one argument is provided and returns it.
+func exprSingleArg(args []any) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected 1 argument, got %v", len(args))
+	}
+
+	return args[0], nil
+}
+
+// exprNumbers returns the numbers found in the single argument which can be either a number or an array.
+func exprNumbers(args []any) ([]float64, error) {
+	arg, err := exprSingleArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	arg = exprValue(arg)
+
+	items, ok := arg.([]any)
+	if !ok {
+		if arg == nil {
+			return nil, nil
+		}
+		items = []any{arg}
+	}
+
+	var numbers []float64
+	for _, item := range items {
+		if !isNumber(item) {
+			return nil, fmt.Errorf("value %#v is not a number", item)
+		}
+		fv, _ := gu.ToFloat64(item)
+		numbers = append(numbers, fv)
+	}
+
+	return numbers, nil
+}
+
+func exprCount(args []any) (any, error) {
+	arg, err := exprSingleArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := arg.(type) {
+	case exprMissing:
+		return 0.0, nil
+	case []any:
+		return float64(len(v)), nil
+	}
+
+	return 1.0, nil
+}
+
+func exprLen(args []any) (any, error) {
+	arg, err := exprSingleArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := exprValue(arg).(type) {
+	case string:
+		return float64(len(v)), nil
+	case []any:
+		return float64(len(v)), nil
+	case map[string]any:
+		return float64(len(v)), nil
+	}
+
+	return nil, fmt.Errorf("value %#v has no length", arg)
+}
+
+func exprExists(args []any) (any, error) {
+	arg, err := exprSingleArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	_, missing := arg.(exprMissing)
+
+	return !missing, nil
+}
+
+func exprSum(args []any) (any, error) {
+	numbers, err := exprNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := 0.0
+	for _, n := range numbers {
+		sum += n
+	}
+
+	return sum, nil
+}
+
+func exprAvg(args []any) (any, error) {
+	numbers, err := exprNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+
+	sum, _ := exprSum(args)
+
+	return sum.(float64) / float64(len(numbers)), nil
+}
+
+func exprMin(args []any) (any, error) {
+	numbers, err := exprNumbers(args)
+	if err != nil || len(numbers) == 0 {
+		return nil, err
+	}
+
+	min := numbers[0]
+	for _, n := range numbers[1:] {
+		if n < min {
+			min = n
+		}
+	}
+
+	return min, nil
+}
+
+func exprMax(args []any) (any, error) {
+	numbers, err := exprNumbers(args)
+	if err != nil || len(numbers) == 0 {
+		return nil, err
+	}
+
+	max := numbers[0]
+	for _, n := range numbers[1:] {
+		if n > max {
+			max = n
+		}
+	}
+
+	return max, nil
+}
+
+// Eval evaluates an expression combining JSONPath queries on the provided data with literals, functions and operators.
+//
+// The supported elements are:
+//   - literals: numbers with an optional exponent, i.e. `1e3`, strings in single or double quotes, `true`, `false` and `null`
+//   - JSONPaths starting with `$`. A path that cannot be resolved evaluates to `null`
+//   - the functions `count`, `sum`, `avg`, `min`, `max`, `len` and `exists`
+//   - the arithmetic operators `+`, `-`, `*`, `/`, `%` where `+` concatenates strings as well and `%` is the floating point remainder
+//   - the comparison operators `==`, `!=`, `<`, `<=`, `>`, `>=`
+//   - the boolean operators `&&`, `||`, `!` and parentheses
+//
+// All numbers produced by the expression are float64.
+//
+// Example: `count($.books[?(@.price > 10)]) > 2 && exists($.store.name)`
+func Eval(data map[string]any, expr string) (any, error) {
+	p := &exprParser{data: data, expr: expr}
+
+	value, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.expr) {
+		return nil, p.errorf("unexpected '%v'", p.expr[p.pos:])
+	}
+
+	return exprValue(value), nil
+}
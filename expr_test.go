@@ -0,0 +1,88 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type EvalTestCase struct {
+	expr                 string
+	expectedValue        any
+	expectedErrorMessage string
+}
+
+func TestEval(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"name": "Store1",
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": 15},
+				map[string]any{"author": "Stirner", "price": 5},
+				map[string]any{"author": "Camus", "price": 20},
+			},
+			"discount": 0.5,
+		},
+	}
+
+	testCases := []EvalTestCase{
+		{expr: "count($.store.books[?(@.price > 10)])", expectedValue: 2.0},
+		{expr: "count($.store.books)", expectedValue: 3.0},
+		{expr: "count($.store.owner)", expectedValue: 0.0},
+		{expr: "count($.store.name)", expectedValue: 1.0},
+		{expr: "sum($.store.books.price)", expectedValue: 40.0},
+		{expr: "avg($.store.books.price)", expectedValue: 40.0 / 3},
+		{expr: "min($.store.books.price)", expectedValue: 5.0},
+		{expr: "max($.store.books.price)", expectedValue: 20.0},
+		{expr: "max($.store.owner)", expectedValue: nil},
+		{expr: "len($.store.name)", expectedValue: 6.0},
+		{expr: "sum($.store.books.price) * $.store.discount", expectedValue: 20.0},
+		{expr: "$.store.discount*2", expectedValue: 1.0},
+		{expr: "(1 + 2) * 3 - 4 / 2", expectedValue: 7.0},
+		{expr: "-$.store.discount", expectedValue: -0.5},
+		{expr: "7 % 3", expectedValue: 1.0},
+		{expr: "5 % 0.5", expectedValue: 0.0},
+		{expr: "5.5 % 2", expectedValue: 1.5},
+		{expr: "-7 % 3", expectedValue: -1.0},
+		{expr: "5 % 0", expectedErrorMessage: "Expression error at position 5: division by zero"},
+		{expr: "1e3 + 2.5E-1", expectedValue: 1000.25},
+		{expr: "1e", expectedErrorMessage: "Expression error at position 2: invalid number '1e'"},
+		{expr: "$.store.name + ' ' + \"open\"", expectedValue: "Store1 open"},
+		{expr: "count($.store.books[?(@.price > 10)]) >= 2 && exists($.store.name)", expectedValue: true},
+		{expr: "exists($.store.owner) || !exists($.store.name)", expectedValue: false},
+		{expr: "exists($.store.owner) && $.store.owner.age * 2 > 1", expectedValue: false},
+		{expr: "exists($.store.owner) && sum($.store.name) > 1", expectedValue: false},
+		{expr: "exists($.store.name) || $.store.name * 2 > 1", expectedValue: true},
+		{expr: "exists($.store.name) && $.store.name * 2 > 1", expectedErrorMessage: "Expression error at position 40: operator '*' expects numbers, got \"Store1\" and 2"},
+		{expr: "exists($.store.owner) && upper($.store.owner)", expectedErrorMessage: "Expression error at position 30: unknown function 'upper'"},
+		{expr: "$.store.name == 'Store1'", expectedValue: true},
+		{expr: "$.store.name != 'Store1'", expectedValue: false},
+		{expr: "$.store.owner == null", expectedValue: true},
+		{expr: "'abc' < 'abd'", expectedValue: true},
+		{expr: "$.store.books[0].price > $.store.books[1].price", expectedValue: true},
+		{expr: "true", expectedValue: true},
+		{expr: "$.store.books[0].author", expectedValue: []any{"Nietzsche"}},
+		{expr: "1 / 0", expectedErrorMessage: "Expression error at position 5: division by zero"},
+		{expr: "$.store.name * 2", expectedErrorMessage: "Expression error at position 16: operator '*' expects numbers, got \"Store1\" and 2"},
+		{expr: "upper($.store.name)", expectedErrorMessage: "Expression error at position 5: unknown function 'upper'"},
+		{expr: "count($.a, $.b)", expectedErrorMessage: "Expression error at position 15: count: expected 1 argument, got 2"},
+		{expr: "sum($.store.name)", expectedErrorMessage: "Expression error at position 17: sum: value \"Store1\" is not a number"},
+		{expr: "(1 + 2", expectedErrorMessage: "Expression error at position 6: expected ')'"},
+		{expr: "1 +", expectedErrorMessage: "Expression error at position 3: unexpected end of expression"},
+		{expr: "'abc", expectedErrorMessage: "Expression error at position 4: unterminated string"},
+		{expr: "1 2", expectedErrorMessage: "Expression error at position 2: unexpected '2'"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Eval(%v)=%v", i, tc.expr, tc.expectedErrorMessage), func(t *testing.T) {
+			value, err := Eval(data, tc.expr)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedValue, value) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedValue, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fieldMaskSegments splits a field mask path, i.e. `a.*.d`, in its segments.
+func fieldMaskSegments(path string) ([]string, error) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("Empty segment in field mask path '%v'", path), Path: path}
+		}
+	}
+
+	return segments, nil
+}
+
+// fieldMaskMatches reports the location and the value of every field of the value matched by the segments of a field
+// mask path. A `*` segment matches all the keys of an object and the segments apply on each element of an array as
+// they do on repeated fields.
+func fieldMaskMatches(value any, segments []string, location []any, visit func(location []any, value any)) {
+	if len(segments) == 0 {
+		visit(location, value)
+		return
+	}
+
+	step := func(key any) []any {
+		return append(append(make([]any, 0, len(location)+1), location...), key)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if segments[0] == "*" {
+			for _, key := range sortedKeys(v) {
+				fieldMaskMatches(v[key], segments[1:], step(key), visit)
+			}
+		} else if child, ok := v[segments[0]]; ok {
+			fieldMaskMatches(child, segments[1:], step(segments[0]), visit)
+		}
+	case []any:
+		for i, item := range v {
+			fieldMaskMatches(item, segments, step(i), visit)
+		}
+	}
+}
+
+// ApplyFieldMask returns a new document holding only the fields of the data matched by the paths of a field mask as
+// used by Google APIs, i.e. `a.b.c` or `a.*.d` where `*` matches any key. Paths through arrays apply on each of their
+// elements. An empty mask keeps all the fields.
+//
+// The kept values are deep copies. An error is returned if any of the paths is invalid.
+func ApplyFieldMask(data map[string]any, mask []string) (map[string]any, error) {
+	if len(mask) == 0 {
+		return deepCopy(data).(map[string]any), nil
+	}
+
+	var root any = make(map[string]any)
+
+	for _, path := range mask {
+		segments, err := fieldMaskSegments(path)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldMaskMatches(data, segments, nil, func(location []any, value any) {
+			root = extractInto(root, location, value)
+		})
+	}
+
+	return extracted(root).(map[string]any), nil
+}
+
+// fieldMaskFields returns the locations of the fields matched by the field mask path by their key along with their
+// values. An error is returned if the path goes through an array.
+func fieldMaskFields(data map[string]any, path string, segments []string) (map[string][]any, map[string]any, error) {
+	locations := make(map[string][]any)
+	values := make(map[string]any)
+
+	var err error
+	fieldMaskMatches(data, segments, nil, func(location []any, value any) {
+		keys := make([]string, len(location))
+		for i, step := range location {
+			key, ok := step.(string)
+			if !ok {
+				err = &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Field mask path '%v' cannot go through arrays when merging", path), Path: path}
+				return
+			}
+			keys[i] = key
+		}
+
+		id := strings.Join(keys, "\x00")
+		locations[id], values[id] = location, value
+	})
+
+	return locations, values, err
+}
+
+// MergeFieldMask updates the fields of dst matched by the paths of a field mask with the corresponding fields of src
+// as the update methods of Google APIs do. Fields matched in dst but not in src are cleared and the missing objects
+// along the paths are created. An empty mask replaces all the fields of dst.
+//
+// The paths cannot go through arrays since their elements cannot be matched between the documents; arrays are
+// replaced as a whole instead. The merged values are deep copies.
+func MergeFieldMask(dst map[string]any, src map[string]any, mask []string) error {
+	if len(mask) == 0 {
+		for key := range dst {
+			delete(dst, key)
+		}
+		for key, value := range src {
+			dst[key] = deepCopy(value)
+		}
+		return nil
+	}
+
+	for _, path := range mask {
+		segments, err := fieldMaskSegments(path)
+		if err != nil {
+			return err
+		}
+
+		srcLocations, srcValues, err := fieldMaskFields(src, path, segments)
+		if err != nil {
+			return err
+		}
+		dstLocations, _, err := fieldMaskFields(dst, path, segments)
+		if err != nil {
+			return err
+		}
+
+		for id, location := range dstLocations {
+			if _, ok := srcLocations[id]; !ok {
+				parent := dst
+				for _, key := range location[:len(location)-1] {
+					parent = parent[key.(string)].(map[string]any)
+				}
+				delete(parent, location[len(location)-1].(string))
+			}
+		}
+
+		for id, location := range srcLocations {
+			parent := dst
+			for _, key := range location[:len(location)-1] {
+				child, ok := parent[key.(string)].(map[string]any)
+				if !ok {
+					child = make(map[string]any)
+					parent[key.(string)] = child
+				}
+				parent = child
+			}
+			parent[location[len(location)-1].(string)] = deepCopy(srcValues[id])
+		}
+	}
+
+	return nil
+}
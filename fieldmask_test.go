@@ -0,0 +1,132 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type FieldMaskTestCase struct {
+	mask                 []string
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	data := map[string]any{
+		"user": map[string]any{"name": "Friedrich", "email": "f@example.com"},
+		"shelves": map[string]any{
+			"a": map[string]any{"name": "Philosophy", "size": 10},
+			"b": map[string]any{"name": "Poetry", "size": 5},
+		},
+		"books": []any{
+			map[string]any{"title": "Book1", "price": 15},
+			map[string]any{"title": "Book2", "price": 5},
+		},
+	}
+
+	testCases := []FieldMaskTestCase{
+		{
+			mask:         []string{"user.name"},
+			expectedData: map[string]any{"user": map[string]any{"name": "Friedrich"}},
+		},
+		{
+			mask: []string{"shelves.*.name", "books.title"},
+			expectedData: map[string]any{
+				"shelves": map[string]any{"a": map[string]any{"name": "Philosophy"}, "b": map[string]any{"name": "Poetry"}},
+				"books":   []any{map[string]any{"title": "Book1"}, map[string]any{"title": "Book2"}},
+			},
+		},
+		{
+			mask:         []string{"user", "user.name", "missing.key"},
+			expectedData: map[string]any{"user": map[string]any{"name": "Friedrich", "email": "f@example.com"}},
+		},
+		{
+			mask:         nil,
+			expectedData: data,
+		},
+		{
+			mask:                 []string{"user..name"},
+			expectedErrorMessage: "Empty segment in field mask path 'user..name'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ApplyFieldMask(%v)=%v", i, tc.mask, tc.expectedErrorMessage), func(t *testing.T) {
+			result, err := ApplyFieldMask(data, tc.mask)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, result) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(result))
+			}
+		})
+	}
+}
+
+func TestMergeFieldMask(t *testing.T) {
+	dst := func() map[string]any {
+		return map[string]any{
+			"user": map[string]any{"name": "Friedrich", "email": "f@example.com"},
+			"shelves": map[string]any{
+				"a": map[string]any{"name": "Philosophy", "size": 10},
+				"b": map[string]any{"name": "Poetry", "size": 5},
+			},
+			"books": []any{map[string]any{"title": "Book1"}},
+		}
+	}
+	src := map[string]any{
+		"user":    map[string]any{"name": "Max"},
+		"shelves": map[string]any{"a": map[string]any{"name": "Ethics"}, "c": map[string]any{"name": "Drama"}},
+		"books":   []any{map[string]any{"title": "Book2"}},
+		"address": map[string]any{"city": "Basel"},
+	}
+
+	testCases := []FieldMaskTestCase{
+		{
+			mask: []string{"user.name", "user.email", "address.city"},
+			expectedData: map[string]any{
+				"user":    map[string]any{"name": "Max"},
+				"shelves": dst()["shelves"],
+				"books":   dst()["books"],
+				"address": map[string]any{"city": "Basel"},
+			},
+		},
+		{
+			mask: []string{"shelves.*.name", "books"},
+			expectedData: map[string]any{
+				"user": dst()["user"],
+				"shelves": map[string]any{
+					"a": map[string]any{"name": "Ethics", "size": 10},
+					"b": map[string]any{"size": 5},
+					"c": map[string]any{"name": "Drama"},
+				},
+				"books": []any{map[string]any{"title": "Book2"}},
+			},
+		},
+		{
+			mask:         []string{},
+			expectedData: src,
+		},
+		{
+			mask:                 []string{"books.title"},
+			expectedData:         dst(),
+			expectedErrorMessage: "Field mask path 'books.title' cannot go through arrays when merging",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - MergeFieldMask(%v)=%v", i, tc.mask, tc.expectedErrorMessage), func(t *testing.T) {
+			d := dst()
+			err := MergeFieldMask(d, src, tc.mask)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, d) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(d))
+			}
+		})
+	}
+}
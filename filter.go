@@ -0,0 +1,350 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// FuzzyMetric is a string similarity metric of the `~=` operator of the array filters.
+type FuzzyMetric string
+
+const (
+	// FuzzyLevenshtein is the Levenshtein distance of the strings normalized by the length of the longest one, so that
+	// `Nietsche` is 0.89 similar to `Nietzsche`.
+	FuzzyLevenshtein FuzzyMetric = "levenshtein"
+
+	// FuzzyJaroWinkler is the Jaro-Winkler similarity of the strings which favors the ones with a common prefix.
+	FuzzyJaroWinkler FuzzyMetric = "jaro-winkler"
+)
+
+// FilterOptions configures the comparisons of the array filters, i.e. `[?(@.createdAt > '2023-01-01T00:00:00Z')]`.
+type FilterOptions struct {
+
+	// TimeLayouts are the layouts, as accepted by time.Parse, of the strings considered timestamps. Timestamps are
+	// compared chronologically with each other and with numbers, which are considered Unix timestamps in seconds.
+	// It defaults to RFC 3339.
+	TimeLayouts []string
+
+	// Epsilon is the tolerance of the `==` and `!=` comparisons of numbers so that values which went through float
+	// serialization, i.e. coordinates, compare predictably. Numbers whose difference is at most Epsilon are equal.
+	// It defaults to 0 which stands for exact comparison.
+	Epsilon float64
+
+	// CompareStrings, if set, compares the strings of the filters instead of the byte-wise comparison, returning
+	// -1, 0 or 1. It allows locale-aware comparisons of user-facing text, i.e. with the `CompareString` method of a
+	// `golang.org/x/text/collate` collator, so that accented names are ordered correctly.
+	CompareStrings func(a, b string) int
+
+	// StrictTypes disables the conversion of the strings of the data to numbers, i.e. `"9"` is compared to `10` as a
+	// string and it is not less than it. Numbers of the data are still compared numerically with numeric values.
+	StrictTypes bool
+
+	// FuzzyMetric is the similarity metric of the `~=` operator, i.e. `[?(@.name ~= 'Nietsche')]`, which tolerates
+	// typos. It defaults to FuzzyLevenshtein.
+	FuzzyMetric FuzzyMetric
+
+	// FuzzyThreshold is the minimum similarity, from 0 to 1, of two strings for the `~=` operator to consider them
+	// matching. It defaults to 0.8.
+	FuzzyThreshold float64
+}
+
+// filterUnquotedValuePattern matches the filter values which can be written without quotes.
+var filterUnquotedValuePattern = regexp.MustCompile(`^[\w.+\-:]*$`)
+
+// currentFilterOptions holds the FilterOptions used by the library.
+var currentFilterOptions atomic.Value
+
+// getFilterOptions returns the FilterOptions used by the library.
+func getFilterOptions() FilterOptions {
+	if opts, ok := currentFilterOptions.Load().(FilterOptions); ok {
+		return opts
+	}
+
+	return defaultFilterOptions()
+}
+
+// defaultFilterOptions returns the default FilterOptions.
+func defaultFilterOptions() FilterOptions {
+	return FilterOptions{TimeLayouts: []string{time.RFC3339Nano}, FuzzyMetric: FuzzyLevenshtein, FuzzyThreshold: 0.8}
+}
+
+// SetFilterOptions sets the default FilterOptions of the library, used by the queries which are not given their own
+// (see GetOptions.FilterOptions). The zero values of the options are replaced by their defaults.
+//
+// It can be called at any time and the comparisons made after the call use the new options.
+func SetFilterOptions(opts FilterOptions) {
+	currentFilterOptions.Store(withFilterDefaults(opts))
+}
+
+// withFilterDefaults returns the options with their zero values replaced by their defaults.
+func withFilterDefaults(opts FilterOptions) FilterOptions {
+	if len(opts.TimeLayouts) == 0 {
+		opts.TimeLayouts = defaultFilterOptions().TimeLayouts
+	}
+	if opts.FuzzyMetric == "" {
+		opts.FuzzyMetric = defaultFilterOptions().FuzzyMetric
+	}
+	if opts.FuzzyThreshold == 0 {
+		opts.FuzzyThreshold = defaultFilterOptions().FuzzyThreshold
+	}
+
+	return opts
+}
+
+// bindFilterOptions returns a copy of the nodes with their filters bound to the provided options, so that a single
+// query can be run with its own options without affecting the rest. The nodes are returned as they are if the
+// options are nil.
+func bindFilterOptions(nodes []nodeDataAccessor, opts *FilterOptions) []nodeDataAccessor {
+	if opts == nil {
+		return nodes
+	}
+
+	bound := withFilterDefaults(*opts)
+	result := make([]nodeDataAccessor, len(nodes))
+	for i, n := range nodes {
+		if filter, ok := n.(arrayFilteredNode); ok {
+			filter.opts = &bound
+			n = filter
+		}
+		result[i] = n
+	}
+
+	return result
+}
+
+// unquoteFilterValue removes the single or double quotes surrounding the value of a filter, if any.
+func unquoteFilterValue(value string) string {
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// quoteFilterValue returns the value of a filter quoted if it cannot be written without quotes.
+func quoteFilterValue(value any) string {
+	s := fmt.Sprintf("%v", value)
+	if _, ok := value.(string); !ok || filterUnquotedValuePattern.MatchString(s) {
+		return s
+	}
+
+	return "'" + s + "'"
+}
+
+// filterTime converts a timestamp string of any of the configured layouts or a number of seconds to time.
+// It also returns whether the value is a timestamp string.
+func filterTime(value any, opts FilterOptions) (t time.Time, isTimestamp bool, ok bool) {
+	if s, isString := value.(string); isString {
+		for _, layout := range opts.TimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true, true
+			}
+		}
+	}
+
+	if f, err := gu.ToFloat64(value); err == nil {
+		return time.Unix(int64(f), 0), false, true
+	}
+
+	return time.Time{}, false, false
+}
+
+// compareTimes returns whether both values are timestamps along with -1, 0 or 1 depending on their chronological order.
+// At least one of them must be a timestamp string, the other may be a number of seconds.
+func compareTimes(val1, val2 any, opts FilterOptions) (int, bool) {
+	t1, isTimestamp1, ok1 := filterTime(val1, opts)
+	t2, isTimestamp2, ok2 := filterTime(val2, opts)
+	if !ok1 || !ok2 || (!isTimestamp1 && !isTimestamp2) {
+		return 0, false
+	}
+
+	switch {
+	case t1.Before(t2):
+		return -1, true
+	case t1.After(t2):
+		return 1, true
+	}
+
+	return 0, true
+}
+
+// floatsEqual returns whether the numbers are equal within the configured Epsilon.
+func floatsEqual(f1, f2 float64, opts FilterOptions) bool {
+	return math.Abs(f1-f2) <= opts.Epsilon
+}
+
+// compareFilterValues compares a value of the data with the value of a filter. It returns -1, 0 or 1 along with
+// whether the values have an order, i.e. booleans can only be equal or not, and whether they are comparable at all.
+//
+// The comparison rules are applied in order:
+//   - booleans are compared with booleans or with the `true` and `false` values
+//   - null is only equal to null or to the `null` value
+//   - numbers are compared numerically with numbers or numeric strings, the strings of the data included unless
+//     FilterOptions.StrictTypes is set
+//   - timestamps are compared chronologically (see FilterOptions.TimeLayouts)
+//   - strings are compared byte-wise or with FilterOptions.CompareStrings if set
+//
+// Any other combination, i.e. a number with a non numeric string, is not comparable.
+func compareFilterValues(val1, val2 any, opts FilterOptions) (c int, ordered bool, ok bool) {
+	if b1, isBool := val1.(bool); isBool {
+		b2, isBool := val2.(bool)
+		if s2, isString := val2.(string); isString {
+			parsed, err := strconv.ParseBool(s2)
+			b2, isBool = parsed, err == nil
+		}
+		if !isBool {
+			return 0, false, false
+		}
+		if b1 == b2 {
+			return 0, false, true
+		}
+		return 1, false, true
+	}
+
+	if val1 == nil {
+		if val2 == nil || val2 == "null" {
+			return 0, false, true
+		}
+		return 1, false, true
+	}
+
+	_, isString1 := val1.(string)
+	fval1, err1 := gu.ToFloat64(val1)
+	fval2, err2 := gu.ToFloat64(val2)
+	if err1 == nil && err2 == nil && !(isString1 && opts.StrictTypes) {
+		switch {
+		case floatsEqual(fval1, fval2, opts):
+			return 0, true, true
+		case fval1 < fval2:
+			return -1, true, true
+		}
+		return 1, true, true
+	}
+
+	if c, ok := compareTimes(val1, val2, opts); ok {
+		return c, true, true
+	}
+
+	s1, isString1 := val1.(string)
+	s2, isString2 := val2.(string)
+	if !isString1 || !isString2 {
+		return 0, false, false
+	}
+
+	if compare := opts.CompareStrings; compare != nil {
+		return compare(s1, s2), true, true
+	}
+
+	return strings.Compare(s1, s2), true, true
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two strings, from 0 to 1.
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	window := len(ra)
+	if len(rb) > window {
+		window = len(rb)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA, matchedB := make([]bool, len(ra)), make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		for j := i - window; j <= i+window; j++ {
+			if j >= 0 && j < len(rb) && !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions/2))/m) / 3
+
+	prefix := 0
+	for prefix < 4 && prefix < len(ra) && prefix < len(rb) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// similarity returns the similarity of two strings, from 0 to 1, according to the configured FuzzyMetric. The strings
+// are compared case-insensitively.
+func similarity(a, b string, opts FilterOptions) float64 {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+
+	if opts.FuzzyMetric == FuzzyJaroWinkler {
+		return jaroWinkler(a, b)
+	}
+
+	length := len([]rune(a))
+	if l := len([]rune(b)); l > length {
+		length = l
+	}
+	if length == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(a, b))/float64(length)
+}
+
+// similar returns whether the values are strings whose similarity reaches the configured FuzzyThreshold.
+func similar(val1, val2 any, opts FilterOptions) bool {
+	s1, ok1 := val1.(string)
+	s2, ok2 := val2.(string)
+
+	return ok1 && ok2 && similarity(s1, s2, opts) >= opts.FuzzyThreshold
+}
+
+// assertOrder asserts the operator on the result of a comparison.
+func assertOrder(c int, op string) bool {
+	switch op {
+	case "<":
+		return c < 0
+	case ">":
+		return c > 0
+	case "<=":
+		return c <= 0
+	case ">=":
+		return c >= 0
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	}
+
+	return false
+}
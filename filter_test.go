@@ -0,0 +1,339 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type AssertConditionTestCase struct {
+	val1           any
+	val2           any
+	op             string
+	expectedResult bool
+}
+
+func TestAssertConditionTimes(t *testing.T) {
+	testCases := []AssertConditionTestCase{
+		{"2023-06-01T00:00:00Z", "2023-01-01T00:00:00Z", ">", true},
+		{"2023-06-01T00:00:00Z", "2023-01-01T00:00:00Z", "<", false},
+		{"2023-01-01T02:00:00+02:00", "2023-01-01T00:00:00Z", "==", true},
+		{"2023-01-01T00:00:00.5Z", "2023-01-01T00:00:00Z", ">=", true},
+		{1672531200, "2023-01-01T00:00:00Z", "==", true},
+		{"2023-01-01T00:00:01Z", "1672531200", ">", true},
+		{1672531200, "1672531201", "<", true},
+		{"2023-01-01T10:00:00+05:00", "2023-01-01T06:00:00Z", "<", true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - assertCondition(%v, %v, %v)=%v", i, tc.val1, tc.val2, tc.op, tc.expectedResult), func(t *testing.T) {
+			if result := assertCondition(tc.val1, tc.val2, tc.op, defaultFilterOptions()); result != tc.expectedResult {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestAssertConditionMixedTypes(t *testing.T) {
+	testCases := []AssertConditionTestCase{
+		{"b", "a", ">", true},
+		{"a", "b", ">", false},
+		{"b", "a", ">=", true},
+		{"a", "a", ">", false},
+		{10, "9", ">", true},
+		{"10", "9", ">", true},
+		{9.5, "9.5", "==", true},
+		{10, "abc", "==", false},
+		{10, "abc", "!=", false},
+		{true, "true", "==", true},
+		{false, "true", "!=", true},
+		{true, "yes", "==", false},
+		{true, "false", ">", false},
+		{nil, "null", "==", true},
+		{nil, "abc", "!=", true},
+		{nil, "abc", "<", false},
+		{"true", true, "==", false},
+		{map[string]any{}, "abc", "==", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - assertCondition(%v, %v, %v)=%v", i, tc.val1, tc.val2, tc.op, tc.expectedResult), func(t *testing.T) {
+			if result := assertCondition(tc.val1, tc.val2, tc.op, defaultFilterOptions()); result != tc.expectedResult {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestFilterStrictTypes(t *testing.T) {
+	defer SetFilterOptions(FilterOptions{})
+
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"name": "a", "qty": "9"},
+			map[string]any{"name": "b", "qty": 20},
+			map[string]any{"name": "c", "qty": "30"},
+		},
+	}
+
+	names, _ := Get(data, "$.items[?(@.qty < 10)].name")
+	if expected := []any{"a"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+
+	SetFilterOptions(FilterOptions{StrictTypes: true})
+
+	// compared as strings `"9"` is greater than `"10"`
+	names, _ = Get(data, "$.items[?(@.qty > 10)].name")
+	if expected := []any{"a", "b", "c"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+}
+
+func TestSetFilterOptions(t *testing.T) {
+	defer SetFilterOptions(FilterOptions{})
+
+	data := map[string]any{
+		"events": []any{
+			map[string]any{"name": "a", "at": "2022-12-31"},
+			map[string]any{"name": "b", "at": "2023-01-02"},
+			map[string]any{"name": "c", "at": 1672617600},
+		},
+	}
+
+	SetFilterOptions(FilterOptions{TimeLayouts: []string{"2006-01-02"}})
+	names, err := Get(data, "$.events[?(@.at >= '2023-01-01')].name")
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	if expected := []any{"b", "c"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+
+	normalized, err := NormalizePath("$.events[?(@.at >= '2023-01-01 00:00')]")
+	if expected := "$['events'][?(@.at >= '2023-01-01 00:00')]"; err != nil || normalized != expected {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, normalized, err)
+	}
+}
+
+func TestFilterElementKeys(t *testing.T) {
+	data := map[string]any{
+		"books": []any{
+			map[string]any{"title": "a", "price": 8, "listPrice": 10},
+			map[string]any{"title": "b", "price": 12, "listPrice": 10},
+			map[string]any{"title": "c", "price": 10, "listPrice": 10},
+			map[string]any{"title": "d", "price": 5},
+		},
+	}
+
+	titles, err := Get(data, "$.books[?(@.price < @.listPrice)].title")
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	if expected := []any{"a"}; !cmp.Equal(expected, titles) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, titles)
+	}
+
+	if err := Put(data, "$.books[?(@.price >= @.listPrice)].title", "full price"); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	titles, _ = Get(data, "$.books.title")
+	if expected := []any{"a", "full price", "full price", "d"}; !cmp.Equal(expected, titles) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, titles)
+	}
+
+	normalized, err := NormalizePath("$.books[?(@.price < @.listPrice)]")
+	if expected := "$['books'][?(@.price < @.listPrice)]"; err != nil || normalized != expected {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, normalized, err)
+	}
+}
+
+type FilterEpsilonTestCase struct {
+	epsilon       float64
+	jsonPath      string
+	expectedNames []any
+}
+
+func TestFilterEpsilon(t *testing.T) {
+	defer SetFilterOptions(FilterOptions{})
+
+	data := map[string]any{
+		"places": []any{
+			map[string]any{"name": "a", "lat": 45.4422445000001},
+			map[string]any{"name": "b", "lat": 45.4422446},
+		},
+	}
+
+	testCases := []FilterEpsilonTestCase{
+		{0, "$.places[?(@.lat == 45.4422445)].name", nil},
+		{1e-9, "$.places[?(@.lat == 45.4422445)].name", []any{"a"}},
+		{1e-9, "$.places[?(@.lat != 45.4422445)].name", []any{"b"}},
+		{1e-6, "$.places[?(@.lat == 45.4422445)].name", []any{"a", "b"}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Get(%v) with epsilon %v", i, tc.jsonPath, tc.epsilon), func(t *testing.T) {
+			SetFilterOptions(FilterOptions{Epsilon: tc.epsilon})
+			names, err := Get(data, tc.jsonPath)
+			if err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+			if !cmp.Equal(tc.expectedNames, names) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestFilterCompareStrings(t *testing.T) {
+	defer SetFilterOptions(FilterOptions{})
+
+	data := map[string]any{
+		"authors": []any{
+			map[string]any{"name": "Zola"},
+			map[string]any{"name": "Émile"},
+			map[string]any{"name": "eco"},
+		},
+	}
+
+	names, _ := Get(data, "$.authors[?(@.name < f)].name")
+	if expected := []any{"Zola", "eco"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+
+	// a simplified collation ignoring the case and the accents of the first letter
+	fold := strings.NewReplacer("É", "e", "é", "e")
+	SetFilterOptions(FilterOptions{CompareStrings: func(a, b string) int {
+		return strings.Compare(strings.ToLower(fold.Replace(a)), strings.ToLower(fold.Replace(b)))
+	}})
+
+	names, _ = Get(data, "$.authors[?(@.name < f)].name")
+	if expected := []any{"Émile", "eco"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+
+	names, _ = Get(data, "$.authors[?(@.name == ECO)].name")
+	if expected := []any{"eco"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+}
+
+func TestFilterFuzzy(t *testing.T) {
+	defer SetFilterOptions(FilterOptions{})
+
+	data := map[string]any{
+		"authors": []any{
+			map[string]any{"name": "Nietzsche"},
+			map[string]any{"name": "nietzche"},
+			map[string]any{"name": "Stirner"},
+			map[string]any{"name": 10},
+		},
+	}
+
+	names, err := Get(data, "$.authors[?(@.name ~= 'Nietsche')].name")
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	if expected := []any{"Nietzsche", "nietzche"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+
+	SetFilterOptions(FilterOptions{FuzzyThreshold: 0.95})
+	names, _ = Get(data, "$.authors[?(@.name ~= 'Nietsche')].name")
+	if expected := []any(nil); !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+
+	SetFilterOptions(FilterOptions{FuzzyMetric: FuzzyJaroWinkler, FuzzyThreshold: 0.9})
+	names, _ = Get(data, "$.authors[?(@.name ~= Stirnr)].name")
+	if expected := []any{"Stirner"}; !cmp.Equal(expected, names) {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, names)
+	}
+
+	normalized, err := NormalizePath("$.authors[?(@.name ~= 'Nietsche')]")
+	if expected := "$['authors'][?(@.name ~= Nietsche)]"; err != nil || normalized != expected {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, normalized, err)
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	testCases := map[[2]string]float64{
+		{"MARTHA", "MARHTA"}:  0.961,
+		{"DIXON", "DICKSONX"}: 0.813,
+		{"abc", "xyz"}:        0,
+		{"", ""}:              1,
+	}
+
+	for strs, expected := range testCases {
+		if result := jaroWinkler(strs[0], strs[1]); math.Abs(result-expected) > 0.001 {
+			t.Errorf("Expected jaroWinkler(%v, %v)=%v, but got %v", strs[0], strs[1], expected, result)
+		}
+	}
+}
+
+type GetWithFilterOptionsTestCase struct {
+	jsonPath       string
+	opts           GetOptions
+	expectedResult any
+}
+
+func TestGetWithFilterOptions(t *testing.T) {
+	defer SetFilterOptions(FilterOptions{})
+	SetFilterOptions(FilterOptions{StrictTypes: true})
+
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"name": "a", "qty": "9", "at": "2022-12-31", "price": 0.30000000000000004},
+			map[string]any{"name": "Nietzsche", "qty": 20, "at": "2023-01-02", "price": 0.3},
+			map[string]any{"name": "Émile", "qty": "30", "at": 1672617600, "price": 1},
+		},
+	}
+
+	testCases := []GetWithFilterOptionsTestCase{
+		{jsonPath: "$.items[?(@.qty < 10)].name", opts: GetOptions{}, expectedResult: []any(nil)},
+		{jsonPath: "$.items[?(@.qty < 10)].name", opts: GetOptions{FilterOptions: &FilterOptions{}}, expectedResult: []any{"a"}},
+		{jsonPath: "$.items[?(@.qty < 10)].name", opts: GetOptions{Limit: 1, FilterOptions: &FilterOptions{}}, expectedResult: []any{"a"}},
+		{
+			jsonPath:       "$.items[?(@.at >= '2023-01-01')].name",
+			opts:           GetOptions{FilterOptions: &FilterOptions{TimeLayouts: []string{"2006-01-02"}}},
+			expectedResult: []any{"Nietzsche", "Émile"},
+		},
+		{
+			jsonPath:       "$.items[?(@.price == 0.3)].name",
+			opts:           GetOptions{FilterOptions: &FilterOptions{Epsilon: 1e-9}},
+			expectedResult: []any{"a", "Nietzsche"},
+		},
+		{
+			jsonPath: "$.items[?(@.name < f)].name",
+			opts: GetOptions{FilterOptions: &FilterOptions{CompareStrings: func(a, b string) int {
+				return strings.Compare(strings.ToLower(strings.ReplaceAll(a, "É", "e")), strings.ToLower(b))
+			}}},
+			expectedResult: []any{"a", "Émile"},
+		},
+		{
+			jsonPath:       "$.items[?(@.name ~= Nietsche)].name",
+			opts:           GetOptions{FilterOptions: &FilterOptions{FuzzyThreshold: 0.95}},
+			expectedResult: []any(nil),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - GetWithOptions(%v)", i, tc.jsonPath), func(t *testing.T) {
+			result, err := GetWithOptions(data, tc.jsonPath, tc.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedResult, result)
+			}
+		})
+	}
+
+	// the options of a query do not change the ones set by SetFilterOptions
+	if opts := getFilterOptions(); !opts.StrictTypes || opts.Epsilon != 0 || opts.FuzzyThreshold != 0.8 {
+		t.Errorf("Expected the options set by SetFilterOptions, but got '%#v'", opts)
+	}
+}
@@ -0,0 +1,102 @@
+package jsonmanu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var fuzzSeedPaths = []string{
+	"$",
+	"$.store.books",
+	"$.store.books[*].title",
+	"$.store.books[0,1].title",
+	"$.store.books[1:3].price",
+	"$.store.books[-1:].price",
+	"$.store.books[?(@.price > 10)].title",
+	"$.store.books[?(@.isbn)]",
+	"$..price",
+	"$..books[0].title",
+	"$.store.*",
+	"$.store.name.first",
+	"$.store.books.title.x",
+	"$...",
+	"$.[",
+}
+
+var fuzzSeedData = []string{
+	`{"store": {"name": "Store1", "books": [{"title": "Book1", "price": 15}, {"title": "Book2", "price": 5, "isbn": "1"}, 3, null, [1]]}}`,
+	`{"store": {"books": "none"}}`,
+	`{"store": null}`,
+	`{}`,
+}
+
+// fuzzData decodes the fuzzed JSON object, skipping the inputs that are not objects.
+func fuzzData(t *testing.T, input string) map[string]any {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(input), &data); err != nil || data == nil {
+		t.Skip()
+	}
+
+	return data
+}
+
+func FuzzParseJsonPath(f *testing.F) {
+	for _, path := range fuzzSeedPaths {
+		f.Add(path)
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		nodes, err := parseJsonPath(path)
+		if err != nil && nodes != nil {
+			t.Errorf("Expected no nodes along with error '%v', but got '%#v'", err, nodes)
+		}
+	})
+}
+
+func FuzzGet(f *testing.F) {
+	for _, path := range fuzzSeedPaths {
+		for _, data := range fuzzSeedData {
+			f.Add(path, data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, path string, input string) {
+		data := fuzzData(t, input)
+		original := deepCopy(data)
+
+		Get(data, path)
+
+		if !cmp.Equal(original, data) {
+			t.Errorf("Get(%v) mutated the data: '%v' became '%v'", path, original, data)
+		}
+	})
+}
+
+func FuzzPut(f *testing.F) {
+	for _, path := range fuzzSeedPaths {
+		for _, data := range fuzzSeedData {
+			f.Add(path, data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, path string, input string) {
+		data := fuzzData(t, input)
+		original := deepCopy(data).(map[string]any)
+
+		err := Put(data, path, "fuzz")
+
+		nodes, parseErr := parseJsonPath(path)
+		if parseErr != nil || len(nodes) == 0 || jsonPathHasReccursiveDescent(path) || nodes[0].getName() == "*" {
+			return
+		}
+
+		// only the branch under the first node of the path may change
+		for key, value := range original {
+			if key != nodes[0].getName() && !cmp.Equal(value, data[key]) {
+				t.Errorf("Put(%v) corrupted sibling branch '%v': '%v' became '%v' (%v)", path, key, value, data[key], err)
+			}
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// The axes of DMSTransformer.
+const (
+	GeoAxisLat = "lat"
+	GeoAxisLon = "lon"
+)
+
+// degreesPattern matches a coordinate in decimal degrees, i.e. `-79.982`, or in degrees, minutes and seconds, i.e.
+// `40°26'46"N`.
+var degreesPattern = regexp.MustCompile(`([+-]?\d+(?:\.\d+)?)\s*°?\s*(?:(\d+(?:\.\d+)?)\s*['′]\s*)?(?:(\d+(?:\.\d+)?)\s*(?:["″]|''|′′)\s*)?([NSEWnsew])?`)
+
+// coordinatesSeparatorPattern matches what may separate the latitude and the longitude of a pair of coordinates.
+var coordinatesSeparatorPattern = regexp.MustCompile(`^[\s,;/]*$`)
+
+// parseDegrees converts the submatches of degreesPattern to decimal degrees along with the axis implied by the
+// hemisphere, if any.
+func parseDegrees(submatches []string) (float64, string, error) {
+	degrees, _ := strconv.ParseFloat(submatches[1], 64)
+
+	for i, max := range []float64{60, 60} {
+		if submatches[i+2] == "" {
+			continue
+		}
+		part, _ := strconv.ParseFloat(submatches[i+2], 64)
+		if part >= max {
+			return 0, "", fmt.Errorf("Invalid coordinate '%v'.", strings.TrimSpace(submatches[0]))
+		}
+		degrees += math.Copysign(part/math.Pow(60, float64(i+1)), degrees)
+	}
+
+	axis := ""
+	switch strings.ToUpper(submatches[4]) {
+	case "N":
+		axis = GeoAxisLat
+	case "S":
+		axis, degrees = GeoAxisLat, -degrees
+	case "E":
+		axis = GeoAxisLon
+	case "W":
+		axis, degrees = GeoAxisLon, -degrees
+	}
+
+	return degrees, axis, nil
+}
+
+// validateCoordinates checks that the latitude and the longitude are within their ranges.
+func validateCoordinates(lat, lon float64) error {
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("Latitude %v out of bound.", lat)
+	}
+	if lon < -180 || lon > 180 {
+		return fmt.Errorf("Longitude %v out of bound.", lon)
+	}
+
+	return nil
+}
+
+// CoordinatesTransformer parses a pair of coordinates, i.e. `40.446, -79.982` or `40°26'46"N 79°58'56"W`, to an
+// object of the latitude and the longitude in decimal degrees, i.e. `{"lat": 40.446, "lon": -79.982}`.
+type CoordinatesTransformer struct {
+
+	// LonFirst means that the longitude precedes the latitude, i.e. as in GeoJSON. It is ignored if the hemispheres
+	// of the coordinates tell their order.
+	LonFirst bool
+}
+
+// CoordinatesTransformer Transform applies the coordinates transformation.
+//
+// It expects a string value holding two coordinates in decimal degrees or in degrees, minutes and seconds separated
+// by spaces, a comma, a semicolon or a slash.
+func (t CoordinatesTransformer) Transform(value any) (any, error) {
+	if !gu.IsString(value) {
+		return nil, errors.New("Value is not a string.")
+	}
+	s := value.(string)
+
+	indices := degreesPattern.FindAllStringSubmatchIndex(s, -1)
+	if len(indices) != 2 {
+		return nil, fmt.Errorf("Couldn't convert '%v' to coordinates.", value)
+	}
+
+	var coordinates [2]float64
+	var axes [2]string
+	previousEnd := 0
+	for i, index := range indices {
+		if !coordinatesSeparatorPattern.MatchString(s[previousEnd:index[0]]) {
+			return nil, fmt.Errorf("Couldn't convert '%v' to coordinates.", value)
+		}
+		previousEnd = index[1]
+
+		submatches := make([]string, len(index)/2)
+		for j := range submatches {
+			if index[2*j] >= 0 {
+				submatches[j] = s[index[2*j]:index[2*j+1]]
+			}
+		}
+
+		var err error
+		if coordinates[i], axes[i], err = parseDegrees(submatches); err != nil {
+			return nil, err
+		}
+	}
+	if strings.TrimSpace(s[previousEnd:]) != "" {
+		return nil, fmt.Errorf("Couldn't convert '%v' to coordinates.", value)
+	}
+
+	if axes[0] != "" && axes[0] == axes[1] {
+		return nil, fmt.Errorf("Couldn't convert '%v' to coordinates.", value)
+	}
+
+	lat, lon := coordinates[0], coordinates[1]
+	if axes[0] == GeoAxisLon || axes[1] == GeoAxisLat || (axes[0] == "" && axes[1] == "" && t.LonFirst) {
+		lat, lon = lon, lat
+	}
+
+	if err := validateCoordinates(lat, lon); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"lat": lat, "lon": lon}, nil
+}
+
+// DecimalDegreesTransformer converts a coordinate in degrees, minutes and seconds, i.e. `40°26'46"N`, to decimal
+// degrees, i.e. `40.446111`.
+type DecimalDegreesTransformer struct{}
+
+// DecimalDegreesTransformer Transform applies the decimal degrees transformation.
+//
+// It expects a string value. The southern and western hemispheres result in negative degrees.
+func (t DecimalDegreesTransformer) Transform(value any) (any, error) {
+	if !gu.IsString(value) {
+		return nil, errors.New("Value is not a string.")
+	}
+
+	s := strings.TrimSpace(value.(string))
+	submatches := degreesPattern.FindStringSubmatch(s)
+	if submatches == nil || submatches[0] != s {
+		return nil, fmt.Errorf("Couldn't convert '%v' to degrees.", value)
+	}
+
+	degrees, _, err := parseDegrees(submatches)
+	if err != nil {
+		return nil, err
+	}
+
+	return degrees, nil
+}
+
+// DMSTransformer converts a coordinate in decimal degrees, i.e. `-79.982`, to degrees, minutes and seconds, i.e.
+// `79°58'55"W`.
+type DMSTransformer struct {
+
+	// Axis is the axis of the coordinate. It can be one of GeoAxisLat and GeoAxisLon in which case the hemisphere
+	// is appended instead of the sign.
+	Axis string
+
+	// Precision is the number of the decimals of the seconds.
+	Precision int
+}
+
+// DMSTransformer Transform applies the DMS transformation.
+//
+// It expects a number value within the range of the Axis if any.
+func (t DMSTransformer) Transform(value any) (any, error) {
+	if !isNumber(value) {
+		return nil, errors.New("Value is not a number.")
+	}
+	degrees, _ := gu.ToFloat64(value)
+
+	if t.Axis != "" && t.Axis != GeoAxisLat && t.Axis != GeoAxisLon {
+		return nil, fmt.Errorf("Unknown axis '%v'.", t.Axis)
+	}
+	if (t.Axis == GeoAxisLat && math.Abs(degrees) > 90) || math.Abs(degrees) > 180 {
+		return nil, fmt.Errorf("Coordinate %v out of bound.", degrees)
+	}
+
+	scale := math.Pow(10, float64(t.Precision))
+	seconds := math.Round(math.Abs(degrees)*3600*scale) / scale
+	d := math.Floor(seconds / 3600)
+	m := math.Floor((seconds - d*3600) / 60)
+	seconds = seconds - d*3600 - m*60
+
+	dms := fmt.Sprintf(`%v°%v'%.*f"`, d, m, t.Precision, seconds)
+
+	switch {
+	case t.Axis == GeoAxisLat && degrees < 0:
+		return dms + "S", nil
+	case t.Axis == GeoAxisLat:
+		return dms + "N", nil
+	case t.Axis == GeoAxisLon && degrees < 0:
+		return dms + "W", nil
+	case t.Axis == GeoAxisLon:
+		return dms + "E", nil
+	case degrees < 0:
+		return "-" + dms, nil
+	}
+
+	return dms, nil
+}
+
+// geohashAlphabet is the base 32 alphabet of the geohashes.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// geohash encodes the coordinates to a geohash of the provided length.
+func geohash(lat, lon float64, precision int) string {
+	latRange, lonRange := [2]float64{-90, 90}, [2]float64{-180, 180}
+
+	var hash strings.Builder
+	bits, char, even := 0, 0, true
+	for hash.Len() < precision {
+		r, v := &latRange, lat
+		if even {
+			r, v = &lonRange, lon
+		}
+
+		middle := (r[0] + r[1]) / 2
+		char <<= 1
+		if v >= middle {
+			char |= 1
+			r[0] = middle
+		} else {
+			r[1] = middle
+		}
+		even = !even
+
+		if bits++; bits == 5 {
+			hash.WriteByte(geohashAlphabet[char])
+			bits, char = 0, 0
+		}
+	}
+
+	return hash.String()
+}
+
+// GeohashTransformer computes the geohash of a pair of coordinates, i.e. `dppn59uz` for `{"lat": 40.446, "lon": -79.982}`.
+type GeohashTransformer struct {
+
+	// Precision is the length of the geohash, from 1 to 12. It defaults to 9.
+	Precision int
+}
+
+// GeohashTransformer Transform applies the geohash transformation.
+//
+// It expects either an object of the latitude and the longitude in decimal degrees, as returned by
+// CoordinatesTransformer, or an array of them in this order, in which case the transformation should be configured
+// with AsArray.
+func (t GeohashTransformer) Transform(value any) (any, error) {
+	precision := t.Precision
+	if precision == 0 {
+		precision = 9
+	}
+	if precision < 1 || precision > 12 {
+		return nil, errors.New("Precision out of bound.")
+	}
+
+	var latValue, lonValue any
+	switch v := value.(type) {
+	case map[string]any:
+		latValue, lonValue = v["lat"], v["lon"]
+	case []any:
+		if len(v) != 2 {
+			return nil, errors.New("Value is not a pair of coordinates.")
+		}
+		latValue, lonValue = v[0], v[1]
+	default:
+		return nil, errors.New("Value is not an object or an array.")
+	}
+
+	if !isNumber(latValue) || !isNumber(lonValue) {
+		return nil, errors.New("Value is not a pair of coordinates.")
+	}
+	lat, _ := gu.ToFloat64(latValue)
+	lon, _ := gu.ToFloat64(lonValue)
+
+	if err := validateCoordinates(lat, lon); err != nil {
+		return nil, err
+	}
+
+	return geohash(lat, lon, precision), nil
+}
@@ -0,0 +1,137 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGeoTransformers(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              CoordinatesTransformer{},
+			value:                    "40.446, -79.982",
+			expectedTransformedValue: map[string]any{"lat": 40.446, "lon": -79.982},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              CoordinatesTransformer{LonFirst: true},
+			value:                    "-79.982 40.446",
+			expectedTransformedValue: map[string]any{"lat": 40.446, "lon": -79.982},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              CoordinatesTransformer{},
+			value:                    `79°45'W 40°30'0"N`,
+			expectedTransformedValue: map[string]any{"lat": 40.5, "lon": -79.75},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              CoordinatesTransformer{},
+			value:                    "40.446 N, 79.982 S",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '40.446 N, 79.982 S' to coordinates.",
+		},
+		{
+			transformer:              CoordinatesTransformer{},
+			value:                    "40.446 x -79.982",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '40.446 x -79.982' to coordinates.",
+		},
+		{
+			transformer:              CoordinatesTransformer{},
+			value:                    "-95.5, 40.446",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Latitude -95.5 out of bound.",
+		},
+		{
+			transformer:              CoordinatesTransformer{},
+			value:                    `40°60'N 79°W`,
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Invalid coordinate '40°60'N'.",
+		},
+		{
+			transformer:              DecimalDegreesTransformer{},
+			value:                    `40°26'46"N`,
+			expectedTransformedValue: 40 + 26.0/60 + 46.0/3600,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DecimalDegreesTransformer{},
+			value:                    `79° 58.5' W`,
+			expectedTransformedValue: -79.975,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DecimalDegreesTransformer{},
+			value:                    "north",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert 'north' to degrees.",
+		},
+		{
+			transformer:              DMSTransformer{Axis: GeoAxisLat},
+			value:                    40.446111,
+			expectedTransformedValue: `40°26'46"N`,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DMSTransformer{Axis: GeoAxisLon, Precision: 1},
+			value:                    -79.982,
+			expectedTransformedValue: `79°58'55.2"W`,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DMSTransformer{},
+			value:                    -0.5,
+			expectedTransformedValue: `-0°30'0"`,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              DMSTransformer{Axis: GeoAxisLat},
+			value:                    -91,
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Coordinate -91 out of bound.",
+		},
+		{
+			transformer:              GeohashTransformer{Precision: 11},
+			value:                    map[string]any{"lat": 57.64911, "lon": 10.40744},
+			expectedTransformedValue: "u4pruydqqvj",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              GeohashTransformer{Precision: 5},
+			value:                    []any{57.64911, 10.40744},
+			expectedTransformedValue: "u4pru",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              GeohashTransformer{},
+			value:                    map[string]any{"lat": "57.64911", "lon": 10.40744},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not a pair of coordinates.",
+		},
+		{
+			transformer:              GeohashTransformer{Precision: 13},
+			value:                    []any{57.64911, 10.40744},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Precision out of bound.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%T.transform(%v)=%v", tc.transformer, tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
@@ -3,8 +3,17 @@ module github.com/antavelos/jsonmanu
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/antavelos/go-utils v0.0.1
+	github.com/fxamacker/cbor/v2 v2.5.0
 	github.com/google/go-cmp v0.5.9
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
 )
 
 // fix version inconsistencies
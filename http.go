@@ -0,0 +1,193 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HTTPMappers holds the mappers to be applied on HTTP bodies.
+type HTTPMappers struct {
+
+	// Request holds the mappers to be applied on the request bodies. No mapping occurs if empty.
+	Request []Mapper
+
+	// Response holds the mappers to be applied on the response bodies. No mapping occurs if empty.
+	Response []Mapper
+}
+
+// isJSONContent returns whether the provided header describes a JSON content.
+func isJSONContent(header http.Header) bool {
+	return strings.Contains(header.Get("Content-Type"), "json")
+}
+
+// mapJSONBody maps a JSON object body to a new one based on the provided mappers.
+func mapJSONBody(body []byte, mappers []Mapper) ([]byte, error) {
+	var src map[string]any
+	if err := json.Unmarshal(body, &src); err != nil {
+		return nil, err
+	}
+
+	dst := make(map[string]any)
+	if errs := Map(src, dst, mappers); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return json.Marshal(dst)
+}
+
+// isEmptyBody returns whether the body holds nothing to be mapped.
+func isEmptyBody(body []byte) bool {
+	return len(bytes.TrimSpace(body)) == 0
+}
+
+// mapRequestBody replaces the body of a JSON request with its mapped version. Empty bodies are left as they are.
+//
+// The GetBody of the request returns the mapped body as well so that retries and redirects do not send the original.
+func mapRequestBody(req *http.Request, mappers []Mapper) error {
+	if len(mappers) == 0 || req.Body == nil || !isJSONContent(req.Header) {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	mapped := body
+	if !isEmptyBody(body) {
+		if mapped, err = mapJSONBody(body, mappers); err != nil {
+			return err
+		}
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(mapped))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(mapped)), nil }
+	req.ContentLength = int64(len(mapped))
+	req.Header.Set("Content-Length", strconv.Itoa(len(mapped)))
+
+	return nil
+}
+
+// bufferedResponseWriter holds the response written by a handler so it can be modified before sent.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header { return w.header }
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// MappingHandler wraps an HTTP handler so that the JSON request and response bodies are mapped on the fly.
+//
+// Only the non-empty bodies with a JSON content type are mapped, the rest pass through untouched. If a request body
+// cannot be mapped a 400 response is returned without calling the wrapped handler. If a response body cannot
+// be mapped a 500 response is returned instead.
+func MappingHandler(next http.Handler, mappers HTTPMappers) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := mapRequestBody(req, mappers.Request); err != nil {
+			http.Error(w, fmt.Sprintf("Request mapping error: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if len(mappers.Response) == 0 {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		buffered := &bufferedResponseWriter{header: make(http.Header)}
+		next.ServeHTTP(buffered, req)
+
+		body := buffered.body.Bytes()
+		if isJSONContent(buffered.header) && !isEmptyBody(body) {
+			mapped, err := mapJSONBody(body, mappers.Response)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Response mapping error: %v", err), http.StatusInternalServerError)
+				return
+			}
+			body = mapped
+		}
+
+		for key, values := range buffered.header {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+
+		if buffered.status != 0 {
+			w.WriteHeader(buffered.status)
+		}
+		w.Write(body)
+	})
+}
+
+// MappingTransport is an http.RoundTripper that maps the JSON request and response bodies on the fly.
+//
+// Only the non-empty bodies with a JSON content type are mapped, the rest pass through untouched. The mapped request
+// bodies are the ones sent on retries and redirects as well.
+type MappingTransport struct {
+
+	// Base is the underlying RoundTripper. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// Mappers holds the mappers to be applied on the request and response bodies.
+	Mappers HTTPMappers
+}
+
+// RoundTrip executes a single HTTP transaction mapping the request and response bodies.
+func (t MappingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if len(t.Mappers.Request) > 0 && req.Body != nil && isJSONContent(req.Header) {
+		req = req.Clone(req.Context())
+		if err := mapRequestBody(req, t.Mappers.Request); err != nil {
+			return nil, fmt.Errorf("Request mapping error: %w", err)
+		}
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(t.Mappers.Response) == 0 || !isJSONContent(resp.Header) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if isEmptyBody(body) {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	mapped, err := mapJSONBody(body, t.Mappers.Response)
+	if err != nil {
+		return nil, fmt.Errorf("Response mapping error: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(mapped))
+	resp.ContentLength = int64(len(mapped))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(mapped)))
+
+	return resp, nil
+}
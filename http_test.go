@@ -0,0 +1,171 @@
+package jsonmanu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var httpTestMappers = HTTPMappers{
+	Request:  []Mapper{{SrcJsonPath: "$.name", DstJsonPath: "$.user.fullName"}},
+	Response: []Mapper{{SrcJsonPath: "$.user.fullName", DstJsonPath: "$.name"}},
+}
+
+// echoHandler writes back the request body with the request content type.
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	w.Header().Set("Content-Type", req.Header.Get("Content-Type"))
+	w.Header().Set("X-Request-Body", string(body))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+})
+
+type MappingHandlerTestCase struct {
+	contentType          string
+	body                 string
+	expectedStatus       int
+	expectedRequestBody  string
+	expectedResponseBody string
+}
+
+func TestMappingHandler(t *testing.T) {
+	testCases := []MappingHandlerTestCase{
+		{
+			contentType:          "application/json",
+			body:                 `{"name": "Nietzsche"}`,
+			expectedStatus:       http.StatusCreated,
+			expectedRequestBody:  `{"user":{"fullName":"Nietzsche"}}`,
+			expectedResponseBody: `{"name":"Nietzsche"}`,
+		},
+		{
+			contentType:          "text/plain",
+			body:                 `{"name": "Nietzsche"}`,
+			expectedStatus:       http.StatusCreated,
+			expectedRequestBody:  `{"name": "Nietzsche"}`,
+			expectedResponseBody: `{"name": "Nietzsche"}`,
+		},
+		{
+			contentType:          "application/json",
+			body:                 "",
+			expectedStatus:       http.StatusCreated,
+			expectedRequestBody:  "",
+			expectedResponseBody: "",
+		},
+		{
+			contentType:          "application/json",
+			body:                 `["Nietzsche"]`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedRequestBody:  "",
+			expectedResponseBody: "Request mapping error: json: cannot unmarshal array into Go value of type map[string]interface {}\n",
+		},
+	}
+
+	handler := MappingHandler(echoHandler, httpTestMappers)
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - MappingHandler(%v, %v)", i, tc.contentType, tc.body), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %v, but got %v", tc.expectedStatus, rec.Code)
+			}
+			if requestBody := rec.Header().Get("X-Request-Body"); requestBody != tc.expectedRequestBody {
+				t.Errorf("Expected request body '%v', but got '%v'", tc.expectedRequestBody, requestBody)
+			}
+			if rec.Body.String() != tc.expectedResponseBody {
+				t.Errorf("Expected response body '%v', but got '%v'", tc.expectedResponseBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestMappingHandlerResponseMappingError(t *testing.T) {
+	handler := MappingHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"price": 10}`))
+		}),
+		HTTPMappers{Response: []Mapper{{
+			SrcJsonPath:     "$.price",
+			DstJsonPath:     "$.price",
+			Transformations: []Transformation{{Trsnfmr: NumberTransformer{}}},
+		}}},
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %v, but got %v", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestMappingTransport(t *testing.T) {
+	server := httptest.NewServer(echoHandler)
+	defer server.Close()
+
+	client := &http.Client{Transport: MappingTransport{Mappers: httpTestMappers}}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name": "Nietzsche"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if requestBody := resp.Header.Get("X-Request-Body"); requestBody != `{"user":{"fullName":"Nietzsche"}}` {
+		t.Errorf("Expected request body '%v', but got '%v'", `{"user":{"fullName":"Nietzsche"}}`, requestBody)
+	}
+
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if body["name"] != "Nietzsche" {
+		t.Errorf("Expected 'Nietzsche', but got '%v'", body["name"])
+	}
+}
+
+// roundTripFunc is an http.RoundTripper out of a function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestMappingTransportGetBody(t *testing.T) {
+	var resent string
+	transport := MappingTransport{
+		Mappers: httpTestMappers,
+		Base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			// the body is read again as it happens when a request is retried
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			raw, _ := io.ReadAll(body)
+			resent = string(raw)
+
+			return &http.Response{StatusCode: http.StatusNoContent, Header: http.Header{"Content-Type": {"application/json"}}, Body: http.NoBody}, nil
+		}),
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(`{"name": "Nietzsche"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `{"user":{"fullName":"Nietzsche"}}`; resent != expected {
+		t.Errorf("Expected request body '%v', but got '%v'", expected, resent)
+	}
+	if body, _ := io.ReadAll(resp.Body); len(body) != 0 {
+		t.Errorf("Expected an empty response body, but got '%s'", body)
+	}
+}
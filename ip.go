@@ -0,0 +1,88 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// The outputs of IPTransformer.
+const (
+	IPOutputAddress = "address"
+	IPOutputNetwork = "network"
+	IPOutputVersion = "version"
+)
+
+// IPTransformer validates and normalizes IP addresses, i.e. `2001:DB8:0:0::1` to `2001:db8::1`, and optionally
+// converts them to their network, i.e. `10.1.2.3` to `10.1.2.0/24`, or to their version, i.e. `v4`.
+type IPTransformer struct {
+
+	// Output is the form of the result. It can be one of IPOutputAddress, IPOutputNetwork and IPOutputVersion and it
+	// defaults to IPOutputAddress.
+	Output string
+
+	// PrefixLen is the length of the prefix of the network, i.e. 24 for `10.1.2.0/24`. If 0, the one of the value is
+	// used if the value is in the CIDR notation.
+	PrefixLen int
+}
+
+// IPTransformer Transform applies the IP transformation.
+//
+// It expects a string value holding an IPv4 or an IPv6 address, optionally in the CIDR notation, i.e.
+// `10.1.2.3/24`. The IPv4 addresses mapped to IPv6, i.e. `::ffff:10.1.2.3`, are converted to IPv4 and the zones of
+// the IPv6 addresses are kept unless the network is requested.
+func (t IPTransformer) Transform(value any) (any, error) {
+	if t.Output != "" && t.Output != IPOutputAddress && t.Output != IPOutputNetwork && t.Output != IPOutputVersion {
+		return nil, fmt.Errorf("Unknown IP output '%v'.", t.Output)
+	}
+
+	if !gu.IsString(value) {
+		return nil, errors.New("Value is not a string.")
+	}
+
+	s, prefixLen := strings.TrimSpace(value.(string)), -1
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't convert '%v' to IP address.", value)
+		}
+		s, prefixLen = prefix.Addr().String(), prefix.Bits()
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't convert '%v' to IP address.", value)
+	}
+	if addr.Is4In6() {
+		if prefixLen >= 0 {
+			prefixLen -= 96
+		}
+		addr = addr.Unmap()
+	}
+
+	switch t.Output {
+	case IPOutputVersion:
+		if addr.Is4() {
+			return "v4", nil
+		}
+		return "v6", nil
+	case IPOutputNetwork:
+		if t.PrefixLen != 0 {
+			prefixLen = t.PrefixLen
+		}
+		if prefixLen < 0 {
+			return nil, fmt.Errorf("Prefix length of '%v' is missing.", value)
+		}
+
+		prefix, err := addr.WithZone("").Prefix(prefixLen)
+		if err != nil {
+			return nil, fmt.Errorf("Prefix length %v out of bound.", prefixLen)
+		}
+		return prefix.String(), nil
+	}
+
+	return addr.String(), nil
+}
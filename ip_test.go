@@ -0,0 +1,107 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIPTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              IPTransformer{},
+			value:                    " 2001:DB8:0:0::1 ",
+			expectedTransformedValue: "2001:db8::1",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{},
+			value:                    "::ffff:10.1.2.3",
+			expectedTransformedValue: "10.1.2.3",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{},
+			value:                    "10.1.2.3/24",
+			expectedTransformedValue: "10.1.2.3",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputNetwork},
+			value:                    "10.1.2.3/24",
+			expectedTransformedValue: "10.1.2.0/24",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputNetwork, PrefixLen: 16},
+			value:                    "10.1.2.3/24",
+			expectedTransformedValue: "10.1.0.0/16",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputNetwork, PrefixLen: 48},
+			value:                    "fe80::1:2:3%eth0",
+			expectedTransformedValue: "fe80::/48",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputNetwork},
+			value:                    "::ffff:10.1.2.3/120",
+			expectedTransformedValue: "10.1.2.0/24",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputNetwork, PrefixLen: 33},
+			value:                    "10.1.2.3",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Prefix length 33 out of bound.",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputNetwork},
+			value:                    "10.1.2.3",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Prefix length of '10.1.2.3' is missing.",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputVersion},
+			value:                    "10.1.2.3",
+			expectedTransformedValue: "v4",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{Output: IPOutputVersion},
+			value:                    "::1",
+			expectedTransformedValue: "v6",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IPTransformer{},
+			value:                    "10.1.2.256",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '10.1.2.256' to IP address.",
+		},
+		{
+			transformer:              IPTransformer{Output: "mask"},
+			value:                    "10.1.2.3",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Unknown IP output 'mask'.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("IPTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
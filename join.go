@@ -0,0 +1,70 @@
+package jsonmanu
+
+import (
+	"fmt"
+)
+
+// JoinKind is the kind of the join of two record sets.
+type JoinKind string
+
+const (
+	// JoinInner keeps only the left records which have matching right records.
+	JoinInner JoinKind = "inner"
+
+	// JoinLeft keeps all the left records, the ones without matching right records joined with nil.
+	JoinLeft JoinKind = "left"
+)
+
+// joinKey returns the key of a record retrieved by the JSONPath as a string so that i.e. 1 matches 1.0. It returns
+// false if the record has no key.
+func joinKey(record map[string]any, keyPath string) (string, bool) {
+	key, err := Get(record, keyPath)
+	if err != nil || key == nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("%v", key), true
+}
+
+// JoinRecords joins two record sets, i.e. the responses of two APIs, by matching the key of each left record, as
+// retrieved by the left JSONPath, with the keys of the right records, as retrieved by the right JSONPath. The keys are
+// compared by their string representation and records without a key match nothing.
+//
+// Each joined record holds the left record under `left` and the matching right record under `right`, i.e.
+// `{"left": {...}, "right": {...}}`, so that it can be mapped with paths like `$.right.name`. A left record matching
+// multiple right records is joined with each one of them in their order. The records are shared with the record sets.
+func JoinRecords(left, right []map[string]any, leftKeyPath, rightKeyPath string, kind JoinKind) ([]map[string]any, error) {
+	if kind != JoinInner && kind != JoinLeft {
+		return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Unknown join kind '%v'", kind)}
+	}
+
+	for _, keyPath := range []string{leftKeyPath, rightKeyPath} {
+		if _, err := parseJsonPath(keyPath); err != nil {
+			return nil, err
+		}
+	}
+
+	index := make(map[string][]map[string]any)
+	for _, record := range right {
+		if key, ok := joinKey(record, rightKeyPath); ok {
+			index[key] = append(index[key], record)
+		}
+	}
+
+	result := make([]map[string]any, 0, len(left))
+	for _, record := range left {
+		var matches []map[string]any
+		if key, ok := joinKey(record, leftKeyPath); ok {
+			matches = index[key]
+		}
+
+		if len(matches) == 0 && kind == JoinLeft {
+			result = append(result, map[string]any{"left": record, "right": nil})
+		}
+		for _, match := range matches {
+			result = append(result, map[string]any{"left": record, "right": match})
+		}
+	}
+
+	return result, nil
+}
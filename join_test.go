@@ -0,0 +1,72 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type JoinRecordsTestCase struct {
+	kind                 JoinKind
+	leftKeyPath          string
+	expectedResult       []map[string]any
+	expectedErrorMessage string
+}
+
+func TestJoinRecords(t *testing.T) {
+	orders := []map[string]any{
+		{"id": 1, "customer": map[string]any{"id": 10}},
+		{"id": 2, "customer": map[string]any{"id": 20}},
+		{"id": 3},
+	}
+	customers := []map[string]any{
+		{"id": 10.0, "name": "Nietzsche"},
+		{"id": 30.0, "name": "Stirner"},
+		{"id": 10.0, "name": "Nietzsche (old)"},
+	}
+
+	testCases := []JoinRecordsTestCase{
+		{
+			kind:        JoinInner,
+			leftKeyPath: "$.customer.id",
+			expectedResult: []map[string]any{
+				{"left": orders[0], "right": customers[0]},
+				{"left": orders[0], "right": customers[2]},
+			},
+		},
+		{
+			kind:        JoinLeft,
+			leftKeyPath: "$.customer.id",
+			expectedResult: []map[string]any{
+				{"left": orders[0], "right": customers[0]},
+				{"left": orders[0], "right": customers[2]},
+				{"left": orders[1], "right": nil},
+				{"left": orders[2], "right": nil},
+			},
+		},
+		{
+			kind:                 "outer",
+			leftKeyPath:          "$.customer.id",
+			expectedErrorMessage: "Unknown join kind 'outer'",
+		},
+		{
+			kind:                 JoinInner,
+			leftKeyPath:          "$.customer.",
+			expectedErrorMessage: "JSONPath should not end with '.'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - JoinRecords(%v, %v)", i, tc.leftKeyPath, tc.kind), func(t *testing.T) {
+			result, err := JoinRecords(orders, customers, tc.leftKeyPath, "$.id", tc.kind)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%v', but got '%v'", gu.Prettify(tc.expectedResult), gu.Prettify(result))
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package jsonmanu
+
+// journaledMap is the content of an object before it was changed.
+type journaledMap struct {
+	object  map[string]any
+	content map[string]any
+}
+
+// journaledSlice is the content of an array before its elements were changed.
+type journaledSlice struct {
+	items   []any
+	content []any
+}
+
+// journal records the content of the objects and the arrays a change may touch so that they can be restored in place
+// should the change fail. Unlike applying the change on a deep copy of the data and replacing the data with it, the
+// objects and the arrays of the data are kept, so the references held to them and their tracking by a KeyOrder remain
+// valid, and only the ones along the changed paths are copied, shallowly.
+type journal struct {
+	maps   map[uintptr]journaledMap
+	slices map[*any]journaledSlice
+}
+
+// newJournal creates an empty journal.
+func newJournal() *journal {
+	return &journal{maps: make(map[uintptr]journaledMap), slices: make(map[*any]journaledSlice)}
+}
+
+// record records the content of the value if it is an object or an array which is not recorded yet. It returns
+// false if it was already recorded.
+func (j *journal) record(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		if v == nil {
+			return false
+		}
+		id := objectID(v)
+		if _, ok := j.maps[id]; ok {
+			return false
+		}
+		content := make(map[string]any, len(v))
+		for key, val := range v {
+			content[key] = val
+		}
+		j.maps[id] = journaledMap{object: v, content: content}
+	case []any:
+		if len(v) == 0 {
+			return false
+		}
+		if _, ok := j.slices[&v[0]]; ok {
+			return false
+		}
+		j.slices[&v[0]] = journaledSlice{items: v, content: append([]any(nil), v...)}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// recordAll records the value along with all the objects and the arrays nested in it.
+func (j *journal) recordAll(value any) {
+	if !j.record(value) {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, val := range v {
+			j.recordAll(val)
+		}
+	case []any:
+		for _, item := range v {
+			j.recordAll(item)
+		}
+	}
+}
+
+// recordPath records the objects and the arrays the JSONPath walks through, which are the ones Put may change.
+// Everything below a recursive descent is recorded.
+func (j *journal) recordPath(data map[string]any, jsonPath string) error {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return err
+	}
+
+	j.recordNodes(data, nodes)
+
+	return nil
+}
+
+// recordNodes records the value and the objects and the arrays the nodes walk through starting from it.
+func (j *journal) recordNodes(value any, nodes []nodeDataAccessor) {
+	j.record(value)
+
+	if len(nodes) == 0 {
+		return
+	}
+
+	switch nodes[0].getName() {
+	case "":
+		j.recordAll(value)
+		return
+	case "*":
+		j.recordNodes(value, nodes[1:])
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		child := v[nodes[0].getName()]
+		items, ok := child.([]any)
+		if !isArrayNode(nodes[0]) || !ok {
+			j.recordNodes(child, nodes[1:])
+			return
+		}
+		j.record(items)
+		for _, item := range items {
+			j.recordNodes(item, nodes[1:])
+		}
+	case []any:
+		// the nodes apply on each element as it happens with Put
+		for _, item := range v {
+			j.recordNodes(item, nodes)
+		}
+	}
+}
+
+// restore restores the content of the recorded objects and arrays in place.
+func (j *journal) restore() {
+	for _, m := range j.maps {
+		for key := range m.object {
+			delete(m.object, key)
+		}
+		for key, val := range m.content {
+			m.object[key] = val
+		}
+	}
+
+	for _, s := range j.slices {
+		copy(s.items, s.content)
+	}
+}
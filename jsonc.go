@@ -0,0 +1,193 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// JSONComments maps the JSONPaths of the members and the elements of a JSONC document to the comments preceding
+// them, i.e. `$.server.port` to `["// the port to listen to"]`. The comments are kept as they are written, markers
+// included.
+type JSONComments map[string][]string
+
+// jsoncComment is a comment found in a JSONC document along with its offset.
+type jsoncComment struct {
+	offset int
+	text   string
+}
+
+// stripJSONC blanks out the comments and the trailing commas of a JSONC document so that it can be decoded as JSON.
+// The offsets and the lines of the rest of the document remain the same. The comments are returned in their order.
+func stripJSONC(src []byte) ([]byte, []jsoncComment, error) {
+	data := append([]byte(nil), src...)
+	var comments []jsoncComment
+
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			if data[i] != '\n' {
+				data[i] = ' '
+			}
+		}
+	}
+
+	inString := false
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				end = len(data) - i
+			}
+			comments = append(comments, jsoncComment{offset: i, text: string(bytes.TrimSpace(src[i : i+end]))})
+			blank(i, i+end)
+			i += end - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, nil, &Error{Code: ErrCodeInvalidDocument, Message: fmt.Sprintf("Unterminated comment at offset %v", i)}
+			}
+			end += 4
+			comments = append(comments, jsoncComment{offset: i, text: string(src[i : i+end])})
+			blank(i, i+end)
+			i += end - 1
+		}
+	}
+
+	inString = false
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			next := i + 1
+			for next < len(data) && (data[next] == ' ' || data[next] == '\t' || data[next] == '\n' || data[next] == '\r') {
+				next++
+			}
+			if next < len(data) && (data[next] == '}' || data[next] == ']') {
+				data[i] = ' '
+			}
+		}
+	}
+
+	return data, comments, nil
+}
+
+// jsoncFrame is an object or an array being walked while attaching the comments of a JSONC document.
+type jsoncFrame struct {
+	path      string
+	object    bool
+	key       string
+	expectKey bool
+	index     int
+}
+
+// attachComments walks the tokens of a stripped JSONC document and attaches each comment to the member or the
+// element it precedes. The comments which precede no member or element are attached to their enclosing object or
+// array, or to the root.
+func attachComments(data []byte, comments []jsoncComment) (JSONComments, error) {
+	result := make(JSONComments)
+
+	next := 0
+	attach := func(path string, end int64) {
+		for ; next < len(comments) && int64(comments[next].offset) < end; next++ {
+			result[path] = append(result[path], comments[next].text)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var stack []*jsoncFrame
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end := decoder.InputOffset()
+
+		path := "$"
+		if len(stack) > 0 {
+			top := stack[len(stack)-1]
+			switch {
+			case token == json.Delim('}') || token == json.Delim(']'):
+				attach(top.path, end)
+				stack = stack[:len(stack)-1]
+				continue
+			case top.object && top.expectKey:
+				top.key, top.expectKey = token.(string), false
+				attach(top.path+"."+top.key, end)
+				continue
+			case top.object:
+				path, top.expectKey = top.path+"."+top.key, true
+			default:
+				path = fmt.Sprintf("%v[%v]", top.path, top.index)
+				top.index++
+			}
+		}
+
+		attach(path, end)
+
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, &jsoncFrame{path: path, object: true, expectKey: true})
+		case json.Delim('['):
+			stack = append(stack, &jsoncFrame{path: path})
+		}
+	}
+
+	attach("$", math.MaxInt64)
+
+	return result, nil
+}
+
+// DecodeJSONC reads a JSONC document, i.e. a JSON document with `//` and `/* */` comments and trailing commas as
+// commonly found in configuration files, out of `r`. The comments are returned along with the data so that they can
+// be written back with Marshal once the data is edited.
+//
+// The document's root must be an object.
+func DecodeJSONC(r io.Reader) (map[string]any, JSONComments, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, comments, err := stripJSONC(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, nil, err
+	}
+
+	dataMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, nil, &Error{Code: ErrCodeNotMap, Message: fmt.Sprintf("Document root should be an object, got %T", value), Path: "$"}
+	}
+
+	attached, err := attachComments(data, comments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dataMap, attached, nil
+}
@@ -0,0 +1,118 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type DecodeJSONCTestCase struct {
+	input                string
+	expectedData         map[string]any
+	expectedComments     JSONComments
+	expectedErrorMessage string
+}
+
+func TestDecodeJSONC(t *testing.T) {
+	testCases := []DecodeJSONCTestCase{
+		{
+			input: `// settings
+{
+  // the server
+  "server": {
+    "host": "http://localhost", // not a comment: "//"
+    /* the port
+       to listen to */
+    "port": 8080,
+  },
+  "users": [
+    "admin", // the first one
+    "guest",
+    // none else
+  ],
+}
+// the end
+`,
+			expectedData: map[string]any{
+				"server": map[string]any{"host": "http://localhost", "port": 8080.0},
+				"users":  []any{"admin", "guest"},
+			},
+			expectedComments: JSONComments{
+				"$":             {"// settings", "// the end"},
+				"$.server":      {"// the server"},
+				"$.server.port": {`// not a comment: "//"`, "/* the port\n       to listen to */"},
+				"$.users[1]":    {"// the first one"},
+				"$.users":       {"// none else"},
+			},
+		},
+		{
+			input:            `{"a": "/* b */", "c": ["d",]}`,
+			expectedData:     map[string]any{"a": "/* b */", "c": []any{"d"}},
+			expectedComments: JSONComments{},
+		},
+		{
+			input:                "{\"a\": 1 /* b }",
+			expectedErrorMessage: "Unterminated comment at offset 8",
+		},
+		{
+			input:                "// a\n[1, 2,]",
+			expectedErrorMessage: "Document root should be an object, got []interface {}",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - DecodeJSONC(%v)=%v", i, tc.input, tc.expectedErrorMessage), func(t *testing.T) {
+			data, comments, err := DecodeJSONC(strings.NewReader(tc.input))
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedData), gu.Prettify(data))
+			}
+			if !cmp.Equal(tc.expectedComments, comments) {
+				t.Errorf("Expected comments '%#v', but got '%#v'", tc.expectedComments, comments)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONCMarshalRoundTrip(t *testing.T) {
+	input := `// settings
+{
+  // the server
+  "server": {
+    "host": "localhost",
+    // the port
+    "port": 8080
+  },
+  "users": [
+    /* the first one */
+    "admin",
+    "guest"
+  ]
+}`
+
+	data, comments, err := DecodeJSONC(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := Marshal(data, MarshalOptions{Indent: "  ", Comments: comments})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(result) != input {
+		t.Errorf("Expected '%v', but got '%s'", input, result)
+	}
+
+	result, err = Marshal(data, MarshalOptions{Comments: comments})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `{"server":{"host":"localhost","port":8080},"users":["admin","guest"]}`; string(result) != expected {
+		t.Errorf("Expected '%v', but got '%s'", expected, result)
+	}
+}
@@ -0,0 +1,113 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CamelToSnake converts a camelCase or PascalCase key to snake_case, i.e. `userID` to `user_id`.
+func CamelToSnake(key string) string {
+	runes := []rune(key)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+// SnakeToCamel converts a snake_case or kebab-case key to camelCase, i.e. `user_id` to `userId`.
+func SnakeToCamel(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' })
+
+	var b strings.Builder
+	for i, part := range parts {
+		runes := []rune(part)
+		if i > 0 {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteString(string(runes))
+	}
+
+	return b.String()
+}
+
+// ConvertKeysOptions holds the options of ConvertKeys.
+type ConvertKeysOptions struct {
+
+	// InPlace rewrites the keys of the provided data instead of a copy of it.
+	InPlace bool
+
+	// Skip holds the paths of the subtrees whose keys remain as they are, in dot notation with the original keys,
+	// i.e. `$.metadata` or `$.items[*].raw` where `[*]` stands for all the elements of an array. The key of the
+	// subtree itself is converted.
+	Skip []string
+}
+
+// keyConverter converts the keys of nested values skipping the configured subtrees.
+type keyConverter struct {
+	convert func(key string) string
+	inPlace bool
+	skip    map[string]bool
+}
+
+// convertValue converts the keys of the value found at the provided path. The path is tracked both with the array
+// indices and with `[*]` in their place so that the subtrees can be skipped either way.
+func (c keyConverter) convertValue(value any, path, wildcardPath string) any {
+	if c.skip[path] || c.skip[wildcardPath] {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		// the keys are visited in order so that colliding keys resolve the same way every time
+		for _, key := range sortedKeys(v) {
+			result[c.convert(key)] = c.convertValue(v[key], path+"."+key, wildcardPath+"."+key)
+		}
+		if !c.inPlace {
+			return result
+		}
+		for key := range v {
+			delete(v, key)
+		}
+		for key, val := range result {
+			v[key] = val
+		}
+		return v
+	case []any:
+		result := v
+		if !c.inPlace {
+			result = make([]any, len(v))
+		}
+		for i, val := range v {
+			result[i] = c.convertValue(val, fmt.Sprintf("%v[%v]", path, i), wildcardPath+"[*]")
+		}
+		return result
+	}
+
+	return value
+}
+
+// ConvertKeys rewrites recursively all the object keys of the provided data with the provided function, i.e.
+// CamelToSnake, SnakeToCamel or a custom one, and returns the result.
+//
+// The data remain untouched unless the InPlace option is set. If two keys of the same object are converted to the
+// same key the value of the key that comes last in lexicographical order is kept.
+func ConvertKeys(data map[string]any, convert func(key string) string, opts ConvertKeysOptions) map[string]any {
+	c := keyConverter{convert: convert, inPlace: opts.InPlace, skip: make(map[string]bool, len(opts.Skip))}
+	for _, path := range opts.Skip {
+		c.skip[path] = true
+	}
+
+	return c.convertValue(data, "$", "$").(map[string]any)
+}
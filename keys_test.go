@@ -0,0 +1,125 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type CaseConversionTestCase struct {
+	convert     func(string) string
+	key         string
+	expectedKey string
+}
+
+func TestCaseConversions(t *testing.T) {
+	testCases := []CaseConversionTestCase{
+		{CamelToSnake, "firstName", "first_name"},
+		{CamelToSnake, "FirstName", "first_name"},
+		{CamelToSnake, "userID", "user_id"},
+		{CamelToSnake, "HTTPServer", "http_server"},
+		{CamelToSnake, "first_name", "first_name"},
+		{SnakeToCamel, "first_name", "firstName"},
+		{SnakeToCamel, "home-town", "homeTown"},
+		{SnakeToCamel, "_id", "id"},
+		{SnakeToCamel, "firstName", "firstName"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - %v=%v", i, tc.key, tc.expectedKey), func(t *testing.T) {
+			if key := tc.convert(tc.key); key != tc.expectedKey {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedKey, key)
+			}
+		})
+	}
+}
+
+type ConvertKeysTestCase struct {
+	convert      func(string) string
+	opts         ConvertKeysOptions
+	expectedData map[string]any
+}
+
+func TestConvertKeys(t *testing.T) {
+	data := func() map[string]any {
+		return map[string]any{
+			"firstName": "Friedrich",
+			"metadata":  map[string]any{"createdAt": "1844"},
+			"books": []any{
+				map[string]any{"bookTitle": "Book1", "rawData": map[string]any{"pageCount": 100}},
+				map[string]any{"bookTitle": "Book2", "rawData": map[string]any{"pageCount": 200}},
+			},
+		}
+	}
+
+	testCases := []ConvertKeysTestCase{
+		{
+			convert: CamelToSnake,
+			expectedData: map[string]any{
+				"first_name": "Friedrich",
+				"metadata":   map[string]any{"created_at": "1844"},
+				"books": []any{
+					map[string]any{"book_title": "Book1", "raw_data": map[string]any{"page_count": 100}},
+					map[string]any{"book_title": "Book2", "raw_data": map[string]any{"page_count": 200}},
+				},
+			},
+		},
+		{
+			convert: CamelToSnake,
+			opts:    ConvertKeysOptions{Skip: []string{"$.metadata", "$.books[*].rawData", "$.books[0]"}},
+			expectedData: map[string]any{
+				"first_name": "Friedrich",
+				"metadata":   map[string]any{"createdAt": "1844"},
+				"books": []any{
+					map[string]any{"bookTitle": "Book1", "rawData": map[string]any{"pageCount": 100}},
+					map[string]any{"book_title": "Book2", "raw_data": map[string]any{"pageCount": 200}},
+				},
+			},
+		},
+		{
+			convert: strings.ToUpper,
+			opts:    ConvertKeysOptions{InPlace: true, Skip: []string{"$.books"}},
+			expectedData: map[string]any{
+				"FIRSTNAME": "Friedrich",
+				"METADATA":  map[string]any{"CREATEDAT": "1844"},
+				"BOOKS": []any{
+					map[string]any{"bookTitle": "Book1", "rawData": map[string]any{"pageCount": 100}},
+					map[string]any{"bookTitle": "Book2", "rawData": map[string]any{"pageCount": 200}},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ConvertKeys(%+v)", i, tc.opts), func(t *testing.T) {
+			original := data()
+			result := ConvertKeys(original, tc.convert, tc.opts)
+
+			if !cmp.Equal(tc.expectedData, result) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(result))
+			}
+
+			expectedOriginal := data()
+			if tc.opts.InPlace {
+				expectedOriginal = tc.expectedData
+			}
+			if !cmp.Equal(expectedOriginal, original) {
+				t.Errorf("Expected the data to be '%s', but got '%s'", gu.Prettify(expectedOriginal), gu.Prettify(original))
+			}
+		})
+	}
+}
+
+func TestConvertKeysCollisions(t *testing.T) {
+	expectedData := map[string]any{"user_id": 2}
+
+	for i := 0; i < 20; i++ {
+		result := ConvertKeys(map[string]any{"userId": 1, "user_id": 2, "UserID": 3}, CamelToSnake, ConvertKeysOptions{})
+		if !cmp.Equal(expectedData, result) {
+			t.Fatalf("Expected '%v', but got '%v'", expectedData, result)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// jsonSeparators are the bytes which may separate the tokens of JSON.
+const jsonSeparators = " \t\r\n,:"
+
+// skipJSONSeparators returns the offset of the first byte of the next token of valid JSON starting from the provided
+// offset.
+func skipJSONSeparators(raw []byte, offset int) int {
+	for offset < len(raw) && strings.IndexByte(jsonSeparators, raw[offset]) >= 0 {
+		offset++
+	}
+
+	return offset
+}
+
+// skipJSONValue returns the end of the value of valid JSON starting at the provided offset.
+func skipJSONValue(raw []byte, offset int) int {
+	switch raw[offset] {
+	case '"':
+		for offset++; raw[offset] != '"'; offset++ {
+			if raw[offset] == '\\' {
+				offset++
+			}
+		}
+		return offset + 1
+	case '{', '[':
+		depth := 0
+		for {
+			switch raw[offset] {
+			case '"':
+				offset = skipJSONValue(raw, offset)
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return offset + 1
+				}
+			}
+			offset++
+		}
+	}
+
+	for offset < len(raw) && strings.IndexByte(jsonSeparators+"]}", raw[offset]) < 0 {
+		offset++
+	}
+
+	return offset
+}
+
+// lazyNode is a value of a LazyDocument which is kept raw until it is needed.
+type lazyNode struct {
+	raw []byte
+
+	// members are the members of an object once it is indexed. They are nil for the rest of the values.
+	members map[string]*lazyNode
+
+	// value is the decoded value once it is decoded.
+	value   any
+	decoded bool
+}
+
+// isObject returns whether the node is an object.
+func (n *lazyNode) isObject() bool {
+	return n.raw[0] == '{'
+}
+
+// index splits the raw members of an object without decoding them.
+func (n *lazyNode) index() error {
+	if n.members != nil || !n.isObject() {
+		return nil
+	}
+
+	members := make(map[string]*lazyNode)
+	for offset := skipJSONSeparators(n.raw, 1); n.raw[offset] != '}'; {
+		keyEnd := skipJSONValue(n.raw, offset)
+		var key string
+		if err := json.Unmarshal(n.raw[offset:keyEnd], &key); err != nil {
+			return err
+		}
+
+		start := skipJSONSeparators(n.raw, keyEnd)
+		end := skipJSONValue(n.raw, start)
+		members[key] = &lazyNode{raw: n.raw[start:end]}
+
+		offset = skipJSONSeparators(n.raw, end)
+	}
+	n.members = members
+
+	return nil
+}
+
+// decode decodes the whole value. The members of an indexed object are decoded one by one so that the ones already
+// decoded are reused.
+func (n *lazyNode) decode() (any, error) {
+	if n.decoded {
+		return n.value, nil
+	}
+
+	if n.members != nil {
+		object := make(map[string]any, len(n.members))
+		for key, member := range n.members {
+			value, err := member.decode()
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+		n.value = object
+	} else if err := json.Unmarshal(n.raw, &n.value); err != nil {
+		return nil, err
+	}
+	n.decoded = true
+
+	return n.value, nil
+}
+
+// materialize returns the object with only the branch the nodes walk through decoded. The rest of the members are
+// left out. The nodes are followed as long as they are simple keys of objects and the value they end up to is
+// decoded as a whole.
+func (n *lazyNode) materialize(nodes []nodeDataAccessor) (map[string]any, error) {
+	if len(nodes) == 0 || n.decoded || nodes[0].getName() == "" || nodes[0].getName() == "*" {
+		value, err := n.decode()
+		if err != nil {
+			return nil, err
+		}
+		return value.(map[string]any), nil
+	}
+
+	if err := n.index(); err != nil {
+		return nil, err
+	}
+
+	name := nodes[0].getName()
+	member, ok := n.members[name]
+	if !ok {
+		return map[string]any{}, nil
+	}
+
+	var value any
+	var err error
+	if _, simple := nodes[0].(node); simple && len(nodes) > 1 && member.isObject() {
+		value, err = member.materialize(nodes[1:])
+	} else {
+		value, err = member.decode()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{name: value}, nil
+}
+
+// LazyDocument is a read-only JSON document which keeps the raw bytes and decodes only the parts of them touched by
+// the queried JSONPaths, so that values can be retrieved out of huge documents without decoding them up front. The
+// decoded parts are kept and reused by the subsequent queries.
+//
+// The JSONPaths are followed lazily as long as they consist of simple keys, i.e. `$.store.books` decodes only the
+// `books` of the `store`, while wildcards and recursive descents decode the whole object they apply on.
+//
+// The raw bytes are referenced and not copied so they must not be modified, but they may be memory-mapped. The
+// retrieved values are shared with the document so they must not be modified either. It is safe for concurrent use.
+type LazyDocument struct {
+	mu   sync.Mutex
+	root *lazyNode
+}
+
+// NewLazyDocument creates a LazyDocument out of raw JSON. The JSON is validated without being decoded.
+//
+// The document's root must be an object.
+func NewLazyDocument(raw []byte) (*LazyDocument, error) {
+	if !json.Valid(raw) {
+		// the syntax error is returned before anything is decoded
+		var value any
+		return nil, json.Unmarshal(raw, &value)
+	}
+
+	raw = bytes.TrimSpace(raw)
+	if raw[0] != '{' {
+		return nil, &Error{Code: ErrCodeNotMap, Message: "Document root should be an object", Path: "$"}
+	}
+
+	return &LazyDocument{root: &lazyNode{raw: raw}}, nil
+}
+
+// Get retrieves a value out of the document as it is described in the provided JSONPath, as Get does, decoding only
+// the parts of the document the JSONPath touches.
+func (d *LazyDocument) Get(jsonPath string) (any, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	data, err := d.root.materialize(nodes)
+	d.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return Get(data, jsonPath)
+}
+
+// Data decodes the whole document and returns it.
+func (d *LazyDocument) Data() (map[string]any, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.root.materialize(nil)
+}
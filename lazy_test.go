@@ -0,0 +1,130 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+var lazyTestJSON = []byte(` {
+	"store": {
+		"name": "Store \"1\" {]",
+		"books": [
+			{"title": "Book1", "price": 15, "tags": ["a", "b"]},
+			{"title": "Book2", "price": 5.5, "author": {"name": "Nietzsche"}}
+		],
+		"open": true,
+		"owner": null
+	},
+	"weïrd key": -1.5e3,
+	"empty": {}
+}
+`)
+
+func TestLazyDocumentGet(t *testing.T) {
+	data, err := Decode(bytes.NewReader(lazyTestJSON), FormatJSON)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	jsonPaths := []string{
+		"$",
+		"$.store",
+		"$.store.name",
+		"$.store.books",
+		"$.store.books[1]",
+		"$.store.books[0].tags",
+		"$.store.books.title",
+		"$.store.books[?(@.price < 10)].author.name",
+		"$.store.books[*].title",
+		"$.store.*",
+		"$..title",
+		"$.store.open",
+		"$.store.owner",
+		"$.empty",
+		"$.store.missing",
+		"$.store.name.first",
+		"$.missing.name",
+		"store",
+	}
+
+	doc, err := NewLazyDocument(lazyTestJSON)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i, jsonPath := range jsonPaths {
+		t.Run(fmt.Sprintf("(%v) - LazyDocument.Get(%v)", i, jsonPath), func(t *testing.T) {
+			expected, expectedErr := Get(data, jsonPath)
+			value, err := doc.Get(jsonPath)
+			if fmt.Sprint(expectedErr) != fmt.Sprint(err) {
+				t.Errorf("Expected error '%v', but got '%v'", expectedErr, err)
+			}
+			if !cmp.Equal(expected, value) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expected), gu.Prettify(value))
+			}
+		})
+	}
+}
+
+func TestLazyDocumentDecodesOnDemand(t *testing.T) {
+	doc, err := NewLazyDocument(lazyTestJSON)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if value, err := doc.Get("$.store.name"); err != nil || value != `Store "1" {]` {
+		t.Fatalf("Unexpected result: %v, %v", value, err)
+	}
+
+	store := doc.root.members["store"]
+	if doc.root.decoded || store.decoded || !store.members["name"].decoded || store.members["books"].decoded {
+		t.Errorf("Expected only the name to be decoded")
+	}
+	if doc.root.members["empty"].members != nil {
+		t.Errorf("Expected the untouched members not to be indexed")
+	}
+
+	books, err := doc.Get("$.store.books[0]")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !store.members["books"].decoded || store.members["open"].decoded {
+		t.Errorf("Expected only the books to be decoded")
+	}
+
+	data, err := doc.Data()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	book := books.([]any)[0].(map[string]any)
+	if objectID(book) != objectID(data["store"].(map[string]any)["books"].([]any)[0].(map[string]any)) {
+		t.Errorf("Expected the decoded books to be reused")
+	}
+}
+
+func TestNewLazyDocumentErrors(t *testing.T) {
+	testCases := []struct {
+		raw                  string
+		expectedErrorMessage string
+	}{
+		{raw: `{"a": [1, 2}`, expectedErrorMessage: "invalid character '}' after array element"},
+		{raw: "", expectedErrorMessage: "unexpected end of JSON input"},
+		{raw: ` ["a"]`, expectedErrorMessage: "Document root should be an object"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - NewLazyDocument(%v)=%v", i, tc.raw, tc.expectedErrorMessage), func(t *testing.T) {
+			doc, err := NewLazyDocument([]byte(tc.raw))
+			if err == nil || err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if doc != nil {
+				t.Errorf("Expected no document, but got '%v'", doc)
+			}
+		})
+	}
+}
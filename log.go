@@ -0,0 +1,52 @@
+package jsonmanu
+
+import "sync/atomic"
+
+// Logger is the interface used by the library for debug traces and warnings. It is satisfied by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Warn(msg string, args ...any)
+}
+
+// noopLogger discards all the messages.
+type noopLogger struct{}
+
+func (noopLogger) Debug(msg string, args ...any) {}
+
+func (noopLogger) Warn(msg string, args ...any) {}
+
+// loggerBox wraps a Logger since an atomic.Value holds values of a single concrete type.
+type loggerBox struct{ Logger }
+
+// swappableLogger forwards the messages to the Logger set last so that it can be replaced while in use.
+type swappableLogger struct {
+	current atomic.Value
+}
+
+// get returns the Logger set last.
+func (l *swappableLogger) get() Logger {
+	if box, ok := l.current.Load().(loggerBox); ok {
+		return box.Logger
+	}
+
+	return noopLogger{}
+}
+
+func (l *swappableLogger) Debug(msg string, args ...any) { l.get().Debug(msg, args...) }
+
+func (l *swappableLogger) Warn(msg string, args ...any) { l.get().Warn(msg, args...) }
+
+// logger is the Logger used by the library.
+var logger = &swappableLogger{}
+
+// SetLogger sets the Logger used by the library. Passing nil disables logging which is the default.
+//
+// It can be called at any time, i.e. to raise the verbosity while debugging, and the messages logged afterwards go
+// to the new Logger.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = noopLogger{}
+	}
+
+	logger.current.Store(loggerBox{l})
+}
@@ -0,0 +1,69 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordingLogger keeps the logged messages along with their level.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) {
+	l.messages = append(l.messages, fmt.Sprintf("DEBUG %v %v", msg, args))
+}
+
+func (l *recordingLogger) Warn(msg string, args ...any) {
+	l.messages = append(l.messages, fmt.Sprintf("WARN %v %v", msg, args))
+}
+
+func TestLogger(t *testing.T) {
+	l := &recordingLogger{}
+	SetLogger(l)
+	defer SetLogger(nil)
+
+	data := map[string]any{
+		"books": []any{
+			map[string]any{"author": "Nietzsche", "price": 15},
+			map[string]any{"author": "Stirner"},
+		},
+	}
+
+	Get(data, "$.books[0,5].author")
+	Get(data, "$.books[?(@.price > 10)].author")
+	Map(data, map[string]any{}, []Mapper{{SrcJsonPath: "$.isbn", DstJsonPath: "$.isbn"}})
+
+	expectedMessages := []string{
+		"DEBUG Index out of range skipped [node books index 5 length 2]",
+		"DEBUG Element without filter key skipped [node books key price]",
+		"DEBUG Get failed [path $.isbn error dataValidationError: Source key not found: 'isbn']",
+		"WARN Mapper failed [mapper 0 src $.isbn dst $.isbn error Error while getting value from data: dataValidationError: Source key not found: 'isbn']",
+	}
+	if !cmp.Equal(expectedMessages, l.messages) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedMessages, l.messages)
+	}
+}
+
+func TestSetLoggerConcurrently(t *testing.T) {
+	defer SetLogger(nil)
+
+	data := map[string]any{"books": []any{map[string]any{"author": "Nietzsche"}}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetLogger(&recordingLogger{})
+			SetMetrics(nil)
+			SetFilterOptions(FilterOptions{})
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		Get(data, "$.books[0,5].author")
+	}
+	<-done
+}
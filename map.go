@@ -2,6 +2,12 @@ package jsonmanu
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	gu "github.com/antavelos/go-utils"
 )
@@ -17,6 +23,150 @@ type Mapper struct {
 	// Transformations enable optional functionality to be applied on the retrieved value before it's put in the destination data.
 	// The transformations will be applied in a chain mode according to their configuration order.
 	Transformations []Transformation
+
+	// DstSchema is an optional JSON Schema describing the whole destination data. The value is validated against the
+	// part of the schema found at the DstJsonPath before it is put, and rejected if it is invalid. The rest of the
+	// destination is not validated, since other mappers may still be filling it in, i.e. the properties `required`
+	// by the objects the DstJsonPath goes through; use ValidateSchema on the destination once Map returns instead.
+	DstSchema map[string]any
+
+	// DstType is the optional JSON Schema type (i.e. `string`, `number`, `array` etc) of the value put in the destination.
+	// It is used when generating the JSON Schema of the destination data out of the mappers.
+	DstType string
+
+	// Timeout is the optional maximum duration of the transformations of the mapper. If exceeded the mapper fails
+	// with an error and its value is not put in the destination data. The transformations cannot be cancelled though,
+	// so they keep running in the background, on a copy of the value, until they return. Transformers calling external
+	// services should rather bound their calls themselves, i.e. as ResolveTransformer does with its Timeout.
+	Timeout time.Duration
+
+	// Join optionally enriches the elements of the retrieved value with the matching elements of another source array
+	// before the transformations apply.
+	Join *Join
+
+	// KeyTransformations optionally rewrite the keys of the retrieved object, or of the objects of the retrieved array,
+	// before the transformations apply, i.e. to strip a vendor prefix off the keys of `$.headers`. They apply in a
+	// chain mode on each key and their result must be a string.
+	KeyTransformations []Transformation
+
+	// UniqueBy optionally prevents duplicates when appending to a destination array, i.e. `$.tags[+]`. If it is `$`
+	// the value is skipped if it is equal to an element of the array. Otherwise it is the JSONPath of the key, relative
+	// to the value, that must be unique among the elements, i.e. `$.id`.
+	UniqueBy string
+
+	// RequireSrc makes the mapper fail if its source JSONPath matches no value, i.e. when a filter or a wildcard
+	// matches nothing, instead of putting an empty result in the destination data.
+	RequireSrc bool
+
+	// RequireDstAbsent makes the mapper fail instead of overwriting a value that already exists in the destination
+	// data under its destination JSONPath. Nil values count as absent so appending to an array, i.e. with `$.tags[+]`,
+	// is always allowed.
+	RequireDstAbsent bool
+
+	// PadArrays determines whether an index of the destination JSONPath beyond the length of an array, i.e.
+	// `$.items[3]` of an empty array, pads it with nils up to the index. Such indices fail the mapper otherwise.
+	PadArrays bool
+
+	// Before is an optional hook called with the source data before the mapper runs. If it fails the mapper is skipped.
+	Before func(src map[string]any) error
+
+	// After is an optional hook called with the destination data after the mapper has put its value in it.
+	// If it fails the mapper fails as well, although the value remains in the destination data.
+	After func(dst map[string]any) error
+}
+
+// Join describes the lookup of the elements of a source array matching the elements of the value retrieved by a mapper,
+// i.e. the customers matching the orders by the customer id.
+type Join struct {
+
+	// SrcJsonPath is the JSONPath of the source array to look up.
+	SrcJsonPath string `json:"src"`
+
+	// LocalKey is the key of the elements of the retrieved value to match.
+	LocalKey string `json:"localKey"`
+
+	// ForeignKey is the key of the elements of the looked up array to match.
+	ForeignKey string `json:"foreignKey"`
+
+	// As is the key the matching element is put under in the elements of the retrieved value. If empty, the fields of
+	// the matching element are merged into them without overriding the existing ones.
+	As string `json:"as,omitempty"`
+}
+
+// apply returns the provided value with its elements enriched by their matching elements in the looked up array.
+// The elements are copied so that the source data remains untouched. Elements without a match are left as they are.
+func (j Join) apply(src map[string]any, value any) (any, error) {
+	lookup, err := Get(src, j.SrcJsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	lookupItems, ok := lookup.([]any)
+	if !ok {
+		return nil, &Error{Code: ErrCodeNotArray, Message: fmt.Sprintf("Value of '%v' is not an array.", j.SrcJsonPath), Path: j.SrcJsonPath}
+	}
+
+	// the keys are compared by their string representation so that i.e. 1 matches 1.0
+	index := make(map[string]map[string]any, len(lookupItems))
+	for _, item := range lookupItems {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		key, ok := itemMap[j.ForeignKey]
+		if !ok {
+			continue
+		}
+		if _, found := index[fmt.Sprintf("%v", key)]; !found {
+			index[fmt.Sprintf("%v", key)] = itemMap
+		}
+	}
+
+	items, isSlice := value.([]any)
+	if !isSlice {
+		items = []any{value}
+	}
+
+	result := make([]any, len(items))
+	for i, item := range items {
+		result[i] = item
+
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		key, ok := itemMap[j.LocalKey]
+		if !ok {
+			continue
+		}
+
+		match, ok := index[fmt.Sprintf("%v", key)]
+		if !ok {
+			continue
+		}
+
+		joined := make(map[string]any, len(itemMap)+len(match))
+		for k, v := range itemMap {
+			joined[k] = v
+		}
+		if j.As != "" {
+			joined[j.As] = match
+		} else {
+			for k, v := range match {
+				if _, ok := joined[k]; !ok {
+					joined[k] = v
+				}
+			}
+		}
+		result[i] = joined
+	}
+
+	if !isSlice {
+		return result[0], nil
+	}
+
+	return result, nil
 }
 
 // handleSlideTransformation applies the transformation on each element of the slice
@@ -26,7 +176,7 @@ func handleSlideTransformation(value any, transformer Transformer) (any, error)
 	for item := range gu.IterAny(value, nil) {
 		transItem, err := transformer.Transform(item)
 		if err != nil {
-			return value, fmt.Errorf("Array[%v]: %v", i, err)
+			return value, fmt.Errorf("Array[%v]: %w", i, err)
 		}
 		transArray = append(transArray, transItem)
 		i++
@@ -36,35 +186,382 @@ func handleSlideTransformation(value any, transformer Transformer) (any, error)
 	return value, nil
 }
 
+// applyTransformation applies the transformation on the value. A panic of the transformer is converted to an error.
+func applyTransformation(transformation Transformation, value any) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = value, fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if gu.IsSlice(value) && !transformation.AsArray {
+		return handleSlideTransformation(value, transformation.Trsnfmr)
+	}
+
+	return transformation.Trsnfmr.Transform(value)
+}
+
+// transform applies the transformations of the mapper on the value in a chain mode.
+// The index of the transformation being applied is stored in current.
+func transform(mapper Mapper, value any, current *int32) (any, error) {
+	var err error
+
+	for i, transformation := range mapper.Transformations {
+		atomic.StoreInt32(current, int32(i))
+		start := time.Now()
+
+		value, err = applyTransformation(transformation, value)
+
+		metrics.TransformationExecuted(fmt.Sprintf("%T", transformation.Trsnfmr), time.Since(start), err)
+
+		if err != nil {
+			return value, &Error{
+				Code:    ErrCodeTransformation,
+				Message: fmt.Sprintf("Transformation[%v] (%T): %v", i, transformation.Trsnfmr, err),
+				Path:    mapper.SrcJsonPath,
+				Err:     err,
+			}
+		}
+	}
+
+	return value, nil
+}
+
+// transformKey applies the key transformations of the mapper on the key in a chain mode.
+func transformKey(mapper Mapper, key string) (string, error) {
+	var value any = key
+
+	for i, transformation := range mapper.KeyTransformations {
+		var err error
+		if value, err = applyTransformation(transformation, value); err == nil {
+			if _, ok := value.(string); !ok {
+				err = fmt.Errorf("Key '%v' transformed to %#v which is not a string", key, value)
+			}
+		}
+
+		if err != nil {
+			return key, &Error{
+				Code:    ErrCodeTransformation,
+				Message: fmt.Sprintf("KeyTransformation[%v] (%T): %v", i, transformation.Trsnfmr, err),
+				Path:    mapper.SrcJsonPath,
+				Err:     err,
+			}
+		}
+	}
+
+	return value.(string), nil
+}
+
+// transformKeys applies the key transformations of the mapper on the keys of the value if it is an object or of its
+// elements which are objects if it is an array. Values of any other type are returned as they are.
+//
+// The objects are copied so that the source data remains untouched. If two keys of the same object are transformed to
+// the same key the value of the key that comes last in lexicographical order is kept.
+func transformKeys(mapper Mapper, value any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for _, key := range sortedKeys(v) {
+			newKey, err := transformKey(mapper, key)
+			if err != nil {
+				return value, err
+			}
+			result[newKey] = v[key]
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if result[i], err = transformKeys(mapper, item); err != nil {
+				return value, err
+			}
+		}
+		return result, nil
+	}
+
+	return value, nil
+}
+
+// transformWithTimeout works like transform but it gives up once the timeout of the mapper expires.
+//
+// Transformers have no way to be cancelled so they keep running in the background in that case, until they return,
+// and their result is discarded. They work on a deep copy of the value so that they cannot change the source data
+// or the value returned along with the timeout error while still running.
+func transformWithTimeout(mapper Mapper, value any) (any, error) {
+	type transformResult struct {
+		value any
+		err   error
+	}
+
+	current := new(int32)
+	done := make(chan transformResult, 1)
+	valueCopy := deepCopy(value)
+	go func() {
+		result, err := transform(mapper, valueCopy, current)
+		done <- transformResult{result, err}
+	}()
+
+	timer := time.NewTimer(mapper.Timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-timer.C:
+		i := atomic.LoadInt32(current)
+		return value, &Error{
+			Code:    ErrCodeTimeout,
+			Message: fmt.Sprintf("Transformation[%v] (%T): Timed out after %v", i, mapper.Transformations[i].Trsnfmr, mapper.Timeout),
+			Path:    mapper.SrcJsonPath,
+		}
+	}
+}
+
+// dstArrayNodePattern matches the destination path nodes which refer to a single array element, either by index
+// (i.e. `items[0]`) or by appending it (i.e. `items[+]`).
+var dstArrayNodePattern = regexp.MustCompile(`^(\w+)\[(\d+|\+)\]$`)
+
+// prepareDstArrays creates the arrays and the elements the array nodes of the destination path refer to, if not
+// present, and replaces the `[+]` nodes with the index of the appended element. The preparation stops at the first
+// node which is neither a simple nor a single element array node.
+//
+// The arrays are replaced rather than changed in place so that the returned keys can revert all the changes. An index
+// beyond the length of an array is an error unless padArrays is set, in which case the array is padded with nils as
+// PutOptions.PadArrays does. On error the created keys are already removed.
+func prepareDstArrays(dst map[string]any, dstJsonPath string, padArrays bool) (string, []createdKey, error) {
+	var created []createdKey
+
+	tokens := splitJsonPath(dstJsonPath)
+	data := dst
+	for i := 1; i < len(tokens) && data != nil; i++ {
+		last := i == len(tokens)-1
+
+		match := dstArrayNodePattern.FindStringSubmatch(tokens[i])
+		if match == nil {
+			n, ok := nodeFromJsonPathSubNode(tokens[i]).(node)
+			if last || !ok || n.name == "*" || n.name == "" {
+				break
+			}
+
+			if data[n.name] == nil {
+				created = append(created, newCreatedKey(data, n.name))
+				data[n.name] = map[string]any{}
+			}
+			data, _ = data[n.name].(map[string]any)
+			continue
+		}
+
+		name := match[1]
+		items, ok := data[name].([]any)
+		if !ok && data[name] != nil {
+			break
+		}
+
+		index := len(items)
+		if match[2] != "+" {
+			var err error
+			if index, err = strconv.Atoi(match[2]); err != nil {
+				removeCreatedKeys(created)
+				return "", nil, &Error{
+					Code:    ErrCodeInvalidPath,
+					Message: fmt.Sprintf("Invalid index '%v'", match[2]),
+					Path:    dstJsonPath,
+					Segment: tokens[i],
+					Err:     err,
+				}
+			}
+			if index > len(items) && !padArrays {
+				removeCreatedKeys(created)
+				return "", nil, &Error{
+					Code:    ErrCodeInvalidOperation,
+					Message: fmt.Sprintf("Index %v is beyond the length %v of '%v'", index, len(items), name),
+					Path:    dstJsonPath,
+					Segment: tokens[i],
+				}
+			}
+		}
+		tokens[i] = fmt.Sprintf("%v[%v]", name, index)
+
+		if index >= len(items) || (items[index] == nil && !last) {
+			newItems := make([]any, len(items))
+			copy(newItems, items)
+			for len(newItems) <= index {
+				newItems = append(newItems, nil)
+			}
+			if !last {
+				newItems[index] = map[string]any{}
+			}
+
+			created = append(created, newCreatedKey(data, name))
+			data[name] = newItems
+			items = newItems
+		}
+
+		if last {
+			break
+		}
+		data, _ = items[index].(map[string]any)
+	}
+
+	return strings.Join(tokens, "."), created, nil
+}
+
+// valuesEqual returns whether two values are deeply equal considering numbers of different types equal if their
+// values are equal.
+func valuesEqual(a, b any) bool {
+	if isNumber(a) && isNumber(b) {
+		fa, _ := gu.ToFloat64(a)
+		fb, _ := gu.ToFloat64(b)
+		return fa == fb
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// dstArrayContains returns whether the destination array a value is appended to, i.e. by `$.tags[+]`, already contains
+// an element equal to the value, or with an equal key if uniqueBy is a JSONPath. Other destination paths never
+// contain the value.
+func dstArrayContains(dst map[string]any, dstJsonPath string, value any, uniqueBy string) (bool, error) {
+	arrayPath := strings.TrimSuffix(dstJsonPath, "[+]")
+	if arrayPath == dstJsonPath || strings.Contains(arrayPath, "[+]") {
+		return false, nil
+	}
+
+	items, ok := getOrNil(dst, arrayPath).([]any)
+	if !ok {
+		return false, nil
+	}
+
+	key := func(v any) (any, error) { return v, nil }
+	if uniqueBy != "$" {
+		if _, err := parseJsonPath(uniqueBy); err != nil {
+			return false, err
+		}
+		key = func(v any) (any, error) {
+			vMap, ok := v.(map[string]any)
+			if !ok {
+				return nil, nil
+			}
+			return getOrNil(vMap, uniqueBy), nil
+		}
+	}
+
+	valueKey, _ := key(value)
+	if valueKey == nil && uniqueBy != "$" {
+		return false, nil
+	}
+
+	for _, item := range items {
+		if itemKey, _ := key(item); valuesEqual(valueKey, itemKey) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getOrNil works like Get but it returns nil instead of an error.
+func getOrNil(data map[string]any, jsonPath string) any {
+	value, _ := Get(data, jsonPath)
+
+	return value
+}
+
 // handleMapper handles the cycle of a mapping of a src value to a dest based on the mapper conf
 func handleMapper(src map[string]any, dst map[string]any, mapper Mapper) error {
 	if err := validateMapper(mapper); err != nil {
-		return fmt.Errorf("Validation error: %v", err)
+		return fmt.Errorf("Validation error: %w", err)
+	}
+
+	if mapper.Before != nil {
+		if err := mapper.Before(src); err != nil {
+			return fmt.Errorf("Before hook: %w", err)
+		}
+	}
+
+	if mapper.RequireSrc {
+		matches, err := findMatchesLimit(src, mapper.SrcJsonPath, 1)
+		if err == nil && len(matches) == 0 {
+			err = &Error{Code: ErrCodeKeyNotFound, Message: "Required source value not found.", Path: mapper.SrcJsonPath}
+		}
+		if err != nil {
+			return fmt.Errorf("Error while getting value from data: %w", err)
+		}
 	}
 
 	srcValue, err := Get(src, mapper.SrcJsonPath)
 	if err != nil {
-		return fmt.Errorf("Error while getting value from data: %v", err)
+		return fmt.Errorf("Error while getting value from data: %w", err)
 	}
 
-	for i, transformation := range mapper.Transformations {
-		if gu.IsSlice(srcValue) {
-			if transformation.AsArray {
-				srcValue, err = transformation.Trsnfmr.Transform(srcValue)
-			} else {
-				srcValue, err = handleSlideTransformation(srcValue, transformation.Trsnfmr)
-			}
-		} else {
-			srcValue, err = transformation.Trsnfmr.Transform(srcValue)
+	if mapper.Join != nil {
+		if srcValue, err = mapper.Join.apply(src, srcValue); err != nil {
+			return fmt.Errorf("Join error: %w", err)
 		}
+	}
 
+	if len(mapper.KeyTransformations) > 0 {
+		if srcValue, err = transformKeys(mapper, srcValue); err != nil {
+			return err
+		}
+	}
+
+	if mapper.Timeout > 0 && len(mapper.Transformations) > 0 {
+		srcValue, err = transformWithTimeout(mapper, srcValue)
+	} else {
+		srcValue, err = transform(mapper, srcValue, new(int32))
+	}
+	if err != nil {
+		return err
+	}
+
+	if mapper.UniqueBy != "" {
+		duplicate, err := dstArrayContains(dst, mapper.DstJsonPath, srcValue, mapper.UniqueBy)
+		if err != nil {
+			return fmt.Errorf("Error while checking for duplicates in destination: %w", err)
+		}
+		if duplicate {
+			logger.Debug("Duplicate value skipped", "dst", mapper.DstJsonPath)
+			return nil
+		}
+	}
+
+	dstJsonPath, created, err := prepareDstArrays(dst, mapper.DstJsonPath, mapper.PadArrays)
+	if err != nil {
+		return fmt.Errorf("Error while putting value in destination: %w", err)
+	}
+
+	if mapper.RequireDstAbsent {
+		matches, err := findMatches(dst, dstJsonPath)
+		for _, m := range matches {
+			if m.value != nil {
+				err = &Error{Code: ErrCodeInvalidOperation, Message: "Destination value already exists.", Path: mapper.DstJsonPath}
+				break
+			}
+		}
 		if err != nil {
-			return fmt.Errorf("Transformation[%v] (%T): %v", i, transformation.Trsnfmr, err)
+			removeCreatedKeys(created)
+			return fmt.Errorf("Error while putting value in destination: %w", err)
 		}
 	}
 
-	if err = Put(dst, mapper.DstJsonPath, srcValue); err != nil {
-		return fmt.Errorf("Error while putting value in destination: %v", err)
+	var schema map[string]any
+	if schema, err = subSchema(mapper.DstSchema, dstJsonPath); err == nil {
+		if err = validateSchema(srcValue, schema, dstJsonPath); err == nil {
+			err = Put(dst, dstJsonPath, srcValue)
+		}
+	}
+
+	if err != nil {
+		removeCreatedKeys(created)
+		return fmt.Errorf("Error while putting value in destination: %w", err)
+	}
+
+	if mapper.After != nil {
+		if err := mapper.After(dst); err != nil {
+			return fmt.Errorf("After hook: %w", err)
+		}
 	}
 
 	return nil
@@ -73,12 +570,46 @@ func handleMapper(src map[string]any, dst map[string]any, mapper Mapper) error {
 // validateMapper validates a mapperconfiguration
 func validateMapper(mapper Mapper) error {
 	if jsonPathHasReccursiveDescent(mapper.DstJsonPath) {
-		return fmt.Errorf("Reccursive descent not allowed in destination path.")
+		return &Error{Code: ErrCodeInvalidPath, Message: "Reccursive descent not allowed in destination path.", Path: mapper.DstJsonPath}
 	}
 
 	return nil
 }
 
+// MapOptions holds the options of MapWithOptions.
+type MapOptions struct {
+
+	// Before is an optional hook called with the source data before any mapper runs. If it fails no mapper runs.
+	Before func(src map[string]any) error
+
+	// After is an optional hook called with the destination data after all the mappers have run, even if some failed.
+	After func(dst map[string]any) error
+
+	// Passthrough deep-copies the source data into the destination data before any mapper runs so that the mappers
+	// only need to describe the fields that change. The copied source fields are kept even if a mapper moves
+	// their values elsewhere.
+	Passthrough bool
+
+	// Drop holds the JSONPaths of the fields to be deleted from the destination data after all the mappers have run,
+	// i.e. internal fields which should not be exposed. They follow the restrictions of Delete.
+	Drop []string
+
+	// Prune, if set, prunes the destination data after all the mappers have run and before the After hook so that
+	// sparse sources do not leave behind empty structures.
+	Prune *PruneOptions
+
+	// PostTransform holds transformers applied in a chain mode on the whole destination data after it is pruned and
+	// before the After hook, i.e. to sort arrays or wrap the data in an envelope. Each one of them must return an
+	// object which replaces the contents of the destination data. The chain stops at the first failure leaving the
+	// destination data as the previous transformer returned it.
+	PostTransform []Transformer
+
+	// PreserveOrder, if set, is the order of the keys of the destination data, i.e. the one returned by DecodeOrdered.
+	// It is updated after each mapper so that the keys added by the mappers follow the original ones in the order
+	// they were added when the destination data is written with Marshal.
+	PreserveOrder *KeyOrder
+}
+
 // Map maps data from a given source map to another destination map based on a configuration described in one or more Mapper objects.
 //
 // The `dst` map object must not be nil.
@@ -89,11 +620,147 @@ func validateMapper(mapper Mapper) error {
 //
 // The changes in `dst` apply in place.
 func Map(src map[string]any, dst map[string]any, mappers []Mapper) (errors []error) {
+	return MapWithOptions(src, dst, mappers, MapOptions{})
+}
+
+// MapperError is an error of a mapper along with the index of the mapper.
+type MapperError struct {
+
+	// Index is the index of the mapper.
+	Index int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (err *MapperError) Error() string { return fmt.Sprintf("Mapper[%v]: %v", err.Index, err.Err) }
+
+func (err *MapperError) Unwrap() error { return err.Err }
+
+// runMapper applies the mapper of the provided index reporting its execution.
+func runMapper(src map[string]any, dst map[string]any, i int, mapper Mapper) error {
+	start := time.Now()
+	err := handleMapper(src, dst, mapper)
+	metrics.MapperExecuted(i, time.Since(start), err)
+
+	if err != nil {
+		logger.Warn("Mapper failed", "mapper", i, "src", mapper.SrcJsonPath, "dst", mapper.DstJsonPath, "error", err)
+		return &MapperError{Index: i, Err: err}
+	}
+
+	return nil
+}
+
+// MapIncremental re-applies on dst, previously mapped out of prevSrc, only the mappers whose source JSONPaths (the
+// SrcJsonPath and the one of the Join) overlap with the changes between prevSrc and src as computed by Diff. It is
+// meant for frequently updated large documents where few paths change at a time.
+//
+// The mappers are expected to put their values rather than append them, i.e. with `[+]`, since a re-applied mapper
+// would append them again. A mapper whose source was removed fails the same way it does with Map.
+func MapIncremental(prevSrc map[string]any, src map[string]any, dst map[string]any, mappers []Mapper) (errors []error) {
+	changeset := Diff(prevSrc, src)
+	if len(changeset) == 0 {
+		return nil
+	}
+
+	for i, mapper := range mappers {
+		overlaps, err := ChangedPathsOverlap(mapper.SrcJsonPath, changeset)
+		if err == nil && !overlaps && mapper.Join != nil {
+			overlaps, err = ChangedPathsOverlap(mapper.Join.SrcJsonPath, changeset)
+		}
+		if err == nil && !overlaps {
+			continue
+		}
+
+		if err := runMapper(src, dst, i, mapper); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	return
+}
+
+// postTransform applies the transformer on the whole destination data and replaces its contents with the result.
+func postTransform(dst map[string]any, transformer Transformer) error {
+	// the transformer gets a shallow copy so that the result can safely nest the data, i.e. in an envelope
+	data := make(map[string]any, len(dst))
+	for key, value := range dst {
+		data[key] = value
+	}
+
+	result, err := applyTransformation(Transformation{Trsnfmr: transformer, AsArray: true}, data)
+	if err != nil {
+		return &Error{Code: ErrCodeTransformation, Message: err.Error(), Path: "$", Err: err}
+	}
+
+	resultMap, ok := result.(map[string]any)
+	if !ok {
+		return &Error{Code: ErrCodeTransformation, Message: fmt.Sprintf("Result %#v is not an object", result), Path: "$"}
+	}
+
+	for key := range dst {
+		delete(dst, key)
+	}
+	for key, value := range resultMap {
+		dst[key] = value
+	}
+
+	return nil
+}
+
+// MapWithOptions works like Map but it also accepts options which adjust the mapping.
+//
+// The errors of the hooks are returned along with the errors of the mappers.
+func MapWithOptions(src map[string]any, dst map[string]any, mappers []Mapper, opts MapOptions) (errors []error) {
+	if opts.Before != nil {
+		if err := opts.Before(src); err != nil {
+			return []error{fmt.Errorf("Before hook: %w", err)}
+		}
+	}
+
+	if opts.Passthrough {
+		for key, value := range src {
+			dst[key] = deepCopy(value)
+		}
+	}
+
+	updateOrder := func() {
+		if opts.PreserveOrder != nil {
+			opts.PreserveOrder.Update(dst)
+		}
+	}
+	updateOrder()
+
 	for i, mapper := range mappers {
-		if err := handleMapper(src, dst, mapper); err != nil {
-			errors = append(errors, fmt.Errorf("Mapper[%v]: %s", i, err.Error()))
+		if err := runMapper(src, dst, i, mapper); err != nil {
+			errors = append(errors, err)
+		}
+		updateOrder()
+	}
+
+	for i, jsonPath := range opts.Drop {
+		if err := Delete(dst, jsonPath); err != nil {
+			errors = append(errors, fmt.Errorf("Drop[%v]: %w", i, err))
+		}
+	}
+
+	if opts.Prune != nil {
+		Prune(dst, *opts.Prune)
+	}
+
+	for i, transformer := range opts.PostTransform {
+		if err := postTransform(dst, transformer); err != nil {
+			errors = append(errors, fmt.Errorf("PostTransform[%v] (%T): %w", i, transformer, err))
+			break
+		}
+	}
+
+	if opts.After != nil {
+		if err := opts.After(dst); err != nil {
+			errors = append(errors, fmt.Errorf("After hook: %w", err))
 		}
 	}
+	updateOrder()
 
 	return
 }
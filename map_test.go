@@ -2,7 +2,9 @@ package jsonmanu
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	gu "github.com/antavelos/go-utils"
 	"github.com/google/go-cmp/cmp"
@@ -398,3 +400,693 @@ func TestMap(t *testing.T) {
 		})
 	}
 }
+
+func TestMapWithDstSchema(t *testing.T) {
+	src := map[string]any{
+		"library": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": "15"},
+				map[string]any{"author": "Stirner", "price": "10"},
+			},
+		},
+	}
+	dst := map[string]any{}
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"authors": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"prices":  map[string]any{"type": "array", "items": map[string]any{"type": "number"}},
+		},
+	}
+	mappers := []Mapper{
+		{
+			SrcJsonPath: "$.library.books.author",
+			DstJsonPath: "$.authors",
+			DstSchema:   schema,
+		},
+		{
+			SrcJsonPath: "$.library.books.price",
+			DstJsonPath: "$.prices",
+			DstSchema:   schema,
+		},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	expectedErrorMessages := []string{"Mapper[1]: Error while putting value in destination: schemaValidationError: $.prices[0]: expected type 'number' but got 'string'"}
+	if len(errors) != len(expectedErrorMessages) || errors[0].Error() != expectedErrorMessages[0] {
+		t.Errorf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errors)
+	}
+
+	expectedDst := map[string]any{"authors": []any{"Nietzsche", "Stirner"}}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+type panickingTransformer struct{}
+
+func (panickingTransformer) Transform(value any) (any, error) { panic("boom") }
+
+type sleepingTransformer struct{ duration time.Duration }
+
+func (t sleepingTransformer) Transform(value any) (any, error) {
+	time.Sleep(t.duration)
+	return value, nil
+}
+
+func TestMapIsolatesTransformers(t *testing.T) {
+	src := map[string]any{"author": "Nietzsche", "title": "Book1", "price": 15}
+	dst := map[string]any{}
+	mappers := []Mapper{
+		{
+			SrcJsonPath:     "$.author",
+			DstJsonPath:     "$.author",
+			Transformations: []Transformation{{Trsnfmr: panickingTransformer{}}},
+		},
+		{
+			SrcJsonPath:     "$.title",
+			DstJsonPath:     "$.title",
+			Transformations: []Transformation{{Trsnfmr: NumberTransformer{}}, {Trsnfmr: sleepingTransformer{time.Second}}},
+			Timeout:         10 * time.Millisecond,
+		},
+		{
+			SrcJsonPath:     "$.title",
+			DstJsonPath:     "$.title",
+			Transformations: []Transformation{{Trsnfmr: ReplaceTransformer{OldVal: "Book", NewVal: "B"}}, {Trsnfmr: sleepingTransformer{time.Second}}},
+			Timeout:         10 * time.Millisecond,
+		},
+		{
+			SrcJsonPath:     "$.price",
+			DstJsonPath:     "$.cost",
+			Transformations: []Transformation{{Trsnfmr: sleepingTransformer{time.Millisecond}}},
+			Timeout:         time.Second,
+		},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	expectedErrorMessages := []string{
+		"Mapper[0]: Transformation[0] (jsonmanu.panickingTransformer): panic: boom",
+		"Mapper[1]: Transformation[0] (jsonmanu.NumberTransformer): Couldn't convert value to number.",
+		"Mapper[2]: Transformation[1] (jsonmanu.sleepingTransformer): Timed out after 10ms",
+	}
+	if len(errors) != len(expectedErrorMessages) {
+		t.Fatalf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errors)
+	}
+	for i, err := range errors {
+		if err.Error() != expectedErrorMessages[i] {
+			t.Errorf("Expected error message '%#v', but got '%#v'", expectedErrorMessages[i], err.Error())
+		}
+	}
+	if ErrorCode(errors[2]) != ErrCodeTimeout {
+		t.Errorf("Expected code '%v', but got '%v'", ErrCodeTimeout, ErrorCode(errors[2]))
+	}
+
+	expectedDst := map[string]any{"cost": 15}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+// touchingTransformer marks the object value as touched after a delay.
+type touchingTransformer struct {
+	duration time.Duration
+	done     chan struct{}
+}
+
+func (t touchingTransformer) Transform(value any) (any, error) {
+	time.Sleep(t.duration)
+	value.(map[string]any)["touched"] = true
+	close(t.done)
+	return value, nil
+}
+
+func TestMapTimedOutTransformersWorkOnCopy(t *testing.T) {
+	src := map[string]any{"book": map[string]any{"title": "Book1"}}
+	done := make(chan struct{})
+	mappers := []Mapper{
+		{
+			SrcJsonPath:     "$.book",
+			DstJsonPath:     "$.book",
+			Transformations: []Transformation{{Trsnfmr: touchingTransformer{20 * time.Millisecond, done}}},
+			Timeout:         time.Millisecond,
+		},
+	}
+
+	errors := Map(src, map[string]any{}, mappers)
+	if len(errors) != 1 || ErrorCode(errors[0]) != ErrCodeTimeout {
+		t.Fatalf("Expected a timeout error, but got '%v'", errors)
+	}
+
+	<-done
+	expectedSrc := map[string]any{"book": map[string]any{"title": "Book1"}}
+	if !cmp.Equal(expectedSrc, src) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedSrc), gu.Prettify(src))
+	}
+}
+
+func TestMapWithDstSchemaRequiredAcrossMappers(t *testing.T) {
+	src := map[string]any{"book": map[string]any{"author": "Nietzsche", "title": "Book1", "isbn": 1}}
+	dst := map[string]any{}
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"author", "info"},
+		"properties": map[string]any{
+			"author": map[string]any{"type": "string"},
+			"info": map[string]any{
+				"type":                 "object",
+				"required":             []any{"title", "isbn"},
+				"additionalProperties": false,
+				"properties": map[string]any{
+					"title": map[string]any{"type": "string"},
+					"isbn":  map[string]any{"type": "string"},
+				},
+			},
+		},
+	}
+	mappers := []Mapper{
+		{SrcJsonPath: "$.book.author", DstJsonPath: "$.author", DstSchema: schema},
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.info.title", DstSchema: schema},
+		{SrcJsonPath: "$.book.isbn", DstJsonPath: "$.info.isbn", DstSchema: schema},
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.info.name", DstSchema: schema},
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.author.name", DstSchema: schema},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	expectedErrorMessages := []string{
+		"Mapper[2]: Error while putting value in destination: schemaValidationError: $.info.isbn: expected type 'string' but got 'number'",
+		"Mapper[3]: Error while putting value in destination: schemaValidationError: $.info: additional property 'name' is not allowed",
+		"Mapper[4]: Error while putting value in destination: schemaValidationError: $.author: expected type 'string' but got 'object'",
+	}
+	var errorMessages []string
+	for _, err := range errors {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if !cmp.Equal(expectedErrorMessages, errorMessages) {
+		t.Errorf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errorMessages)
+	}
+
+	expectedDst := map[string]any{"author": "Nietzsche", "info": map[string]any{"title": "Book1"}}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+func TestMapToDstArrays(t *testing.T) {
+	src := map[string]any{
+		"book":    map[string]any{"author": "Nietzsche", "title": "Book1"},
+		"authors": []any{"Nietzsche", "Stirner"},
+	}
+	dst := map[string]any{
+		"items": []any{map[string]any{"name": "Existing", "kind": "book"}},
+	}
+	mappers := []Mapper{
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.items[0].name"},
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.items[+].name"},
+		{SrcJsonPath: "$.book.author", DstJsonPath: "$.items[3].author", PadArrays: true},
+		{SrcJsonPath: "$.authors", DstJsonPath: "$.meta.authors[+]"},
+		{SrcJsonPath: "$.book.author", DstJsonPath: "$.groups[+].members[+].name"},
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.invalid[+].name", DstSchema: map[string]any{"properties": map[string]any{"invalid": map[string]any{"items": map[string]any{"properties": map[string]any{"name": map[string]any{"type": "number"}}}}}}},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	expectedErrorMessages := []string{"Mapper[5]: Error while putting value in destination: schemaValidationError: $.invalid[0].name: expected type 'number' but got 'string'"}
+	if len(errors) != len(expectedErrorMessages) || errors[0].Error() != expectedErrorMessages[0] {
+		t.Errorf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errors)
+	}
+
+	expectedDst := map[string]any{
+		"items": []any{
+			map[string]any{"name": "Book1", "kind": "book"},
+			map[string]any{"name": "Book1"},
+			nil,
+			map[string]any{"author": "Nietzsche"},
+		},
+		"meta": map[string]any{
+			"authors": []any{[]any{"Nietzsche", "Stirner"}},
+		},
+		"groups": []any{
+			map[string]any{"members": []any{map[string]any{"name": "Nietzsche"}}},
+		},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+type MapToDstArraysIndexTestCase struct {
+	dstJsonPath  string
+	expectedCode string
+}
+
+func TestMapToDstArraysIndexErrors(t *testing.T) {
+	testCases := []MapToDstArraysIndexTestCase{
+		{dstJsonPath: "$.items[3].name", expectedCode: ErrCodeInvalidOperation},
+		{dstJsonPath: "$.groups[+].items[2]", expectedCode: ErrCodeInvalidOperation},
+		{dstJsonPath: "$.items[99999999999999999999].name", expectedCode: ErrCodeInvalidPath},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Map(..., %v)", i, tc.dstJsonPath), func(t *testing.T) {
+			dst := map[string]any{"items": []any{"Existing"}}
+
+			errors := Map(map[string]any{"title": "Book1"}, dst, []Mapper{{SrcJsonPath: "$.title", DstJsonPath: tc.dstJsonPath}})
+
+			if len(errors) != 1 || ErrorCode(errors[0]) != tc.expectedCode {
+				t.Fatalf("Expected one error with code '%v', but got '%#v'", tc.expectedCode, errors)
+			}
+
+			expectedDst := map[string]any{"items": []any{"Existing"}}
+			if !cmp.Equal(expectedDst, dst) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+			}
+		})
+	}
+}
+
+func TestMapUniqueBy(t *testing.T) {
+	src := map[string]any{
+		"tags":    []any{"philosophy", "classic"},
+		"tag":     "philosophy",
+		"book":    map[string]any{"id": 1, "title": "Book1"},
+		"edition": map[string]any{"id": 1.0, "title": "Book1 (2nd edition)"},
+		"other":   map[string]any{"id": 2, "title": "Book2"},
+	}
+	dst := map[string]any{"tags": []any{"classic"}}
+	mappers := []Mapper{
+		{SrcJsonPath: "$.tag", DstJsonPath: "$.tags[+]", UniqueBy: "$"},
+		{SrcJsonPath: "$.tag", DstJsonPath: "$.tags[+]", UniqueBy: "$"},
+		{SrcJsonPath: "$.tags", DstJsonPath: "$.tags[+]", UniqueBy: "$"},
+		{SrcJsonPath: "$.book", DstJsonPath: "$.books[+]", UniqueBy: "$.id"},
+		{SrcJsonPath: "$.edition", DstJsonPath: "$.books[+]", UniqueBy: "$.id"},
+		{SrcJsonPath: "$.other", DstJsonPath: "$.books[+]", UniqueBy: "$.id"},
+		{SrcJsonPath: "$.tag", DstJsonPath: "$.books[+]", UniqueBy: "$.id"},
+		{SrcJsonPath: "$.book", DstJsonPath: "$.books[+]", UniqueBy: "$.["},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	if len(errors) != 1 || !strings.HasPrefix(errors[0].Error(), "Mapper[7]: Error while checking for duplicates in destination: ") {
+		t.Errorf("Expected one error of Mapper[7], but got '%#v'", errors)
+	}
+
+	expectedDst := map[string]any{
+		"tags": []any{"classic", "philosophy", []any{"philosophy", "classic"}},
+		"books": []any{
+			map[string]any{"id": 1, "title": "Book1"},
+			map[string]any{"id": 2, "title": "Book2"},
+			"philosophy",
+		},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+func TestMapWithOptionsHooks(t *testing.T) {
+	src := map[string]any{"author": "Nietzsche", "title": "Book1"}
+	dst := map[string]any{}
+
+	var calls []string
+	hook := func(name string, err error) func(map[string]any) error {
+		return func(map[string]any) error {
+			calls = append(calls, name)
+			return err
+		}
+	}
+
+	mappers := []Mapper{
+		{SrcJsonPath: "$.author", DstJsonPath: "$.author", Before: hook("before author", nil), After: hook("after author", nil)},
+		{SrcJsonPath: "$.title", DstJsonPath: "$.title", Before: hook("before title", fmt.Errorf("skipped"))},
+		{SrcJsonPath: "$.title", DstJsonPath: "$.name", After: hook("after name", fmt.Errorf("invalid"))},
+	}
+	opts := MapOptions{Before: hook("before", nil), After: hook("after", fmt.Errorf("failed"))}
+
+	errors := MapWithOptions(src, dst, mappers, opts)
+
+	expectedErrorMessages := []string{
+		"Mapper[1]: Before hook: skipped",
+		"Mapper[2]: After hook: invalid",
+		"After hook: failed",
+	}
+	if len(errors) != len(expectedErrorMessages) {
+		t.Fatalf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errors)
+	}
+	for i, err := range errors {
+		if err.Error() != expectedErrorMessages[i] {
+			t.Errorf("Expected error message '%#v', but got '%#v'", expectedErrorMessages[i], err.Error())
+		}
+	}
+
+	expectedCalls := []string{"before", "before author", "after author", "before title", "after name", "after"}
+	if !cmp.Equal(expectedCalls, calls) {
+		t.Errorf("Expected calls '%#v', but got '%#v'", expectedCalls, calls)
+	}
+
+	expectedDst := map[string]any{"author": "Nietzsche", "name": "Book1"}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+
+	errors = MapWithOptions(src, dst, mappers, MapOptions{Before: hook("before", fmt.Errorf("aborted"))})
+	if len(errors) != 1 || errors[0].Error() != "Before hook: aborted" {
+		t.Errorf("Expected error messages '%#v', but got '%#v'", []string{"Before hook: aborted"}, errors)
+	}
+}
+
+func TestMapWithOptionsPrune(t *testing.T) {
+	src := map[string]any{"book": map[string]any{"title": "Book1", "author": nil, "isbn": nil, "tags": []any{}}}
+	dst := map[string]any{}
+	mappers := []Mapper{
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.title"},
+		{SrcJsonPath: "$.book.author", DstJsonPath: "$.author"},
+		{SrcJsonPath: "$.book.isbn", DstJsonPath: "$.identifiers.isbn"},
+		{SrcJsonPath: "$.book.tags", DstJsonPath: "$.meta.tags[+]"},
+	}
+
+	var afterDst map[string]any
+	opts := MapOptions{
+		Prune: &PruneOptions{},
+		After: func(dst map[string]any) error { afterDst = deepCopy(dst).(map[string]any); return nil },
+	}
+	MapWithOptions(src, dst, mappers, opts)
+
+	expectedDst := map[string]any{"title": "Book1"}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+	if !cmp.Equal(expectedDst, afterDst) {
+		t.Errorf("Expected the After hook to get '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(afterDst))
+	}
+}
+
+func TestMapWithOptionsPassthrough(t *testing.T) {
+	src := map[string]any{
+		"title":  "Book1",
+		"author": map[string]any{"name": "Nietzsche"},
+		"price":  "15",
+	}
+	original := deepCopy(src)
+	dst := map[string]any{"id": 1, "title": "Unknown"}
+	mappers := []Mapper{
+		{
+			SrcJsonPath:     "$.price",
+			DstJsonPath:     "$.price",
+			Transformations: []Transformation{{Trsnfmr: NumberTransformer{}}},
+		},
+		{SrcJsonPath: "$.author.name", DstJsonPath: "$.authorName"},
+		{SrcJsonPath: "$.author.name", DstJsonPath: "$.author.fullName"},
+	}
+
+	errors := MapWithOptions(src, dst, mappers, MapOptions{Passthrough: true})
+	if len(errors) > 0 {
+		t.Errorf("Expected no errors, but got '%#v'", errors)
+	}
+
+	expectedDst := map[string]any{
+		"id":         1,
+		"title":      "Book1",
+		"author":     map[string]any{"name": "Nietzsche", "fullName": "Nietzsche"},
+		"authorName": "Nietzsche",
+		"price":      float64(15),
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+	if !cmp.Equal(original, src) {
+		t.Errorf("Expected the source to remain '%s', but got '%s'", gu.Prettify(original), gu.Prettify(src))
+	}
+}
+
+func TestMapWithOptionsDrop(t *testing.T) {
+	src := map[string]any{
+		"title":    "Book1",
+		"internal": map[string]any{"cost": 5},
+		"authors": []any{
+			map[string]any{"name": "Nietzsche", "email": "fn@example.com"},
+			map[string]any{"name": "Stirner", "email": "ms@example.com"},
+		},
+	}
+	dst := map[string]any{}
+	mappers := []Mapper{{SrcJsonPath: "$.internal.cost", DstJsonPath: "$.cost"}}
+	opts := MapOptions{Passthrough: true, Drop: []string{"$.internal", "$.authors[*].email", "$.missing.key", "$..cost"}}
+
+	errors := MapWithOptions(src, dst, mappers, opts)
+
+	expectedErrorMessages := []string{"Drop[3]: Reccursive descent not allowed in delete path."}
+	if len(errors) != len(expectedErrorMessages) || errors[0].Error() != expectedErrorMessages[0] {
+		t.Errorf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errors)
+	}
+
+	expectedDst := map[string]any{
+		"title":   "Book1",
+		"cost":    5,
+		"authors": []any{map[string]any{"name": "Nietzsche"}, map[string]any{"name": "Stirner"}},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+func TestMapRenameKeys(t *testing.T) {
+	src := map[string]any{
+		"book_title": "Book1",
+		"author":     map[string]any{"first_name": "Friedrich", "last_name": "Nietzsche"},
+	}
+	rename := []Transformation{{Trsnfmr: RenameKeysTransformer{Case: KeyCaseCamel}}}
+
+	dst := map[string]any{}
+	errors := Map(src, dst, []Mapper{{SrcJsonPath: "$", DstJsonPath: "$", Transformations: rename}})
+	if len(errors) > 0 {
+		t.Errorf("Expected no errors, but got '%#v'", errors)
+	}
+
+	expectedDst := map[string]any{
+		"bookTitle": "Book1",
+		"author":    map[string]any{"firstName": "Friedrich", "lastName": "Nietzsche"},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+
+	dst = map[string]any{}
+	errors = MapWithOptions(src, dst, []Mapper{{SrcJsonPath: "$.author", DstJsonPath: "$.author", Transformations: rename}}, MapOptions{Passthrough: true})
+	if len(errors) > 0 {
+		t.Errorf("Expected no errors, but got '%#v'", errors)
+	}
+
+	expectedDst = map[string]any{
+		"book_title": "Book1",
+		"author":     map[string]any{"firstName": "Friedrich", "lastName": "Nietzsche"},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+func TestMapWithJoin(t *testing.T) {
+	src := map[string]any{
+		"orders": []any{
+			map[string]any{"id": 1, "customerId": 10},
+			map[string]any{"id": 2, "customerId": 20.0},
+			map[string]any{"id": 3, "customerId": 30},
+			map[string]any{"id": 4},
+		},
+		"customers": []any{
+			map[string]any{"customerId": 10, "name": "Nietzsche"},
+			map[string]any{"customerId": 20, "name": "Stirner", "id": 200},
+		},
+		"customer": "invalid",
+	}
+	original := deepCopy(src)
+	dst := map[string]any{}
+	mappers := []Mapper{
+		{
+			SrcJsonPath: "$.orders",
+			DstJsonPath: "$.nested",
+			Join:        &Join{SrcJsonPath: "$.customers", LocalKey: "customerId", ForeignKey: "customerId", As: "customer"},
+		},
+		{
+			SrcJsonPath:     "$.orders",
+			DstJsonPath:     "$.names",
+			Join:            &Join{SrcJsonPath: "$.customers", LocalKey: "customerId", ForeignKey: "customerId"},
+			Transformations: []Transformation{{Trsnfmr: UnzipTransformer{Fields: map[string]string{"name": "names"}}, AsArray: true}},
+		},
+		{
+			SrcJsonPath: "$.orders",
+			DstJsonPath: "$.invalid",
+			Join:        &Join{SrcJsonPath: "$.customer", LocalKey: "customerId", ForeignKey: "customerId"},
+		},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	expectedErrorMessages := []string{"Mapper[2]: Join error: Value of '$.customer' is not an array."}
+	if len(errors) != len(expectedErrorMessages) || errors[0].Error() != expectedErrorMessages[0] {
+		t.Errorf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errors)
+	}
+
+	expectedDst := map[string]any{
+		"nested": []any{
+			map[string]any{"id": 1, "customerId": 10, "customer": map[string]any{"customerId": 10, "name": "Nietzsche"}},
+			map[string]any{"id": 2, "customerId": 20.0, "customer": map[string]any{"customerId": 20, "name": "Stirner", "id": 200}},
+			map[string]any{"id": 3, "customerId": 30},
+			map[string]any{"id": 4},
+		},
+		"names": map[string]any{
+			"names": []any{"Nietzsche", "Stirner", nil, nil},
+		},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+	if !cmp.Equal(original, src) {
+		t.Errorf("Expected the source to remain '%s', but got '%s'", gu.Prettify(original), gu.Prettify(src))
+	}
+}
+
+func TestMapWithKeyTransformations(t *testing.T) {
+	src := map[string]any{
+		"headers": map[string]any{"X-Vendor-Trace": "abc", "Accept": "json"},
+		"items": []any{
+			map[string]any{"X-Vendor-Id": 1},
+			"plain",
+		},
+	}
+	original := deepCopy(src)
+	dst := map[string]any{}
+	mappers := []Mapper{
+		{
+			SrcJsonPath:        "$.headers",
+			DstJsonPath:        "$.headers",
+			KeyTransformations: []Transformation{{Trsnfmr: ReplaceTransformer{OldVal: "X-Vendor-", NewVal: ""}}},
+		},
+		{
+			SrcJsonPath:        "$.items",
+			DstJsonPath:        "$.items",
+			KeyTransformations: []Transformation{{Trsnfmr: ReplaceTransformer{OldVal: "X-Vendor-", NewVal: ""}}},
+		},
+		{
+			SrcJsonPath:        "$.headers",
+			DstJsonPath:        "$.invalid",
+			KeyTransformations: []Transformation{{Trsnfmr: NumberTransformer{}}},
+		},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	if len(errors) != 1 || !strings.HasPrefix(errors[0].Error(), "Mapper[2]: KeyTransformation[0] (jsonmanu.NumberTransformer): ") {
+		t.Errorf("Expected a key transformation error, but got '%#v'", errors)
+	}
+
+	expectedDst := map[string]any{
+		"headers": map[string]any{"Trace": "abc", "Accept": "json"},
+		"items":   []any{map[string]any{"Id": 1}, "plain"},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+	if !cmp.Equal(original, src) {
+		t.Errorf("Expected the source to remain '%s', but got '%s'", gu.Prettify(original), gu.Prettify(src))
+	}
+}
+
+func TestMapWithPreconditions(t *testing.T) {
+	src := map[string]any{
+		"name":  "Nietzsche",
+		"books": []any{map[string]any{"title": "Book1"}},
+	}
+	dst := map[string]any{"name": "Stirner", "year": nil}
+	mappers := []Mapper{
+		{SrcJsonPath: "$.books[?(@.title == Book2)]", DstJsonPath: "$.optional"},
+		{SrcJsonPath: "$.books[?(@.title == Book2)]", DstJsonPath: "$.required", RequireSrc: true},
+		{SrcJsonPath: "$.age", DstJsonPath: "$.age", RequireSrc: true},
+		{SrcJsonPath: "$.name", DstJsonPath: "$.name", RequireDstAbsent: true},
+		{SrcJsonPath: "$.name", DstJsonPath: "$.year", RequireDstAbsent: true},
+		{SrcJsonPath: "$.name", DstJsonPath: "$.authors[+]", RequireDstAbsent: true},
+		{SrcJsonPath: "$.name", DstJsonPath: "$.nested.name", RequireDstAbsent: true},
+		{SrcJsonPath: "$.name", DstJsonPath: "$.nested.name", RequireDstAbsent: true},
+	}
+
+	errors := Map(src, dst, mappers)
+
+	expectedErrorMessages := []string{
+		"Mapper[1]: Error while getting value from data: Required source value not found.",
+		"Mapper[2]: Error while getting value from data: Required source value not found.",
+		"Mapper[3]: Error while putting value in destination: Destination value already exists.",
+		"Mapper[7]: Error while putting value in destination: Destination value already exists.",
+	}
+	if len(errors) != len(expectedErrorMessages) {
+		t.Fatalf("Expected error messages '%#v', but got '%#v'", expectedErrorMessages, errors)
+	}
+	for i, err := range errors {
+		if err.Error() != expectedErrorMessages[i] {
+			t.Errorf("Expected error message '%v', but got '%v'", expectedErrorMessages[i], err)
+		}
+	}
+
+	expectedDst := map[string]any{
+		"name":     "Stirner",
+		"year":     "Nietzsche",
+		"optional": []any(nil),
+		"authors":  []any{"Nietzsche"},
+		"nested":   map[string]any{"name": "Nietzsche"},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
+
+type envelopeTransformer struct{ version int }
+
+func (t envelopeTransformer) Transform(value any) (any, error) {
+	return map[string]any{"version": t.version, "data": value}, nil
+}
+
+type notObjectTransformer struct{}
+
+func (notObjectTransformer) Transform(value any) (any, error) { return []any{value}, nil }
+
+func TestMapWithOptionsPostTransform(t *testing.T) {
+	src := map[string]any{"book": map[string]any{"title": "Book1", "author": nil}}
+	mappers := []Mapper{
+		{SrcJsonPath: "$.book.title", DstJsonPath: "$.bookTitle"},
+		{SrcJsonPath: "$.book.author", DstJsonPath: "$.author"},
+	}
+
+	dst := map[string]any{}
+	var afterDst map[string]any
+	opts := MapOptions{
+		Prune:         &PruneOptions{},
+		PostTransform: []Transformer{RenameKeysTransformer{Case: KeyCaseSnake}, envelopeTransformer{version: 2}},
+		After:         func(dst map[string]any) error { afterDst = deepCopy(dst).(map[string]any); return nil },
+	}
+	errors := MapWithOptions(src, dst, mappers, opts)
+
+	expectedDst := map[string]any{"version": 2, "data": map[string]any{"book_title": "Book1"}}
+	if len(errors) > 0 {
+		t.Errorf("Expected no errors, but got '%#v'", errors)
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+	if !cmp.Equal(expectedDst, afterDst) {
+		t.Errorf("Expected the After hook to get '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(afterDst))
+	}
+
+	dst = map[string]any{}
+	opts = MapOptions{PostTransform: []Transformer{envelopeTransformer{version: 1}, notObjectTransformer{}, panickingTransformer{}}}
+	errors = MapWithOptions(src, dst, mappers, opts)
+
+	expectedErrorMessage := "PostTransform[1] (jsonmanu.notObjectTransformer): Result []interface {}{map[string]interface {}{\"data\":map[string]interface {}{\"author\":interface {}(nil), \"bookTitle\":\"Book1\"}, \"version\":1}} is not an object"
+	if len(errors) != 1 || errors[0].Error() != expectedErrorMessage {
+		t.Errorf("Expected error messages '%#v', but got '%#v'", []string{expectedErrorMessage}, errors)
+	}
+	expectedDst = map[string]any{"version": 1, "data": map[string]any{"bookTitle": "Book1", "author": nil}}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+}
@@ -0,0 +1,708 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"time"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// TransformationConfig is the serializable form of a Transformation.
+type TransformationConfig struct {
+
+	// Type is the type of the transformer. It can be one of `split`, `join`, `replace`, `match`, `substr`, `number`,
+	// `zip`, `unzip`, `pivot`, `unpivot`, `renameKeys`, `index`, `pick`, `omit`,
+	// `parseBool`, `money`, `formatMoney`, `normalizeEmail`, `normalizePhone`, `coordinates`, `decimalDegrees`, `dms`,
+	// `geohash`, `ip`, `duration`, `checksum` and `resolve`.
+	Type string `json:"type"`
+
+	// AsArray corresponds to Transformation.AsArray.
+	AsArray bool `json:"asArray,omitempty"`
+
+	// Delim is used by the `split` and `join` transformers.
+	Delim string `json:"delim,omitempty"`
+
+	// Index is used by the `split` and `index` transformers.
+	Index int `json:"index,omitempty"`
+
+	// OldVal is used by the `replace` transformer.
+	OldVal string `json:"oldVal,omitempty"`
+
+	// NewVal is used by the `replace` transformer.
+	NewVal string `json:"newVal,omitempty"`
+
+	// Regex is used by the `match` transformer.
+	Regex string `json:"regex,omitempty"`
+
+	// Start is used by the `substr` transformer.
+	Start int `json:"start,omitempty"`
+
+	// End is used by the `substr` transformer.
+	End int `json:"end,omitempty"`
+
+	// Fields is used by the `zip` and `unzip` transformers.
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// KeyField is used by the `pivot` and `unpivot` transformers.
+	KeyField string `json:"keyField,omitempty"`
+
+	// ValueField is used by the `pivot` and `unpivot` transformers.
+	ValueField string `json:"valueField,omitempty"`
+
+	// TrimPrefix is used by the `renameKeys` transformer.
+	TrimPrefix string `json:"trimPrefix,omitempty"`
+
+	// Case is used by the `renameKeys` transformer.
+	Case string `json:"case,omitempty"`
+
+	// Keys is used by the `pick`, `omit` and `checksum` transformers.
+	Keys []string `json:"keys,omitempty"`
+
+	// TrueValues is used by the `parseBool` transformer.
+	TrueValues []string `json:"trueValues,omitempty"`
+
+	// FalseValues is used by the `parseBool` transformer.
+	FalseValues []string `json:"falseValues,omitempty"`
+
+	// UseDefault is used by the `parseBool` transformer.
+	UseDefault bool `json:"useDefault,omitempty"`
+
+	// Default is used by the `parseBool` transformer.
+	Default *bool `json:"default,omitempty"`
+
+	// Output is used by the `money` and `ip` transformers.
+	Output string `json:"output,omitempty"`
+
+	// Currency is used by the `money` and `formatMoney` transformers.
+	Currency string `json:"currency,omitempty"`
+
+	// DecimalSep is used by the `money` and `formatMoney` transformers.
+	DecimalSep string `json:"decimalSep,omitempty"`
+
+	// ThousandsSep is used by the `formatMoney` transformer.
+	ThousandsSep string `json:"thousandsSep,omitempty"`
+
+	// UseSymbol is used by the `formatMoney` transformer.
+	UseSymbol bool `json:"useSymbol,omitempty"`
+
+	// StripPlusTag is used by the `normalizeEmail` transformer.
+	StripPlusTag bool `json:"stripPlusTag,omitempty"`
+
+	// DefaultRegion is used by the `normalizePhone` transformer.
+	DefaultRegion string `json:"defaultRegion,omitempty"`
+
+	// LonFirst is used by the `coordinates` transformer.
+	LonFirst bool `json:"lonFirst,omitempty"`
+
+	// Axis is used by the `dms` transformer.
+	Axis string `json:"axis,omitempty"`
+
+	// Precision is used by the `dms` and `geohash` transformers.
+	Precision int `json:"precision,omitempty"`
+
+	// PrefixLen is used by the `ip` transformer.
+	PrefixLen int `json:"prefixLen,omitempty"`
+
+	// Unit is used by the `duration` transformer.
+	Unit string `json:"unit,omitempty"`
+
+	// Format is used by the `duration` transformer.
+	Format string `json:"format,omitempty"`
+
+	// Algorithm is used by the `checksum` transformer.
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Resolver is the name of a registered resolver used by the `resolve` transformer.
+	Resolver string `json:"resolver,omitempty"`
+
+	// BatchSize is used by the `resolve` transformer.
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// Timeout is used by the `resolve` transformer in the form accepted by time.ParseDuration, i.e. `500ms`.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// MapperConfig is the serializable form of a Mapper.
+type MapperConfig struct {
+
+	// Src corresponds to Mapper.SrcJsonPath.
+	Src string `json:"src"`
+
+	// Dst corresponds to Mapper.DstJsonPath.
+	Dst string `json:"dst"`
+
+	// DstType corresponds to Mapper.DstType.
+	DstType string `json:"dstType,omitempty"`
+
+	// Transformations corresponds to Mapper.Transformations.
+	Transformations []TransformationConfig `json:"transformations,omitempty"`
+
+	// KeyTransformations corresponds to Mapper.KeyTransformations.
+	KeyTransformations []TransformationConfig `json:"keyTransformations,omitempty"`
+
+	// Timeout corresponds to Mapper.Timeout in the form accepted by time.ParseDuration, i.e. `500ms`.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Join corresponds to Mapper.Join.
+	Join *Join `json:"join,omitempty"`
+
+	// UniqueBy corresponds to Mapper.UniqueBy.
+	UniqueBy string `json:"uniqueBy,omitempty"`
+
+	// RequireSrc corresponds to Mapper.RequireSrc.
+	RequireSrc bool `json:"requireSrc,omitempty"`
+
+	// RequireDstAbsent corresponds to Mapper.RequireDstAbsent.
+	RequireDstAbsent bool `json:"requireDstAbsent,omitempty"`
+
+	// PadArrays corresponds to Mapper.PadArrays.
+	PadArrays bool `json:"padArrays,omitempty"`
+
+	// Recipe is the name of a recipe the configuration is instantiated from. If set, the rest of the fields
+	// but Params are ignored.
+	Recipe string `json:"recipe,omitempty"`
+
+	// Params are the parameters the recipe is instantiated with.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// MappingConfig is the serializable form of a list of mappers.
+type MappingConfig struct {
+	Mappers []MapperConfig `json:"mappers"`
+
+	// Recipes are available to the mappers of the configuration in addition to the registered ones.
+	Recipes []Recipe `json:"recipes,omitempty"`
+
+	// Passthrough corresponds to MapOptions.Passthrough.
+	Passthrough bool `json:"passthrough,omitempty"`
+
+	// Drop corresponds to MapOptions.Drop.
+	Drop []string `json:"drop,omitempty"`
+
+	// Dialect is the notation of the source and destination paths of the mappers. It defaults to JSONPath.
+	Dialect Dialect `json:"dialect,omitempty"`
+
+	// Locations holds the locations of the entries of the configuration by path, i.e. `mappers[0].src`, if it was
+	// loaded by LoadMappingConfig out of JSON or YAML. They are used to locate the errors of the configuration.
+	Locations map[string]SpecLocation `json:"-"`
+}
+
+// ToTransformation converts the configuration to a Transformation.
+func (c TransformationConfig) ToTransformation() (Transformation, error) {
+	var transformer Transformer
+
+	switch c.Type {
+	case "split":
+		transformer = SplitTransformer{Delim: c.Delim, Index: c.Index}
+	case "join":
+		transformer = JoinTransformer{Delim: c.Delim}
+	case "replace":
+		transformer = ReplaceTransformer{OldVal: c.OldVal, NewVal: c.NewVal}
+	case "match":
+		transformer = StringMatchTransformer{Regex: c.Regex}
+	case "substr":
+		transformer = SubStrTransformer{Start: c.Start, End: c.End}
+	case "number":
+		transformer = NumberTransformer{}
+	case "zip":
+		transformer = ZipTransformer{Fields: c.Fields}
+	case "unzip":
+		transformer = UnzipTransformer{Fields: c.Fields}
+	case "pivot":
+		transformer = PivotTransformer{KeyField: c.KeyField, ValueField: c.ValueField}
+	case "unpivot":
+		transformer = UnpivotTransformer{KeyField: c.KeyField, ValueField: c.ValueField}
+	case "renameKeys":
+		transformer = RenameKeysTransformer{TrimPrefix: c.TrimPrefix, Case: c.Case}
+	case "index":
+		transformer = IndexTransformer{N: c.Index}
+	case "pick":
+		transformer = PickTransformer{Keys: c.Keys}
+	case "omit":
+		transformer = OmitTransformer{Keys: c.Keys}
+	case "parseBool":
+		transformer = ParseBoolTransformer{TrueValues: c.TrueValues, FalseValues: c.FalseValues, UseDefault: c.UseDefault, Default: c.Default}
+	case "money":
+		transformer = MoneyTransformer{Output: c.Output, Currency: c.Currency, DecimalSep: c.DecimalSep}
+	case "formatMoney":
+		transformer = MoneyFormatTransformer{Currency: c.Currency, UseSymbol: c.UseSymbol, DecimalSep: c.DecimalSep, ThousandsSep: c.ThousandsSep}
+	case "normalizeEmail":
+		transformer = EmailNormalizeTransformer{StripPlusTag: c.StripPlusTag}
+	case "normalizePhone":
+		transformer = PhoneNormalizeTransformer{DefaultRegion: c.DefaultRegion}
+	case "coordinates":
+		transformer = CoordinatesTransformer{LonFirst: c.LonFirst}
+	case "decimalDegrees":
+		transformer = DecimalDegreesTransformer{}
+	case "dms":
+		transformer = DMSTransformer{Axis: c.Axis, Precision: c.Precision}
+	case "geohash":
+		transformer = GeohashTransformer{Precision: c.Precision}
+	case "ip":
+		transformer = IPTransformer{Output: c.Output, PrefixLen: c.PrefixLen}
+	case "duration":
+		transformer = DurationTransformer{Unit: c.Unit, Format: c.Format}
+	case "checksum":
+		transformer = ChecksumTransformer{Algorithm: c.Algorithm, Keys: c.Keys}
+	case "resolve":
+		resolver, err := findResolver(c.Resolver)
+		if err != nil {
+			return Transformation{}, err
+		}
+		resolveTransformer := ResolveTransformer{Resolver: resolver, BatchSize: c.BatchSize}
+		if c.Timeout != "" {
+			if resolveTransformer.Timeout, err = time.ParseDuration(c.Timeout); err != nil {
+				return Transformation{}, fmt.Errorf("Invalid timeout: %w", err)
+			}
+		}
+		transformer = resolveTransformer
+	default:
+		return Transformation{}, &Error{Code: ErrCodeUnknownTransformer, Message: fmt.Sprintf("Unknown transformer type '%v'", c.Type)}
+	}
+
+	return Transformation{Trsnfmr: transformer, AsArray: c.AsArray}, nil
+}
+
+// ToMapper converts the configuration to a Mapper.
+//
+// The returned errors are SpecErrors located at the offending field, i.e. `transformations[0].type`.
+func (c MapperConfig) ToMapper() (Mapper, error) {
+	mapper := Mapper{
+		SrcJsonPath:      c.Src,
+		DstJsonPath:      c.Dst,
+		DstType:          c.DstType,
+		Join:             c.Join,
+		UniqueBy:         c.UniqueBy,
+		RequireSrc:       c.RequireSrc,
+		RequireDstAbsent: c.RequireDstAbsent,
+		PadArrays:        c.PadArrays,
+	}
+
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return Mapper{}, &SpecError{Location: SpecLocation{Path: "timeout"}, Err: fmt.Errorf("Invalid timeout: %w", err)}
+		}
+		mapper.Timeout = timeout
+	}
+
+	for i, transformationConfig := range c.Transformations {
+		transformation, err := transformationConfig.ToTransformation()
+		if err != nil {
+			location := SpecLocation{Path: fmt.Sprintf("transformations[%v].type", i)}
+			return Mapper{}, &SpecError{Location: location, Err: fmt.Errorf("Transformation[%v]: %w", i, err)}
+		}
+		mapper.Transformations = append(mapper.Transformations, transformation)
+	}
+
+	for i, transformationConfig := range c.KeyTransformations {
+		transformation, err := transformationConfig.ToTransformation()
+		if err != nil {
+			location := SpecLocation{Path: fmt.Sprintf("keyTransformations[%v].type", i)}
+			return Mapper{}, &SpecError{Location: location, Err: fmt.Errorf("KeyTransformation[%v]: %w", i, err)}
+		}
+		mapper.KeyTransformations = append(mapper.KeyTransformations, transformation)
+	}
+
+	return mapper, nil
+}
+
+// ToMappers converts the configuration to a list of mappers.
+//
+// The returned errors are SpecErrors located at the offending entry of the configuration.
+func (c MappingConfig) ToMappers() ([]Mapper, error) {
+	var mappers []Mapper
+
+	for i, mapperConfig := range c.Mappers {
+		mapperPath := fmt.Sprintf("mappers[%v]", i)
+
+		if mapperConfig.Recipe != "" {
+			recipeMappers, err := c.recipeMappers(mapperConfig)
+			if err != nil {
+				return nil, &SpecError{Location: c.location(mapperPath + ".recipe"), Err: fmt.Errorf("Mapper[%v]: %w", i, err)}
+			}
+			for j := range recipeMappers {
+				if err := c.convertDialect(&recipeMappers[j]); err != nil {
+					return nil, &SpecError{Location: c.location(mapperPath + ".recipe"), Err: fmt.Errorf("Mapper[%v]: %w", i, err)}
+				}
+			}
+			mappers = append(mappers, recipeMappers...)
+			continue
+		}
+
+		mapper, err := mapperConfig.ToMapper()
+		if err == nil {
+			err = c.convertDialect(&mapper)
+		}
+		if err != nil {
+			return nil, c.specError(mapperPath, fmt.Errorf("Mapper[%v]: %w", i, err))
+		}
+		mappers = append(mappers, mapper)
+	}
+
+	return mappers, nil
+}
+
+// convertDialect converts the source and destination paths of the mapper from the dialect of the configuration to JSONPath.
+func (c MappingConfig) convertDialect(mapper *Mapper) error {
+	if c.Dialect == "" || c.Dialect == DialectJsonPath {
+		return nil
+	}
+
+	var err error
+	if mapper.SrcJsonPath, err = ToJsonPath(mapper.SrcJsonPath, c.Dialect); err != nil {
+		return &SpecError{Location: SpecLocation{Path: "src"}, Err: err}
+	}
+	if mapper.DstJsonPath, err = ToJsonPath(mapper.DstJsonPath, c.Dialect); err != nil {
+		return &SpecError{Location: SpecLocation{Path: "dst"}, Err: err}
+	}
+
+	return nil
+}
+
+// specVariablePattern matches the `${NAME}` variable references of a mapping configuration.
+var specVariablePattern = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// specPathVariableValuePattern matches the values of the variables which may be referenced in paths, i.e. single keys.
+var specPathVariableValuePattern = regexp.MustCompile(`^\w+$`)
+
+// substitutePathVariables replaces the variable references of a path, or of a value which may end up in a path. The
+// referenced variables must be single keys so that they cannot change what the path selects, i.e. with `*`, `..` or
+// a filter.
+func substitutePathVariables(s string, vars map[string]string) (string, error) {
+	for _, reference := range specVariablePattern.FindAllStringSubmatch(s, -1) {
+		if value, ok := vars[reference[1]]; ok && !specPathVariableValuePattern.MatchString(value) {
+			return "", &Error{
+				Code:    ErrCodeInvalidPath,
+				Message: fmt.Sprintf("Variable '%v' should consist of letters, digits and underscores to be used in a path", reference[1]),
+				Path:    s,
+				Segment: reference[0],
+			}
+		}
+	}
+
+	return substitute(s, specVariablePattern, vars, "variable")
+}
+
+// configField is a string field of a configuration along with its path.
+type configField struct {
+	path  string
+	value *string
+
+	// jsonPath determines whether the field is a JSONPath, whose variables are substituted by substitutePathVariables.
+	jsonPath bool
+}
+
+// stringFields returns the string fields of the mapper configuration which may hold variable references.
+func (c *MapperConfig) stringFields() []configField {
+	fields := []configField{
+		{path: "src", value: &c.Src, jsonPath: true},
+		{path: "dst", value: &c.Dst, jsonPath: true},
+		{path: "timeout", value: &c.Timeout},
+		{path: "uniqueBy", value: &c.UniqueBy, jsonPath: true},
+	}
+
+	if c.Join != nil {
+		fields = append(fields, configField{path: "join.src", value: &c.Join.SrcJsonPath, jsonPath: true})
+	}
+
+	for i := range c.Transformations {
+		fields = append(fields, c.Transformations[i].stringFields(fmt.Sprintf("transformations[%v].", i))...)
+	}
+	for i := range c.KeyTransformations {
+		fields = append(fields, c.KeyTransformations[i].stringFields(fmt.Sprintf("keyTransformations[%v].", i))...)
+	}
+
+	return fields
+}
+
+// stringFields returns the string fields of the transformation configuration which may hold variable references with
+// their paths prefixed by the provided one.
+func (t *TransformationConfig) stringFields(prefix string) []configField {
+	return []configField{
+		{path: prefix + "delim", value: &t.Delim},
+		{path: prefix + "oldVal", value: &t.OldVal},
+		{path: prefix + "newVal", value: &t.NewVal},
+		{path: prefix + "regex", value: &t.Regex},
+		{path: prefix + "trimPrefix", value: &t.TrimPrefix},
+		{path: prefix + "keyField", value: &t.KeyField},
+		{path: prefix + "valueField", value: &t.ValueField},
+		{path: prefix + "output", value: &t.Output},
+		{path: prefix + "currency", value: &t.Currency},
+		{path: prefix + "decimalSep", value: &t.DecimalSep},
+		{path: prefix + "thousandsSep", value: &t.ThousandsSep},
+		{path: prefix + "defaultRegion", value: &t.DefaultRegion},
+		{path: prefix + "axis", value: &t.Axis},
+		{path: prefix + "unit", value: &t.Unit},
+		{path: prefix + "format", value: &t.Format},
+		{path: prefix + "algorithm", value: &t.Algorithm},
+		{path: prefix + "resolver", value: &t.Resolver},
+		{path: prefix + "timeout", value: &t.Timeout},
+	}
+}
+
+// withVariables returns a copy of the mapper configuration with its variable references replaced.
+func (c MapperConfig) withVariables(vars map[string]string) (MapperConfig, error) {
+	result := c
+	result.Transformations = append([]TransformationConfig(nil), c.Transformations...)
+	result.KeyTransformations = append([]TransformationConfig(nil), c.KeyTransformations...)
+	if c.Join != nil {
+		join := *c.Join
+		result.Join = &join
+	}
+
+	for _, field := range result.stringFields() {
+		var err error
+		if field.jsonPath {
+			*field.value, err = substitutePathVariables(*field.value, vars)
+		} else {
+			*field.value, err = substitute(*field.value, specVariablePattern, vars, "variable")
+		}
+		if err != nil {
+			return result, &SpecError{Location: SpecLocation{Path: field.path}, Err: err}
+		}
+	}
+
+	if c.Params != nil {
+		result.Params = make(map[string]string, len(c.Params))
+		for _, name := range sortedStringKeys(c.Params) {
+			// the parameters may be placed in the paths of the recipe
+			value, err := substitutePathVariables(c.Params[name], vars)
+			if err != nil {
+				return result, &SpecError{Location: SpecLocation{Path: "params." + name}, Err: err}
+			}
+			result.Params[name] = value
+		}
+	}
+
+	return result, nil
+}
+
+// sortedStringKeys returns the keys of the map in lexicographical order.
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// WithVariables returns a copy of the configuration where the `${NAME}` references in the paths and the string
+// parameters of the mappers, the recipes and the dropped paths, i.e. `$.tenants.${TENANT_ID}.config`, are replaced
+// by the corresponding variables so that a single configuration serves multiple environments.
+//
+// The variables referenced in paths and in the parameters of recipes, which may end up in paths, must consist of
+// letters, digits and underscores, so that their values are always single keys and cannot widen what the paths
+// select, i.e. a TENANT_ID of `*` selecting the configuration of all the tenants.
+//
+// The returned errors are SpecErrors located at the field referring to a missing variable.
+func (c MappingConfig) WithVariables(vars map[string]string) (MappingConfig, error) {
+	result := c
+	result.Mappers = append([]MapperConfig(nil), c.Mappers...)
+	result.Recipes = append([]Recipe(nil), c.Recipes...)
+	result.Drop = append([]string(nil), c.Drop...)
+
+	for i, mapperConfig := range c.Mappers {
+		var err error
+		if result.Mappers[i], err = mapperConfig.withVariables(vars); err != nil {
+			return c, c.specError(fmt.Sprintf("mappers[%v]", i), fmt.Errorf("Mapper[%v]: %w", i, err))
+		}
+	}
+
+	for i, recipe := range c.Recipes {
+		result.Recipes[i].Mappers = append([]MapperConfig(nil), recipe.Mappers...)
+		for j, mapperConfig := range recipe.Mappers {
+			var err error
+			if result.Recipes[i].Mappers[j], err = mapperConfig.withVariables(vars); err != nil {
+				return c, c.specError(fmt.Sprintf("recipes[%v].mappers[%v]", i, j), fmt.Errorf("Recipe '%v': Mapper[%v]: %w", recipe.Name, j, err))
+			}
+		}
+	}
+
+	for i, jsonPath := range c.Drop {
+		var err error
+		if result.Drop[i], err = substitutePathVariables(jsonPath, vars); err != nil {
+			return c, &SpecError{Location: c.location(fmt.Sprintf("drop[%v]", i)), Err: fmt.Errorf("Drop[%v]: %w", i, err)}
+		}
+	}
+
+	return result, nil
+}
+
+// ToMapOptions converts the configuration to the options the mappers should be applied with.
+func (c MappingConfig) ToMapOptions() MapOptions {
+	return MapOptions{Passthrough: c.Passthrough, Drop: c.Drop}
+}
+
+// recipeMappers instantiates the recipe the mapper configuration refers to.
+func (c MappingConfig) recipeMappers(mapperConfig MapperConfig) ([]Mapper, error) {
+	recipe, err := findRecipe(mapperConfig.Recipe, c.Recipes)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipe.ToMappers(mapperConfig.Params)
+}
+
+// LoadMappingConfig reads a mapping configuration of the provided format out of `r`.
+//
+// For JSON and YAML the locations of the entries of the configuration are recorded as well so that its errors
+// carry the line and the column of the offending entry.
+func LoadMappingConfig(r io.Reader, format Format) (MappingConfig, error) {
+	var config MappingConfig
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return config, err
+	}
+
+	data, err := Decode(bytes.NewReader(raw), format)
+	if err != nil {
+		return config, err
+	}
+
+	if err := unmarshalConfig(data, &config); err != nil {
+		return config, err
+	}
+	config.Locations = specLocations(raw, format)
+
+	return config, nil
+}
+
+// unmarshalConfig loads the decoded data in the provided config.
+func unmarshalConfig(data map[string]any, config any) error {
+	// the decoded data is marshalled back to JSON so the config can be loaded the same way regardless the format
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, config)
+}
+
+// LoadMappers reads a mapping configuration of the provided format out of `r` and converts it to a list of mappers.
+//
+// The configuration is expected to be of the following form (in JSON):
+//
+//	{
+//	  "mappers": [
+//	    {
+//	      "src": "$.store.books.summary",
+//	      "dst": "$.info.birthYears",
+//	      "transformations": [
+//	        {"type": "match", "regex": "\\d{4}"},
+//	        {"type": "number"}
+//	      ]
+//	    }
+//	  ]
+//	}
+func LoadMappers(r io.Reader, format Format) ([]Mapper, error) {
+	config, err := LoadMappingConfig(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.ToMappers()
+}
+
+// MappingSpecVersion is the current version of MappingSpec.
+const MappingSpecVersion = 1
+
+// MappingSpec is a versioned MappingConfig so that long-lived mapping configuration files can evolve.
+type MappingSpec struct {
+
+	// Version is the version of the spec. A missing version stands for version 1.
+	Version int `json:"version"`
+
+	MappingConfig
+}
+
+// MappingSpecLoader loads mapping specs upgrading the ones of older versions to its own version.
+type MappingSpecLoader struct {
+
+	// Version is the version of the loaded specs. Specs of newer versions are rejected. It defaults to MappingSpecVersion.
+	Version int
+
+	// Upgrades holds per version the function which upgrades the decoded spec of that version to the next one,
+	// i.e. by renaming transformer types. Specs of older versions without an upgrade path are rejected.
+	Upgrades map[int]func(spec map[string]any) error
+}
+
+// specVersion returns the version of the decoded spec.
+func specVersion(spec map[string]any) (int, error) {
+	value, ok := spec["version"]
+	if !ok || value == nil {
+		return 1, nil
+	}
+
+	version, err := gu.ToFloat64(value)
+	if err != nil || gu.IsString(value) || version != float64(int(version)) {
+		return 0, &Error{Code: ErrCodeUnsupportedVersion, Message: fmt.Sprintf("Invalid spec version '%v'", value)}
+	}
+
+	return int(version), nil
+}
+
+// Load reads a mapping spec of the provided format out of `r` and upgrades it to the version of the loader.
+//
+// As with LoadMappingConfig, the locations of the entries are recorded for JSON and YAML. They refer to the input as
+// it was before any upgrade.
+func (l MappingSpecLoader) Load(r io.Reader, format Format) (MappingSpec, error) {
+	var spec MappingSpec
+
+	targetVersion := l.Version
+	if targetVersion == 0 {
+		targetVersion = MappingSpecVersion
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return spec, err
+	}
+
+	data, err := Decode(bytes.NewReader(raw), format)
+	if err != nil {
+		return spec, err
+	}
+
+	version, err := specVersion(data)
+	if err != nil {
+		return spec, err
+	}
+
+	if version < 1 || version > targetVersion {
+		return spec, &Error{Code: ErrCodeUnsupportedVersion, Message: fmt.Sprintf("Unsupported spec version %v", version)}
+	}
+
+	for ; version < targetVersion; version++ {
+		upgrade, ok := l.Upgrades[version]
+		if !ok {
+			return spec, &Error{Code: ErrCodeUnsupportedVersion, Message: fmt.Sprintf("No upgrade of spec version %v", version)}
+		}
+
+		if err := upgrade(data); err != nil {
+			return spec, fmt.Errorf("Upgrade of spec version %v: %w", version, err)
+		}
+	}
+	data["version"] = targetVersion
+
+	if err := unmarshalConfig(data, &spec); err != nil {
+		return spec, err
+	}
+	spec.Locations = specLocations(raw, format)
+
+	return spec, nil
+}
+
+// LoadMappingSpec reads a mapping spec of the provided format out of `r`. Specs of a version other than
+// MappingSpecVersion are rejected.
+func LoadMappingSpec(r io.Reader, format Format) (MappingSpec, error) {
+	return MappingSpecLoader{}.Load(r, format)
+}
@@ -0,0 +1,270 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type LoadMappersTestCase struct {
+	input                string
+	format               Format
+	expectedMappers      []Mapper
+	expectedErrorMessage string
+}
+
+func TestLoadMappers(t *testing.T) {
+	testCases := []LoadMappersTestCase{
+		{
+			input: `{"mappers": [
+				{"src": "$.books.author", "dst": "$.authors", "dstType": "array"},
+				{"src": "$.books.summary", "dst": "$.years", "transformations": [
+					{"type": "match", "regex": "\\d{2}/\\d{2}/\\d{4}"},
+					{"type": "split", "delim": "/", "index": 2},
+					{"type": "number"},
+					{"type": "join", "delim": ",", "asArray": true},
+					{"type": "replace", "oldVal": ",", "newVal": ";"},
+					{"type": "substr", "start": 1, "end": 3}
+				]}
+			]}`,
+			format: FormatJSON,
+			expectedMappers: []Mapper{
+				{SrcJsonPath: "$.books.author", DstJsonPath: "$.authors", DstType: "array"},
+				{
+					SrcJsonPath: "$.books.summary",
+					DstJsonPath: "$.years",
+					Transformations: []Transformation{
+						{Trsnfmr: StringMatchTransformer{Regex: `\d{2}/\d{2}/\d{4}`}},
+						{Trsnfmr: SplitTransformer{Delim: "/", Index: 2}},
+						{Trsnfmr: NumberTransformer{}},
+						{Trsnfmr: JoinTransformer{Delim: ","}, AsArray: true},
+						{Trsnfmr: ReplaceTransformer{OldVal: ",", NewVal: ";"}},
+						{Trsnfmr: SubStrTransformer{Start: 1, End: 3}},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:  `{"mappers": [{"src": "$.headers", "dst": "$.headers", "keyTransformations": [{"type": "replace", "oldVal": "X-Vendor-", "newVal": ""}]}]}`,
+			format: FormatJSON,
+			expectedMappers: []Mapper{
+				{
+					SrcJsonPath:        "$.headers",
+					DstJsonPath:        "$.headers",
+					KeyTransformations: []Transformation{{Trsnfmr: ReplaceTransformer{OldVal: "X-Vendor-", NewVal: ""}}},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:  "mappers:\n  - src: $.books.author\n    dst: $.authors\n",
+			format: FormatYAML,
+			expectedMappers: []Mapper{
+				{SrcJsonPath: "$.books.author", DstJsonPath: "$.authors"},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			input:                `{"mappers": [{"src": "$.a", "dst": "$.b"}, {"src": "$.a", "dst": "$.b", "transformations": [{"type": "upper"}]}]}`,
+			format:               FormatJSON,
+			expectedMappers:      nil,
+			expectedErrorMessage: "Mapper[1]: Transformation[0]: Unknown transformer type 'upper'",
+		},
+		{
+			input:                `{"mappers": [{"src": "$.a", "dst": "$.b", "keyTransformations": [{"type": "lower"}]}]}`,
+			format:               FormatJSON,
+			expectedMappers:      nil,
+			expectedErrorMessage: "Mapper[0]: KeyTransformation[0]: Unknown transformer type 'lower'",
+		},
+		{
+			input:                `{"mappers": {}}`,
+			format:               FormatJSON,
+			expectedMappers:      nil,
+			expectedErrorMessage: "json: cannot unmarshal object into Go struct field MappingConfig.mappers of type []jsonmanu.MapperConfig",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - LoadMappers(%v)=%v", i, tc.input, tc.expectedErrorMessage), func(t *testing.T) {
+			mappers, err := LoadMappers(strings.NewReader(tc.input), tc.format)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedMappers, mappers) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedMappers, mappers)
+			}
+		})
+	}
+}
+
+type LoadMappingSpecTestCase struct {
+	input                string
+	loader               MappingSpecLoader
+	expectedSpec         MappingSpec
+	expectedErrorMessage string
+}
+
+func TestMappingSpecLoaderLoad(t *testing.T) {
+	renameToNumber := func(spec map[string]any) error {
+		for _, mapper := range spec["mappers"].([]any) {
+			for _, transformation := range mapper.(map[string]any)["transformations"].([]any) {
+				if transformation.(map[string]any)["type"] == "to_number" {
+					transformation.(map[string]any)["type"] = "number"
+				}
+			}
+		}
+		return nil
+	}
+
+	testCases := []LoadMappingSpecTestCase{
+		{
+			input: `{"mappers": [{"src": "$.a", "dst": "$.b"}]}`,
+			expectedSpec: MappingSpec{
+				Version:       1,
+				MappingConfig: MappingConfig{Mappers: []MapperConfig{{Src: "$.a", Dst: "$.b"}}},
+			},
+		},
+		{
+			input:                `{"version": 2, "mappers": []}`,
+			expectedErrorMessage: "Unsupported spec version 2",
+		},
+		{
+			input:                `{"version": "1", "mappers": []}`,
+			expectedErrorMessage: "Invalid spec version '1'",
+		},
+		{
+			input:  `{"version": 1, "mappers": [{"src": "$.a", "dst": "$.b", "transformations": [{"type": "to_number"}]}]}`,
+			loader: MappingSpecLoader{Version: 2, Upgrades: map[int]func(map[string]any) error{1: renameToNumber}},
+			expectedSpec: MappingSpec{
+				Version: 2,
+				MappingConfig: MappingConfig{Mappers: []MapperConfig{
+					{Src: "$.a", Dst: "$.b", Transformations: []TransformationConfig{{Type: "number"}}},
+				}},
+			},
+		},
+		{
+			input:                `{"version": 1, "mappers": []}`,
+			loader:               MappingSpecLoader{Version: 3, Upgrades: map[int]func(map[string]any) error{1: renameToNumber}},
+			expectedErrorMessage: "No upgrade of spec version 2",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Load(%v)=%v", i, tc.input, tc.expectedErrorMessage), func(t *testing.T) {
+			spec, err := tc.loader.Load(strings.NewReader(tc.input), FormatJSON)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			spec.Locations = nil
+			if err == nil && !cmp.Equal(tc.expectedSpec, spec) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedSpec, spec)
+			}
+		})
+	}
+}
+
+func TestLoadMappingSpecLocations(t *testing.T) {
+	input := "version: 1\nmappers:\n  - src: $.a\n    dst: $.b\n    transformations:\n      - type: unknown\n"
+
+	spec, err := LoadMappingSpec(strings.NewReader(input), FormatYAML)
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	_, err = spec.ToMappers()
+	var specErr *SpecError
+	if expected := (SpecLocation{Path: "mappers[0].transformations[0].type", Line: 6, Column: 9}); !errors.As(err, &specErr) || specErr.Location != expected {
+		t.Errorf("Expected '%v', but got '%#v'", expected, err)
+	}
+}
+
+func TestMappingConfigToMapOptions(t *testing.T) {
+	config, err := LoadMappingConfig(strings.NewReader(`{"mappers": [], "passthrough": true, "drop": ["$.internal"]}`), FormatJSON)
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%#v'", err)
+	}
+
+	expectedOpts := MapOptions{Passthrough: true, Drop: []string{"$.internal"}}
+	if opts := config.ToMapOptions(); !cmp.Equal(expectedOpts, opts) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedOpts, opts)
+	}
+}
+
+func TestMappingConfigWithVariables(t *testing.T) {
+	input := `
+mappers:
+  - src: $.tenants.${TENANT_ID}.config.name
+    dst: $.name
+    transformations:
+      - type: replace
+        oldVal: ${ENV}
+        newVal: prod
+  - recipe: copy
+    params: {field: "${FIELD}"}
+recipes:
+  - name: copy
+    mappers:
+      - src: $.{{field}}
+        dst: $.${TARGET}
+drop:
+  - $.${TENANT_ID}
+`
+	config, err := LoadMappingConfig(strings.NewReader(input), FormatYAML)
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%#v'", err)
+	}
+
+	vars := map[string]string{"TENANT_ID": "acme", "ENV": "staging", "FIELD": "id", "TARGET": "tenantId"}
+	resolved, err := config.WithVariables(vars)
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%#v'", err)
+	}
+
+	mappers, err := resolved.ToMappers()
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%#v'", err)
+	}
+	expectedMappers := []Mapper{
+		{
+			SrcJsonPath:     "$.tenants.acme.config.name",
+			DstJsonPath:     "$.name",
+			Transformations: []Transformation{{Trsnfmr: ReplaceTransformer{OldVal: "staging", NewVal: "prod"}}},
+		},
+		{SrcJsonPath: "$.id", DstJsonPath: "$.tenantId"},
+	}
+	if !cmp.Equal(expectedMappers, mappers) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedMappers, mappers)
+	}
+	if expectedDrop := []string{"$.acme"}; !cmp.Equal(expectedDrop, resolved.Drop) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedDrop, resolved.Drop)
+	}
+	if config.Mappers[0].Src != "$.tenants.${TENANT_ID}.config.name" || config.Mappers[0].Transformations[0].OldVal != "${ENV}" {
+		t.Errorf("Expected the configuration to be left untouched, but got '%#v'", config.Mappers[0])
+	}
+
+	delete(vars, "ENV")
+	_, err = config.WithVariables(vars)
+	if expected := "Mapper[0]: Missing variable 'ENV'"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+	}
+	var specErr *SpecError
+	if expected := (SpecLocation{Path: "mappers[0].transformations[0].oldVal", Line: 7, Column: 9}); !errors.As(err, &specErr) || specErr.Location != expected {
+		t.Errorf("Expected '%v', but got '%#v'", expected, err)
+	}
+
+	// the variables of paths cannot change what the paths select
+	vars["ENV"] = "staging, production"
+	for name, value := range map[string]string{"TENANT_ID": "*", "FIELD": "..secret", "TARGET": "a[?(@.b)]"} {
+		previous := vars[name]
+		vars[name] = value
+		_, err = config.WithVariables(vars)
+		if ErrorCode(err) != ErrCodeInvalidPath || !strings.Contains(err.Error(), fmt.Sprintf("Variable '%v' should consist of letters, digits and underscores to be used in a path", name)) {
+			t.Errorf("Expected an invalid path error for '%v', but got '%v'", name, err)
+		}
+		vars[name] = previous
+	}
+}
@@ -0,0 +1,185 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MarshalOptions holds the options of Marshal.
+type MarshalOptions struct {
+
+	// Indent is the indentation of each nesting level, i.e. two spaces or a tab. The JSON is compact if it is empty.
+	Indent string
+
+	// KeyOrder holds the keys which come first in every object, in this order, i.e. `$schema`, `name` and `version`.
+	// The rest of the keys follow sorted or in the order of PreserveOrder.
+	KeyOrder []string
+
+	// PreserveOrder is the order of the keys of the objects, i.e. the one returned by DecodeOrdered. The keys are
+	// sorted if it is nil.
+	PreserveOrder *KeyOrder
+
+	// EscapeHTML escapes the `<`, `>` and `&` characters of the strings as encoding/json does by default.
+	EscapeHTML bool
+
+	// TrailingNewline ends the JSON with a newline.
+	TrailingNewline bool
+
+	// Comments are written on their own lines before the members and the elements of their paths, i.e. the ones
+	// returned by DecodeJSONC, turning the JSON to JSONC. They are ignored if the JSON is compact.
+	Comments JSONComments
+}
+
+// Marshal serializes the data to JSON in the form described by the options so that documents edited with Put or Map
+// can be written back with minimal diffs against the original files.
+//
+// The keys of the objects are sorted, or kept in the order of PreserveOrder, apart from the ones listed in KeyOrder.
+func Marshal(data map[string]any, opts MarshalOptions) ([]byte, error) {
+	var buf bytes.Buffer
+
+	keyRanks := make(map[string]int, len(opts.KeyOrder))
+	for i, key := range opts.KeyOrder {
+		if _, ok := keyRanks[key]; !ok {
+			keyRanks[key] = i
+		}
+	}
+
+	m := marshaler{buf: &buf, opts: opts, keyRanks: keyRanks}
+	m.writeComments("$", 0)
+	if err := m.write(data, 0, "$"); err != nil {
+		return nil, err
+	}
+
+	if opts.TrailingNewline {
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
+
+// marshaler writes JSON to a buffer according to the options of Marshal.
+type marshaler struct {
+	buf      *bytes.Buffer
+	opts     MarshalOptions
+	keyRanks map[string]int
+}
+
+// newline starts a new line indented to the provided depth unless the JSON is compact.
+func (m marshaler) newline(depth int) {
+	if m.opts.Indent == "" {
+		return
+	}
+
+	m.buf.WriteByte('\n')
+	m.buf.WriteString(strings.Repeat(m.opts.Indent, depth))
+}
+
+// writeComments writes the comments of the path, each one on its own line indented to the provided depth.
+func (m marshaler) writeComments(path string, depth int) {
+	if m.opts.Indent == "" {
+		return
+	}
+
+	for _, comment := range m.opts.Comments[path] {
+		if depth > 0 {
+			m.newline(depth)
+		}
+		m.buf.WriteString(comment)
+		if depth == 0 {
+			m.buf.WriteByte('\n')
+		}
+	}
+}
+
+// orderedKeys returns the keys of the object in the order of the options.
+func (m marshaler) orderedKeys(object map[string]any) []string {
+	keys := m.opts.PreserveOrder.Keys(object)
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		ri, iRanked := m.keyRanks[keys[i]]
+		rj, jRanked := m.keyRanks[keys[j]]
+		if iRanked && jRanked {
+			return ri < rj
+		}
+		return iRanked && !jRanked
+	})
+
+	return keys
+}
+
+// write writes the value of the provided path at the provided depth.
+func (m marshaler) write(value any, depth int, path string) error {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			m.buf.WriteString("{}")
+			return nil
+		}
+
+		m.buf.WriteByte('{')
+		for i, key := range m.orderedKeys(v) {
+			if i > 0 {
+				m.buf.WriteByte(',')
+			}
+			m.writeComments(path+"."+key, depth+1)
+			m.newline(depth + 1)
+			if err := m.writeLeaf(key, depth+1); err != nil {
+				return err
+			}
+			m.buf.WriteByte(':')
+			if m.opts.Indent != "" {
+				m.buf.WriteByte(' ')
+			}
+			if err := m.write(v[key], depth+1, path+"."+key); err != nil {
+				return err
+			}
+		}
+		m.newline(depth)
+		m.buf.WriteByte('}')
+	case []any:
+		if len(v) == 0 {
+			m.buf.WriteString("[]")
+			return nil
+		}
+
+		m.buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				m.buf.WriteByte(',')
+			}
+			itemPath := fmt.Sprintf("%v[%v]", path, i)
+			m.writeComments(itemPath, depth+1)
+			m.newline(depth + 1)
+			if err := m.write(item, depth+1, itemPath); err != nil {
+				return err
+			}
+		}
+		m.newline(depth)
+		m.buf.WriteByte(']')
+	default:
+		return m.writeLeaf(v, depth)
+	}
+
+	return nil
+}
+
+// writeLeaf writes a value other than the generic objects and arrays with encoding/json.
+func (m marshaler) writeLeaf(value any, depth int) error {
+	var leaf bytes.Buffer
+
+	encoder := json.NewEncoder(&leaf)
+	encoder.SetEscapeHTML(m.opts.EscapeHTML)
+	if m.opts.Indent != "" {
+		encoder.SetIndent(strings.Repeat(m.opts.Indent, depth), m.opts.Indent)
+	}
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+
+	m.buf.Write(bytes.TrimSuffix(leaf.Bytes(), []byte("\n")))
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+type MarshalTestCase struct {
+	opts                 MarshalOptions
+	expectedResult       string
+	expectedErrorMessage string
+}
+
+func TestMarshal(t *testing.T) {
+	data := map[string]any{
+		"version": 2,
+		"name":    "<shop>",
+		"books":   []any{map[string]any{"title": "Book1", "price": 15.5}, []any{}},
+		"meta":    map[string]any{},
+		"$schema": "schema.json",
+	}
+
+	testCases := []MarshalTestCase{
+		{
+			opts:           MarshalOptions{},
+			expectedResult: `{"$schema":"schema.json","books":[{"price":15.5,"title":"Book1"},[]],"meta":{},"name":"<shop>","version":2}`,
+		},
+		{
+			opts:           MarshalOptions{EscapeHTML: true, TrailingNewline: true},
+			expectedResult: `{"$schema":"schema.json","books":[{"price":15.5,"title":"Book1"},[]],"meta":{},"name":"\u003cshop\u003e","version":2}` + "\n",
+		},
+		{
+			opts: MarshalOptions{Indent: "  ", KeyOrder: []string{"name", "version", "title"}},
+			expectedResult: `{
+  "name": "<shop>",
+  "version": 2,
+  "$schema": "schema.json",
+  "books": [
+    {
+      "title": "Book1",
+      "price": 15.5
+    },
+    []
+  ],
+  "meta": {}
+}`,
+		},
+		{
+			opts:           MarshalOptions{Indent: "\t", TrailingNewline: true, KeyOrder: []string{"books"}},
+			expectedResult: "{\n\t\"books\": [\n\t\t{\n\t\t\t\"price\": 15.5,\n\t\t\t\"title\": \"Book1\"\n\t\t},\n\t\t[]\n\t],\n\t\"$schema\": \"schema.json\",\n\t\"meta\": {},\n\t\"name\": \"<shop>\",\n\t\"version\": 2\n}\n",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Marshal(%+v)", i, tc.opts), func(t *testing.T) {
+			result, err := Marshal(data, tc.opts)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if string(result) != tc.expectedResult {
+				t.Errorf("Expected '%v', but got '%s'", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestMarshalLeaves(t *testing.T) {
+	type book struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	}
+
+	result, err := Marshal(map[string]any{"book": book{Title: "Book1", Tags: []string{"a"}}}, MarshalOptions{Indent: "  "})
+	expected := "{\n  \"book\": {\n    \"title\": \"Book1\",\n    \"tags\": [\n      \"a\"\n    ]\n  }\n}"
+	if err != nil || string(result) != expected {
+		t.Errorf("Expected '%v', but got '%s' (%v)", expected, result, err)
+	}
+
+	if _, err := Marshal(map[string]any{"a": math.NaN()}, MarshalOptions{}); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
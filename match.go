@@ -0,0 +1,254 @@
+package jsonmanu
+
+import (
+	"fmt"
+)
+
+// match is a value matched by a JSONPath along with its location in the data so that it can be replaced.
+type match struct {
+
+	// path is the concrete JSONPath of the value in dot notation, i.e. `$.books[0].title`.
+	path string
+
+	// value is the matched value.
+	value any
+
+	// parent is the object or the array holding the value. It is nil for the root.
+	parent any
+
+	// key is the key of the value if the parent is an object.
+	key string
+
+	// index is the index of the value if the parent is an array.
+	index int
+
+	// location holds the keys and the indices leading from the root to the value.
+	location []any
+}
+
+// at returns the location of the match extended by the provided keys or indices.
+func (m match) at(steps ...any) []any {
+	location := make([]any, 0, len(m.location)+len(steps))
+
+	return append(append(location, m.location...), steps...)
+}
+
+// set replaces the matched value in its parent. The root cannot be replaced.
+func (m match) set(value any) {
+	switch parent := m.parent.(type) {
+	case map[string]any:
+		parent[m.key] = value
+	case []any:
+		parent[m.index] = value
+	}
+}
+
+// childMatch returns the match of the value found under the provided key of an object.
+func childMatch(parent match, obj map[string]any, key string) match {
+	return match{path: parent.path + "." + key, value: obj[key], parent: obj, key: key, location: parent.at(key)}
+}
+
+// elementMatches returns the matches of the elements of the array found under the name of an array node, filtered
+// according to the node.
+func elementMatches(parent match, obj map[string]any, n nodeDataAccessor) []match {
+	items, ok := obj[n.getName()].([]any)
+	if !ok {
+		return nil
+	}
+
+	var indices []int
+	switch an := n.(type) {
+	case arrayIndexedNode:
+		for _, i := range an.indices {
+			if i >= 0 && i < len(items) {
+				indices = append(indices, i)
+			}
+		}
+		if len(an.indices) > 0 {
+			break
+		}
+		for i := range items {
+			indices = append(indices, i)
+		}
+	case arraySlicedNode:
+		start, end := an.bounds(len(items))
+		for i := start; i < end; i++ {
+			indices = append(indices, i)
+		}
+	case arrayFilteredNode:
+		for i, item := range items {
+			itemMap, _ := item.(map[string]any)
+			value, ok := itemMap[an.key]
+			if ok && an.satisfiedBy(itemMap, value) {
+				indices = append(indices, i)
+			}
+		}
+	}
+
+	var matches []match
+	for _, i := range indices {
+		path := fmt.Sprintf("%v.%v[%v]", parent.path, n.getName(), i)
+		matches = append(matches, match{path: path, value: items[i], parent: items, index: i, location: parent.at(n.getName(), i)})
+	}
+
+	return matches
+}
+
+// nodeMatches applies the node on an object.
+func nodeMatches(parent match, obj map[string]any, n nodeDataAccessor) []match {
+	if isArrayNode(n) {
+		return elementMatches(parent, obj, n)
+	}
+
+	if _, ok := obj[n.getName()]; !ok {
+		return nil
+	}
+
+	return []match{childMatch(parent, obj, n.getName())}
+}
+
+// descendantMatches applies the node on all the objects nested in the matched value, as it happens after a recursive
+// descent. The keys are visited in lexicographical order.
+func descendantMatches(m match, n nodeDataAccessor) []match {
+	var matches []match
+
+	switch v := m.value.(type) {
+	case map[string]any:
+		matches = append(matches, nodeMatches(m, v, n)...)
+		for _, key := range sortedKeys(v) {
+			matches = append(matches, descendantMatches(childMatch(m, v, key), n)...)
+		}
+	case []any:
+		for i, item := range v {
+			itemMatch := match{path: fmt.Sprintf("%v[%v]", m.path, i), value: item, parent: v, index: i, location: m.at(i)}
+			matches = append(matches, descendantMatches(itemMatch, n)...)
+		}
+	}
+
+	return matches
+}
+
+// visitMatches applies the nodes on the match depth-first and reports the matches of the last node to the visit
+// function in the same order findMatches returns them. It stops as soon as the visit function returns false, in
+// which case it returns false as well.
+func visitMatches(m match, nodes []nodeDataAccessor, recursive bool, visit func(match) bool) bool {
+	if len(nodes) == 0 {
+		return visit(m)
+	}
+
+	n := nodes[0]
+	if n.getName() == "*" {
+		return visitMatches(m, nodes[1:], recursive, visit)
+	}
+
+	if n.getName() == "" {
+		return visitMatches(m, nodes[1:], true, visit)
+	}
+
+	var next []match
+	if recursive {
+		next = descendantMatches(m, n)
+	} else {
+		switch v := m.value.(type) {
+		case map[string]any:
+			next = nodeMatches(m, v, n)
+		case []any:
+			// the node applies on each element as it happens with Get
+			for i, item := range v {
+				if itemMap, ok := item.(map[string]any); ok {
+					itemMatch := match{path: fmt.Sprintf("%v[%v]", m.path, i), value: item, parent: v, index: i, location: m.at(i)}
+					next = append(next, nodeMatches(itemMatch, itemMap, n)...)
+				}
+			}
+		}
+	}
+
+	for _, nextMatch := range next {
+		if !visitMatches(nextMatch, nodes[1:], false, visit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// visitValues applies the nodes on the value depth-first and reports the values of the last node to the visit
+// function in the order Get returns them. As with Get, a recursive descent does not search any further the objects
+// having the key, the values it finds are flattened and an array node following it applies on all of them at once,
+// i.e. `$..books[0]` is the first of all the books found. Unlike Get, missing keys and array elements that are not
+// objects are skipped instead of causing an error.
+//
+// It stops as soon as the visit function returns false, in which case it returns false as well.
+func visitValues(value any, nodes []nodeDataAccessor, recursive bool, visit func(any) bool) bool {
+	if len(nodes) == 0 {
+		return visit(value)
+	}
+
+	n := nodes[0]
+	switch {
+	case n.getName() == "*":
+		return visitValues(value, nodes[1:], recursive, visit)
+	case n.getName() == "":
+		return visitValues(value, nodes[1:], true, visit)
+	case recursive && isArrayNode(n):
+		found := deepSearch(value, n.getName(), collectFlattened, nil)
+		selected, _ := n.get(map[string]any{n.getName(): found})
+		items, _ := selected.([]any)
+		for _, item := range items {
+			if !visitValues(item, nodes[1:], false, visit) {
+				return false
+			}
+		}
+		return true
+	case recursive:
+		return visitDeep(value, n.getName(), func(found any) bool {
+			return visitValues(found, nodes[1:], false, visit)
+		})
+	}
+
+	var next []match
+	switch v := value.(type) {
+	case map[string]any:
+		next = nodeMatches(match{}, v, n)
+	case []any:
+		// the node applies on each element as it happens with Get
+		for _, item := range v {
+			if itemMap, ok := item.(map[string]any); ok {
+				next = append(next, nodeMatches(match{}, itemMap, n)...)
+			}
+		}
+	}
+
+	for _, nextMatch := range next {
+		if !visitValues(nextMatch.value, nodes[1:], false, visit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findMatches returns all the values matched by the JSONPath along with their locations so that they can be replaced
+// one by one, whereas Put puts the same value in all of them.
+//
+// Unlike Get, missing keys and array elements that are not objects are skipped instead of causing an error.
+func findMatches(data map[string]any, jsonPath string) ([]match, error) {
+	return findMatchesLimit(data, jsonPath, 0)
+}
+
+// findMatchesLimit works like findMatches but it stops the traversal once the limit of matches is reached.
+// A non positive limit means no limit.
+func findMatchesLimit(data map[string]any, jsonPath string, limit int) ([]match, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []match
+	visitMatches(match{path: "$", value: data}, nodes, false, func(m match) bool {
+		matches = append(matches, m)
+		return limit <= 0 || len(matches) < limit
+	})
+
+	return matches, nil
+}
@@ -0,0 +1,84 @@
+package jsonmanu
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics is the interface used by the library to report the executed operations so that they can be exported
+// to a monitoring system.
+type Metrics interface {
+
+	// QueryExecuted is called after each Get, Put, Modify or Delete call. The operation is one of `get`, `put`,
+	// `modify` and `delete`.
+	QueryExecuted(operation string, duration time.Duration, err error)
+
+	// QueryCacheAccessed is called after each Document.Get looked up in the query cache (see EnableQueryCache). Hit is
+	// whether the result, along with the parsed JSONPath, was already memoized.
+	QueryCacheAccessed(hit bool)
+
+	// MapperExecuted is called after each mapper handled by Map.
+	MapperExecuted(index int, duration time.Duration, err error)
+
+	// TransformationExecuted is called after each transformation applied by a mapper. The transformer is the type
+	// name of the applied transformer, i.e. `jsonmanu.SplitTransformer`.
+	TransformationExecuted(transformer string, duration time.Duration, err error)
+}
+
+// noopMetrics discards all the metrics.
+type noopMetrics struct{}
+
+func (noopMetrics) QueryExecuted(operation string, duration time.Duration, err error) {}
+
+func (noopMetrics) QueryCacheAccessed(hit bool) {}
+
+func (noopMetrics) MapperExecuted(index int, duration time.Duration, err error) {}
+
+func (noopMetrics) TransformationExecuted(transformer string, duration time.Duration, err error) {}
+
+// metricsBox wraps a Metrics since an atomic.Value holds values of a single concrete type.
+type metricsBox struct{ Metrics }
+
+// swappableMetrics forwards the metrics to the Metrics set last so that it can be replaced while in use.
+type swappableMetrics struct {
+	current atomic.Value
+}
+
+// get returns the Metrics set last.
+func (m *swappableMetrics) get() Metrics {
+	if box, ok := m.current.Load().(metricsBox); ok {
+		return box.Metrics
+	}
+
+	return noopMetrics{}
+}
+
+func (m *swappableMetrics) QueryExecuted(operation string, duration time.Duration, err error) {
+	m.get().QueryExecuted(operation, duration, err)
+}
+
+func (m *swappableMetrics) QueryCacheAccessed(hit bool) {
+	m.get().QueryCacheAccessed(hit)
+}
+
+func (m *swappableMetrics) MapperExecuted(index int, duration time.Duration, err error) {
+	m.get().MapperExecuted(index, duration, err)
+}
+
+func (m *swappableMetrics) TransformationExecuted(transformer string, duration time.Duration, err error) {
+	m.get().TransformationExecuted(transformer, duration, err)
+}
+
+// metrics is the Metrics used by the library.
+var metrics = &swappableMetrics{}
+
+// SetMetrics sets the Metrics used by the library. Passing nil disables the metrics which is the default.
+//
+// Operations which are already running may still report to the previous Metrics.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		m = noopMetrics{}
+	}
+
+	metrics.current.Store(metricsBox{m})
+}
@@ -0,0 +1,93 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordingMetrics keeps the reported metrics without their durations.
+type recordingMetrics struct {
+	events []string
+}
+
+func (m *recordingMetrics) QueryExecuted(operation string, duration time.Duration, err error) {
+	m.events = append(m.events, fmt.Sprintf("query %v %v", operation, err))
+}
+
+func (m *recordingMetrics) QueryCacheAccessed(hit bool) {
+	m.events = append(m.events, fmt.Sprintf("cache %v", hit))
+}
+
+func (m *recordingMetrics) MapperExecuted(index int, duration time.Duration, err error) {
+	m.events = append(m.events, fmt.Sprintf("mapper %v %v", index, err))
+}
+
+func (m *recordingMetrics) TransformationExecuted(transformer string, duration time.Duration, err error) {
+	m.events = append(m.events, fmt.Sprintf("transformation %v %v", transformer, err))
+}
+
+func TestMetrics(t *testing.T) {
+	m := &recordingMetrics{}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	data := map[string]any{"book": map[string]any{"price": "15", "author": "Nietzsche"}}
+
+	Get(data, "$.book.price")
+	Delete(data, "$.book.isbn")
+	Map(data, map[string]any{}, []Mapper{
+		{
+			SrcJsonPath:     "$.book.price",
+			DstJsonPath:     "$.price",
+			Transformations: []Transformation{{Trsnfmr: NumberTransformer{}}},
+		},
+		{
+			SrcJsonPath:     "$.book.author",
+			DstJsonPath:     "$.author",
+			Transformations: []Transformation{{Trsnfmr: NumberTransformer{}}},
+		},
+	})
+
+	expectedEvents := []string{
+		"query get <nil>",
+		"query delete <nil>",
+		"query get <nil>",
+		"transformation jsonmanu.NumberTransformer <nil>",
+		"query put <nil>",
+		"mapper 0 <nil>",
+		"query get <nil>",
+		"transformation jsonmanu.NumberTransformer Couldn't convert value to number.",
+		"mapper 1 Transformation[0] (jsonmanu.NumberTransformer): Couldn't convert value to number.",
+	}
+	if !cmp.Equal(expectedEvents, m.events) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedEvents, m.events)
+	}
+}
+
+func TestMetricsModifyAndQueryCache(t *testing.T) {
+	m := &recordingMetrics{}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	doc := NewDocument(map[string]any{"book": map[string]any{"price": 15}})
+	doc.EnableQueryCache()
+
+	doc.Get("$.book.price")
+	doc.Get("$.book.price")
+	Modify(doc.Data(), "$.book.price", func(old any) (any, error) { return 20, nil })
+	PutFunc(doc.Data(), "$.book.price", func(old any) any { return 25 })
+
+	expectedEvents := []string{
+		"cache false",
+		"query get <nil>",
+		"cache true",
+		"query modify <nil>",
+		"query modify <nil>",
+	}
+	if !cmp.Equal(expectedEvents, m.events) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedEvents, m.events)
+	}
+}
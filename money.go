@@ -0,0 +1,322 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// The outputs of MoneyTransformer.
+const (
+	MoneyOutputObject     = "object"
+	MoneyOutputMinorUnits = "minorUnits"
+)
+
+// currencySymbols maps the recognized currency symbols to their ISO 4217 codes.
+var currencySymbols = map[string]string{
+	"$": "USD",
+	"€": "EUR",
+	"£": "GBP",
+	"¥": "JPY",
+	"₹": "INR",
+	"₩": "KRW",
+	"₽": "RUB",
+}
+
+// currencyDecimals holds the number of the decimals of the currencies which do not have 2 of them.
+var currencyDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"ISK": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"JOD": 3,
+	"OMR": 3,
+	"TND": 3,
+}
+
+// minorDigits returns the number of the decimals of the currency.
+func minorDigits(currency string) int {
+	if digits, ok := currencyDecimals[currency]; ok {
+		return digits
+	}
+
+	return 2
+}
+
+// currencySymbol returns the symbol of the currency or an empty string if it has none.
+func currencySymbol(currency string) string {
+	for symbol, code := range currencySymbols {
+		if code == currency {
+			return symbol
+		}
+	}
+
+	return ""
+}
+
+// parseCurrency returns the ISO 4217 code of a currency symbol or code.
+func parseCurrency(s string) (string, error) {
+	if code, ok := currencySymbols[s]; ok {
+		return code, nil
+	}
+
+	if len(s) == 3 && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) || r > unicode.MaxASCII }) < 0 {
+		return strings.ToUpper(s), nil
+	}
+
+	return "", fmt.Errorf("Unknown currency '%v'.", s)
+}
+
+// parseMinorUnits converts a decimal amount, i.e. `-1,234.5`, to an integer of minor units with the provided number
+// of decimals without going through floats. The separator other than the decimal one, spaces and apostrophes are
+// taken as thousands separators of the integer part and they must group its digits by 3, so that amounts with the
+// separators of another locale, i.e. `12,99` for `.` as the decimal separator, are rejected instead of guessed.
+func parseMinorUnits(amount string, decimalSep string, digits int) (int64, error) {
+	thousandsSep := ","
+	if decimalSep == "," {
+		thousandsSep = "."
+	}
+
+	negative := strings.HasPrefix(amount, "-")
+	parts := strings.Split(strings.TrimPrefix(amount, "-"), decimalSep)
+	if len(parts) > 2 {
+		return 0, fmt.Errorf("Couldn't convert '%v' to amount.", amount)
+	}
+
+	groups := strings.FieldsFunc(parts[0], func(r rune) bool { return strings.ContainsRune(thousandsSep+" '", r) })
+	if len(groups) > 1 {
+		if len(strings.Join(groups, "")) != len(parts[0])-len(groups)+1 || len(groups[0]) > 3 {
+			return 0, fmt.Errorf("Amount '%v' has misplaced thousands separators.", amount)
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return 0, fmt.Errorf("Amount '%v' has misplaced thousands separators.", amount)
+			}
+		}
+		parts[0] = strings.Join(groups, "")
+	}
+
+	if parts[0] == "" || strings.IndexFunc(strings.Join(parts, ""), func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return 0, fmt.Errorf("Couldn't convert '%v' to amount.", amount)
+	}
+
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+	if len(fraction) > digits {
+		return 0, fmt.Errorf("Amount '%v' has more than %v decimals.", amount, digits)
+	}
+
+	minor, err := strconv.ParseInt(parts[0]+fraction+strings.Repeat("0", digits-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Amount '%v' is out of range.", amount)
+	}
+
+	if negative {
+		minor = -minor
+	}
+
+	return minor, nil
+}
+
+// formatMinorUnits converts an integer of minor units to a decimal amount with the provided number of decimals,
+// grouping the thousands with the provided separator if any.
+func formatMinorUnits(minor int64, digits int, decimalSep string, thousandsSep string) string {
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+
+	s := fmt.Sprintf("%0*d", digits+1, minor)
+	integer, fraction := s[:len(s)-digits], s[len(s)-digits:]
+
+	if thousandsSep != "" {
+		var groups []string
+		for len(integer) > 3 {
+			groups = append([]string{integer[len(integer)-3:]}, groups...)
+			integer = integer[:len(integer)-3]
+		}
+		integer = strings.Join(append([]string{integer}, groups...), thousandsSep)
+	}
+
+	if digits == 0 {
+		return sign + integer
+	}
+
+	return sign + integer + decimalSep + fraction
+}
+
+// MoneyTransformer parses amounts with currency symbols or codes, i.e. `$1,234.50` or `12,99 EUR`, to an object of
+// the amount and the currency, i.e. `{"amount": "1234.50", "currency": "USD"}`, or to an integer of minor units, i.e.
+// `123450`. The amounts are never converted to floats so that they are not subject to rounding errors.
+type MoneyTransformer struct {
+
+	// Output is the form of the result. It can be one of MoneyOutputObject and MoneyOutputMinorUnits and it
+	// defaults to MoneyOutputObject.
+	Output string
+
+	// Currency is the ISO 4217 code of the currency of the amounts without a symbol or a code.
+	Currency string
+
+	// DecimalSep is the decimal separator of the amounts. It defaults to `.` and it can be `,` in which case `.`
+	// separates the thousands. Amounts whose thousands separators do not group the digits by 3 are rejected.
+	DecimalSep string
+}
+
+// MoneyTransformer Transform applies the money transformation.
+//
+// It expects a string or a number value. The currency may precede or follow the amount and the number of the
+// decimals must not exceed the ones of the currency, i.e. 2 for USD and 0 for JPY.
+func (t MoneyTransformer) Transform(value any) (any, error) {
+	if t.Output != "" && t.Output != MoneyOutputObject && t.Output != MoneyOutputMinorUnits {
+		return nil, fmt.Errorf("Unknown money output '%v'.", t.Output)
+	}
+
+	decimalSep := t.DecimalSep
+	if decimalSep == "" {
+		decimalSep = "."
+	}
+
+	var s string
+	switch {
+	case gu.IsString(value):
+		s = strings.TrimSpace(value.(string))
+	case isNumber(value):
+		f, _ := gu.ToFloat64(value)
+		s = strings.Replace(strconv.FormatFloat(f, 'f', -1, 64), ".", decimalSep, 1)
+	default:
+		return nil, errors.New("Value is not a string or a number.")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimSpace(strings.TrimPrefix(s, "-"))
+
+	start := strings.IndexFunc(s, func(r rune) bool { return unicode.IsDigit(r) || r == '-' })
+	end := strings.LastIndexFunc(s, unicode.IsDigit)
+	if start < 0 || end < 0 {
+		return nil, fmt.Errorf("Couldn't convert '%v' to amount.", value)
+	}
+
+	amount := s[start : end+1]
+	if strings.HasPrefix(amount, "-") {
+		if negative {
+			return nil, fmt.Errorf("Couldn't convert '%v' to amount.", value)
+		}
+		negative, amount = true, strings.TrimSpace(amount[1:])
+	}
+
+	prefix, suffix := strings.TrimSpace(s[:start]), strings.TrimSpace(s[end+1:])
+	if prefix != "" && suffix != "" {
+		return nil, fmt.Errorf("Couldn't convert '%v' to amount.", value)
+	}
+
+	currency := t.Currency
+	if prefix+suffix != "" {
+		var err error
+		if currency, err = parseCurrency(prefix + suffix); err != nil {
+			return nil, err
+		}
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("Amount '%v' has no currency.", value)
+	}
+
+	digits := minorDigits(currency)
+	minor, err := parseMinorUnits(amount, decimalSep, digits)
+	if err != nil {
+		return nil, err
+	}
+	if negative {
+		minor = -minor
+	}
+
+	if t.Output == MoneyOutputMinorUnits {
+		return minor, nil
+	}
+
+	return map[string]any{"amount": formatMinorUnits(minor, digits, ".", ""), "currency": currency}, nil
+}
+
+// MoneyFormatTransformer formats amounts as strings, i.e. `$1,234.50` or `1,234.50 CHF`, which is the reverse of
+// MoneyTransformer.
+type MoneyFormatTransformer struct {
+
+	// Currency is the ISO 4217 code of the currency of the amounts given in minor units.
+	Currency string
+
+	// UseSymbol puts the symbol of the currency, if it has one, in front of the amount instead of its code after it.
+	UseSymbol bool
+
+	// DecimalSep is the decimal separator of the result. It defaults to `.`.
+	DecimalSep string
+
+	// ThousandsSep is the thousands separator of the result. The thousands are not separated if it is empty.
+	ThousandsSep string
+}
+
+// MoneyFormatTransformer Transform applies the money format transformation.
+//
+// It expects either an object of the amount and the currency as returned by MoneyTransformer, where the amount may
+// also be a number, or an integer of minor units of the Currency.
+func (t MoneyFormatTransformer) Transform(value any) (any, error) {
+	decimalSep := t.DecimalSep
+	if decimalSep == "" {
+		decimalSep = "."
+	}
+
+	var minor int64
+	var currency string
+	switch {
+	case gu.IsMap(value):
+		valueMap := value.(map[string]any)
+		currencyValue, ok := valueMap["currency"].(string)
+		if !ok {
+			return nil, errors.New("Value has no currency.")
+		}
+		var err error
+		if currency, err = parseCurrency(currencyValue); err != nil {
+			return nil, err
+		}
+
+		amount := valueMap["amount"]
+		if isNumber(amount) {
+			f, _ := gu.ToFloat64(amount)
+			amount = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		amountString, ok := amount.(string)
+		if !ok {
+			return nil, errors.New("Value has no amount.")
+		}
+		if minor, err = parseMinorUnits(amountString, ".", minorDigits(currency)); err != nil {
+			return nil, err
+		}
+	case isNumber(value):
+		f, _ := gu.ToFloat64(value)
+		if f != float64(int64(f)) {
+			return nil, errors.New("Minor units must be an integer.")
+		}
+		if t.Currency == "" {
+			return nil, errors.New("Currency is required for minor units.")
+		}
+		minor, currency = int64(f), t.Currency
+	default:
+		return nil, errors.New("Value is not an object or a number.")
+	}
+
+	amount := formatMinorUnits(minor, minorDigits(currency), decimalSep, t.ThousandsSep)
+
+	if symbol := currencySymbol(currency); t.UseSymbol && symbol != "" {
+		if strings.HasPrefix(amount, "-") {
+			return "-" + symbol + amount[1:], nil
+		}
+		return symbol + amount, nil
+	}
+
+	return amount + " " + currency, nil
+}
@@ -0,0 +1,200 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMoneyTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              MoneyTransformer{},
+			value:                    "$1,234.5",
+			expectedTransformedValue: map[string]any{"amount": "1234.50", "currency": "USD"},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{DecimalSep: ","},
+			value:                    "-1.234,56 €",
+			expectedTransformedValue: map[string]any{"amount": "-1234.56", "currency": "EUR"},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{DecimalSep: ","},
+			value:                    "12,99 EUR",
+			expectedTransformedValue: map[string]any{"amount": "12.99", "currency": "EUR"},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{DecimalSep: ","},
+			value:                    "1.234.567 EUR",
+			expectedTransformedValue: map[string]any{"amount": "1234567.00", "currency": "EUR"},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{DecimalSep: ","},
+			value:                    "1.5 EUR",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Amount '1.5' has misplaced thousands separators.",
+		},
+		{
+			transformer:              MoneyTransformer{},
+			value:                    "12,99 EUR",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Amount '12,99' has misplaced thousands separators.",
+		},
+		{
+			transformer:              MoneyTransformer{},
+			value:                    "1 234'567.5 USD",
+			expectedTransformedValue: map[string]any{"amount": "1234567.50", "currency": "USD"},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{},
+			value:                    "$1,2345.00",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Amount '1,2345.00' has misplaced thousands separators.",
+		},
+		{
+			transformer:              MoneyTransformer{},
+			value:                    "$1,234.5,0",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '1,234.5,0' to amount.",
+		},
+		{
+			transformer:              MoneyTransformer{Output: MoneyOutputMinorUnits},
+			value:                    "chf 0.1",
+			expectedTransformedValue: int64(10),
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{Output: MoneyOutputMinorUnits},
+			value:                    "¥1,200",
+			expectedTransformedValue: int64(1200),
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{Output: MoneyOutputMinorUnits, Currency: "USD"},
+			value:                    19.99,
+			expectedTransformedValue: int64(1999),
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyTransformer{Currency: "KWD"},
+			value:                    "$-0.125",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Amount '0.125' has more than 2 decimals.",
+		},
+		{
+			transformer:              MoneyTransformer{},
+			value:                    "12.50",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Amount '12.50' has no currency.",
+		},
+		{
+			transformer:              MoneyTransformer{},
+			value:                    "12 dollars",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Unknown currency 'dollars'.",
+		},
+		{
+			transformer:              MoneyTransformer{Currency: "USD"},
+			value:                    "1.2.3",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert '1.2.3' to amount.",
+		},
+		{
+			transformer:              MoneyTransformer{Output: "float"},
+			value:                    "$1",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Unknown money output 'float'.",
+		},
+		{
+			transformer:              MoneyTransformer{},
+			value:                    true,
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not a string or a number.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("MoneyTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestMoneyFormatTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              MoneyFormatTransformer{ThousandsSep: ",", UseSymbol: true},
+			value:                    map[string]any{"amount": "-1234567.5", "currency": "USD"},
+			expectedTransformedValue: "-$1,234,567.50",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyFormatTransformer{DecimalSep: ",", ThousandsSep: "."},
+			value:                    map[string]any{"amount": 1234.5, "currency": "EUR"},
+			expectedTransformedValue: "1.234,50 EUR",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyFormatTransformer{Currency: "JPY", UseSymbol: true},
+			value:                    1200,
+			expectedTransformedValue: "¥1200",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyFormatTransformer{Currency: "CHF", UseSymbol: true},
+			value:                    int64(5),
+			expectedTransformedValue: "0.05 CHF",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              MoneyFormatTransformer{},
+			value:                    1200,
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Currency is required for minor units.",
+		},
+		{
+			transformer:              MoneyFormatTransformer{},
+			value:                    map[string]any{"amount": "12"},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value has no currency.",
+		},
+		{
+			transformer:              MoneyFormatTransformer{},
+			value:                    "$12",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an object or a number.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("MoneyFormatTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package jsonmanu
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ReadNDJSON returns a sequence of the objects found in the newline delimited JSON data read out of `r`.
+//
+// The returned function has the signature of `iter.Seq2[map[string]any, error]` so it can be ranged over
+// with Go 1.23 or later. Blank lines are skipped. A line that cannot be decoded yields an error and the
+// iteration continues with the next line unless the consumer stops it. A read error ends the iteration.
+func ReadNDJSON(r io.Reader) func(yield func(map[string]any, error) bool) {
+	return func(yield func(map[string]any, error) bool) {
+		reader := bufio.NewReader(r)
+
+		for lineNumber := 1; ; lineNumber++ {
+			line, readErr := reader.ReadBytes('\n')
+			if readErr != nil && readErr != io.EOF {
+				yield(nil, fmt.Errorf("Line %v: %v", lineNumber, readErr))
+				return
+			}
+
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				var data map[string]any
+				err := json.Unmarshal(line, &data)
+				if err != nil {
+					err = fmt.Errorf("Line %v: %w", lineNumber, err)
+				}
+
+				if !yield(data, err) {
+					return
+				}
+			}
+
+			if readErr == io.EOF {
+				return
+			}
+		}
+	}
+}
+
+// WriteNDJSON writes the objects of the provided sequence to `w` as newline delimited JSON.
+//
+// The sequence has the signature of `iter.Seq2[map[string]any, error]` so the result of ReadNDJSON can be
+// passed directly. Writing stops at the first error, either yielded by the sequence or occured while writing.
+func WriteNDJSON(w io.Writer, seq func(yield func(map[string]any, error) bool)) (err error) {
+	encoder := json.NewEncoder(w)
+
+	seq(func(data map[string]any, seqErr error) bool {
+		if seqErr != nil {
+			err = seqErr
+			return false
+		}
+
+		err = encoder.Encode(data)
+
+		return err == nil
+	})
+
+	return err
+}
+
+// SliceSeq returns a sequence of the provided objects which can be passed to WriteNDJSON.
+func SliceSeq(items []map[string]any) func(yield func(map[string]any, error) bool) {
+	return func(yield func(map[string]any, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
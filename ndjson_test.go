@@ -0,0 +1,107 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type ReadNDJSONTestCase struct {
+	input                 string
+	expectedItems         []map[string]any
+	expectedErrorMessages []string
+}
+
+func TestReadNDJSON(t *testing.T) {
+	testCases := []ReadNDJSONTestCase{
+		{
+			input: "{\"author\": \"Nietzsche\"}\n\n{\"author\": \"Stirner\"}",
+			expectedItems: []map[string]any{
+				{"author": "Nietzsche"},
+				{"author": "Stirner"},
+			},
+			expectedErrorMessages: nil,
+		},
+		{
+			input: "{\"author\": \"Nietzsche\"}\n{\"author\": \n{\"author\": \"Stirner\"}\n",
+			expectedItems: []map[string]any{
+				{"author": "Nietzsche"},
+				{"author": "Stirner"},
+			},
+			expectedErrorMessages: []string{"Line 2: unexpected end of JSON input"},
+		},
+		{
+			input:                 "",
+			expectedItems:         nil,
+			expectedErrorMessages: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ReadNDJSON(%v)", i, tc.input), func(t *testing.T) {
+			var items []map[string]any
+			var errorMessages []string
+
+			ReadNDJSON(strings.NewReader(tc.input))(func(data map[string]any, err error) bool {
+				if err != nil {
+					errorMessages = append(errorMessages, err.Error())
+				} else {
+					items = append(items, data)
+				}
+				return true
+			})
+
+			if !cmp.Equal(tc.expectedErrorMessages, errorMessages) {
+				t.Errorf("Expected error messages '%#v', but got '%#v'", tc.expectedErrorMessages, errorMessages)
+			}
+			if !cmp.Equal(tc.expectedItems, items) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedItems), gu.Prettify(items))
+			}
+		})
+	}
+}
+
+func TestReadNDJSONStopsWhenConsumerStops(t *testing.T) {
+	count := 0
+
+	ReadNDJSON(strings.NewReader("{}\n{}\n{}\n"))(func(data map[string]any, err error) bool {
+		count++
+		return false
+	})
+
+	if count != 1 {
+		t.Errorf("Expected 1 item, but got %v", count)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	items := []map[string]any{
+		{"author": "Nietzsche"},
+		{"author": "Stirner"},
+	}
+	if err := WriteNDJSON(&buf, SliceSeq(items)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "{\"author\":\"Nietzsche\"}\n{\"author\":\"Stirner\"}\n"
+	if buf.String() != expected {
+		t.Errorf("Expected '%#v', but got '%#v'", expected, buf.String())
+	}
+
+	var out bytes.Buffer
+	err := WriteNDJSON(&out, ReadNDJSON(strings.NewReader("{}\n{\n{}\n")))
+
+	expectedErrorMessage := "Line 2: unexpected end of JSON input"
+	if err == nil || err.Error() != expectedErrorMessage {
+		t.Errorf("Expected error message '%#v', but got '%#v'", expectedErrorMessage, err)
+	}
+	if out.String() != "{}\n" {
+		t.Errorf("Expected '%#v', but got '%#v'", "{}\n", out.String())
+	}
+}
@@ -30,7 +30,10 @@ const jsonPathSlicedArrayNodePattern = `^(?P<node>\w+)\[(?P<start>\-?\d*):(?P<en
 // Examples:
 // - `books[?(@.isbn)]`
 // - `books[?(@.price<10)]`
-const jsonPathFilteredArrayNodePattern = `^(?P<node>\w+)\[\?\(@\.(?P<key>\w+)\s*((?P<op>(\<|\>|(!=)|={2}|(<=)|(>=))?)\s*(?P<value>[\w\d]*))?\)\]$`
+// - `events[?(@.createdAt > '2023-01-01T00:00:00Z')]`
+// - `books[?(@.price < @.listPrice)]`
+// - `authors[?(@.name ~= 'Nietsche')]`
+const jsonPathFilteredArrayNodePattern = `^(?P<node>\w+)\[\?\(@\.(?P<key>\w+)\s*((?P<op>(\<|\>|(!=)|={2}|(<=)|(>=)|(~=))?)\s*(?P<value>@\.\w+|'[^']*'|"[^"]*"|[\w.+\-:]*))?\)\]$`
 
 // Simple JSON node pattern.
 const jsonPathSimpleNodePattern = `^(?P<node>(\w*|\*))$`
@@ -82,11 +85,17 @@ type arrayFilteredNode struct {
 	// The property to filter with.
 	key string
 
-	// The comparison oparator. Can be one of '=', '!', '<', '=<', '=>', '>'.
+	// The comparison oparator. Can be one of '=', '!', '<', '=<', '=>', '>' or '~=' for similar strings.
 	op string
 
 	// The value to compare with.
 	value any
+
+	// The property of the same element to compare with instead of the value, i.e. `listPrice` in `[?(@.price < @.listPrice)]`.
+	valueKey string
+
+	// The options of the comparisons. The ones set by SetFilterOptions are used if nil.
+	opts *FilterOptions
 }
 
 const (
@@ -107,6 +116,9 @@ func (err dataValidationError) Error() string {
 
 	switch err.errorType {
 	case dataValidationErrorNotMap:
+		if err.data != nil {
+			return fmt.Sprintf("%v: Data is not a map: %#v", prefix, err.data)
+		}
 		return fmt.Sprintf("%v: Data is nil.", prefix)
 	case dataValidationErrorKeyNotFound:
 		return fmt.Sprintf("%v: Source key not found: '%v'", prefix, err.key)
@@ -117,6 +129,34 @@ func (err dataValidationError) Error() string {
 	return prefix
 }
 
+// ErrorCode returns the code of the error.
+func (err dataValidationError) ErrorCode() string {
+	switch err.errorType {
+	case dataValidationErrorKeyNotFound:
+		return ErrCodeKeyNotFound
+	case dataValidationErrorValueNotArray:
+		return ErrCodeNotArray
+	}
+
+	return ErrCodeNotMap
+}
+
+// MarshalJSON returns the error in the same form as Error.MarshalJSON.
+func (err dataValidationError) MarshalJSON() ([]byte, error) { return AsError(err).MarshalJSON() }
+
+// asMap returns the provided value as a map or a not map validation error if it is of any other type.
+func asMap(value any) (map[string]any, error) {
+	valueMap, ok := value.(map[string]any)
+	if ok && valueMap == nil {
+		return nil, dataValidationError{errorType: dataValidationErrorNotMap}
+	}
+	if !ok {
+		return nil, dataValidationError{data: value, errorType: dataValidationErrorNotMap}
+	}
+
+	return valueMap, nil
+}
+
 // validateSource ensures that the provided data can be used by the node for retrieval or update.
 func validateNodeData(n nodeDataAccessor, data map[string]any) error {
 	nodeName := n.getName()
@@ -158,7 +198,7 @@ func (n node) put(data map[string]any, value any) error {
 	err := validateNodeData(n, data)
 
 	// the key not found error is excluded because the key will be created anyway below
-	if err != nil && err.(dataValidationError).errorType != dataValidationErrorKeyNotFound {
+	if vErr, ok := err.(dataValidationError); err != nil && (!ok || vErr.errorType != dataValidationErrorKeyNotFound) {
 		return err
 	}
 
@@ -188,12 +228,15 @@ func (n arrayIndexedNode) get(data map[string]any) (any, error) {
 		return value, nil
 	}
 
+	items, _ := value.([]any)
+
 	var result []any
 	for _, i := range n.indices {
-		if i < 0 || i >= len(value.([]any)) {
+		if i < 0 || i >= len(items) {
+			logger.Debug("Index out of range skipped", "node", n.name, "index", i, "length", len(items))
 			continue
 		}
-		result = append(result, value.([]any)[i])
+		result = append(result, items[i])
 	}
 
 	return result, nil
@@ -207,13 +250,14 @@ func (n arrayIndexedNode) put(data map[string]any, newVal any) error {
 		return err
 	}
 
-	value := data[n.name]
+	items, _ := data[n.name].([]any)
 
 	for _, i := range n.indices {
-		if i < 0 || i >= len(value.([]any)) {
+		if i < 0 || i >= len(items) {
+			logger.Debug("Index out of range skipped", "node", n.name, "index", i, "length", len(items))
 			continue
 		}
-		value.([]any)[i] = newVal
+		items[i] = newVal
 	}
 
 	return nil
@@ -226,6 +270,34 @@ func (n arrayIndexedNode) getName() string { return n.node.name }
 // arraySlicedNode
 // ----------------
 
+// bounds returns the start and end indices of the node within a slice of the provided length.
+// Negative indices count from the end of the slice and both indices are clamped within the slice.
+// An end of 0 stands for the end of the slice.
+func (n arraySlicedNode) bounds(length int) (int, int) {
+	clamp := func(i int) int {
+		if i < 0 {
+			i += length
+		}
+		if i < 0 {
+			return 0
+		}
+		if i > length {
+			return length
+		}
+		return i
+	}
+
+	start, end := clamp(n.start), length
+	if n.end != 0 {
+		end = clamp(n.end)
+	}
+	if end < start {
+		end = start
+	}
+
+	return start, end
+}
+
 // get returns the value of the provided map data with key same as the name of the node.
 // The underlying value must be a slice and the returned value will be the subslice
 // defined by the start and end values of the node.
@@ -234,21 +306,14 @@ func (n arraySlicedNode) get(data map[string]any) (any, error) {
 		return nil, err
 	}
 
-	value := data[n.name]
-
-	if n.start != 0 && n.end != 0 {
-		return value.([]any)[n.start:n.end], nil
+	if n.start == 0 && n.end == 0 {
+		return data, nil
 	}
 
-	if n.start != 0 && n.end == 0 {
-		return value.([]any)[n.start:], nil
-	}
+	items, _ := data[n.name].([]any)
+	start, end := n.bounds(len(items))
 
-	if n.start == 0 && n.end != 0 {
-		return value.([]any)[:n.end], nil
-	}
-
-	return data, nil
+	return items[start:end], nil
 }
 
 // put updates the value of the provided map data with key same as the name of the n.
@@ -259,20 +324,15 @@ func (n arraySlicedNode) put(data map[string]any, newVal any) error {
 		return err
 	}
 
-	value := data[n.name]
-
-	if n.start != 0 && n.end != 0 {
-		value = value.([]any)[n.start:n.end]
-	} else if n.start != 0 && n.end == 0 {
-		value = value.([]any)[n.start:]
-	} else if n.start == 0 && n.end != 0 {
-		value = value.([]any)[:n.end]
-	} else {
+	if n.start == 0 && n.end == 0 {
 		return nil
 	}
 
-	for i := range value.([]any) {
-		value.([]any)[i] = newVal
+	items, _ := data[n.name].([]any)
+	start, end := n.bounds(len(items))
+
+	for i := start; i < end; i++ {
+		items[i] = newVal
 	}
 
 	return nil
@@ -286,59 +346,41 @@ func (n arraySlicedNode) getName() string { return n.node.name }
 // -----------------
 
 // assertCondition asserts the condition defined by the values and the operator.
-// The operator can be one of `=`, `!“, `<`, `>`, `<=`, `>=`
-// First a comparison will be attempted between floats (if applicable) and then between strings (if applicable)
-func assertCondition(val1 any, val2 any, op string) bool {
-	fval1, err1 := gu.ToFloat64(val1)
-	fval2, err2 := gu.ToFloat64(val2)
-	areFloats := err1 == nil && err2 == nil
-
-	switch op {
-	case "<":
-		if areFloats {
-			return fval1 < fval2
-		}
-		if gu.IsString(val1) && gu.IsString(val2) {
-			return val1.(string) < val2.(string)
-		}
-	case ">":
-		if areFloats {
-			return fval1 > fval2
-		}
-		if gu.IsString(val1) && gu.IsString(val2) {
-			return val1.(string) < val2.(string)
-		}
-	case "<=":
-		if areFloats {
-			return fval1 <= fval2
-		}
-		if gu.IsString(val1) && gu.IsString(val2) {
-			return val1.(string) <= val2.(string)
-		}
-	case ">=":
-		if areFloats {
-			return fval1 >= fval2
-		}
-		if gu.IsString(val1) && gu.IsString(val2) {
-			return val1.(string) >= val2.(string)
-		}
-	case "==":
-		if areFloats {
-			return fval1 == fval2
-		}
-		if gu.IsString(val1) && gu.IsString(val2) {
-			return val1.(string) == val2.(string)
-		}
-	case "!=":
-		if areFloats {
-			return fval1 != fval2
-		}
-		if gu.IsString(val1) && gu.IsString(val2) {
-			return val1.(string) != val2.(string)
-		}
+// The operator can be one of `==`, `!=`, `<`, `>`, `<=`, `>=`, `~=`
+// The values are compared according to compareFilterValues except for `~=` which requires similar strings (see
+// FilterOptions.FuzzyThreshold). Values which cannot be compared never satisfy the condition, whatever the operator.
+func assertCondition(val1 any, val2 any, op string, opts FilterOptions) bool {
+	if op == "~=" {
+		return similar(val1, val2, opts)
 	}
 
-	return false
+	c, ordered, ok := compareFilterValues(val1, val2, opts)
+	if !ok || (!ordered && op != "==" && op != "!=") {
+		return false
+	}
+
+	return assertOrder(c, op)
+}
+
+// satisfiedBy returns whether an element with the provided value of the filter key satisfies the condition of the n.
+// If the n compares with another property of the element the element must have it.
+func (n arrayFilteredNode) satisfiedBy(itemMap map[string]any, value any) bool {
+	if len(n.op) == 0 || (n.value == nil && n.valueKey == "") {
+		return true
+	}
+
+	opts := getFilterOptions()
+	if n.opts != nil {
+		opts = *n.opts
+	}
+
+	if n.valueKey == "" {
+		return assertCondition(value, n.value, n.op, opts)
+	}
+
+	other, ok := itemMap[n.valueKey]
+
+	return ok && assertCondition(value, other, n.op, opts)
 }
 
 // get returns the value of the provided map data with key same as the name of the n.
@@ -349,16 +391,18 @@ func (n arrayFilteredNode) get(data map[string]any) (any, error) {
 		return nil, err
 	}
 
-	value := data[n.name]
+	items, _ := data[n.name].([]any)
 
 	var filteredVal []any
-	for _, item := range value.([]any) {
-		value, ok := item.(map[string]any)[n.key]
+	for _, item := range items {
+		itemMap, _ := item.(map[string]any)
+		value, ok := itemMap[n.key]
 		if !ok {
+			logger.Debug("Element without filter key skipped", "node", n.name, "key", n.key)
 			continue
 		}
 
-		if len(n.op) == 0 || n.value == nil || assertCondition(value, n.value, n.op) {
+		if n.satisfiedBy(itemMap, value) {
 			filteredVal = append(filteredVal, item)
 		}
 	}
@@ -374,16 +418,18 @@ func (n arrayFilteredNode) put(data map[string]any, newVal any) error {
 		return err
 	}
 
-	value := data[n.name]
+	items, _ := data[n.name].([]any)
 
-	for _, item := range value.([]any) {
-		currValue, ok := item.(map[string]any)[n.key]
+	for _, item := range items {
+		itemMap, _ := item.(map[string]any)
+		currValue, ok := itemMap[n.key]
 		if !ok {
+			logger.Debug("Element without filter key skipped", "node", n.name, "key", n.key)
 			continue
 		}
 
-		if len(n.op) == 0 || n.value == nil || assertCondition(currValue, n.value, n.op) {
-			item.(map[string]any)[n.key] = newVal
+		if n.satisfiedBy(itemMap, currValue) {
+			itemMap[n.key] = newVal
 		}
 	}
 
@@ -403,7 +449,7 @@ type matchDictionary map[string]string
 func getMatchDictionary(patt string, s string) (dict matchDictionary) {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Println("Recovered from: ", r)
+			logger.Warn("Recovered while matching JSONPath pattern", "pattern", patt, "subpath", s, "panic", r)
 		}
 	}()
 
@@ -430,6 +476,15 @@ func getMatchDictionary(patt string, s string) (dict matchDictionary) {
 	return
 }
 
+// atoiOrZero works like strconv.Atoi but it returns 0 for an empty string.
+func atoiOrZero(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(s)
+}
+
 // nodeFromJsonPathSubNode checks one by one the existing JSONPath patterns and returns an appropriate node data accessor.
 func nodeFromJsonPathSubNode(jsonPathSubNode string) nodeDataAccessor {
 	var dict map[string]string
@@ -452,7 +507,10 @@ func nodeFromJsonPathSubNode(jsonPathSubNode string) nodeDataAccessor {
 		}
 		indices := strings.Split(dict["indices"], ",")
 		for _, index := range indices {
-			indexInt, _ := strconv.Atoi(strings.TrimSpace(index))
+			indexInt, err := strconv.Atoi(strings.TrimSpace(index))
+			if err != nil {
+				return nil
+			}
 			node.indices = append(node.indices, indexInt)
 		}
 
@@ -466,22 +524,33 @@ func nodeFromJsonPathSubNode(jsonPathSubNode string) nodeDataAccessor {
 				name: dict["node"],
 			},
 		}
-		node.start, _ = strconv.Atoi(dict["start"])
-		node.end, _ = strconv.Atoi(dict["end"])
+		var err error
+		if node.start, err = atoiOrZero(dict["start"]); err != nil {
+			return nil
+		}
+		if node.end, err = atoiOrZero(dict["end"]); err != nil {
+			return nil
+		}
 
 		return node
 	}
 
 	dict = getMatchDictionary(jsonPathFilteredArrayNodePattern, jsonPathSubNode)
 	if len(dict) > 0 {
-		return arrayFilteredNode{
+		filteredNode := arrayFilteredNode{
 			node: node{
 				name: dict["node"],
 			},
-			key:   dict["key"],
-			op:    dict["op"],
-			value: dict["value"],
+			key: dict["key"],
+			op:  dict["op"],
+		}
+		if strings.HasPrefix(dict["value"], "@.") {
+			filteredNode.valueKey = strings.TrimPrefix(dict["value"], "@.")
+		} else {
+			filteredNode.value = unquoteFilterValue(dict["value"])
 		}
+
+		return filteredNode
 	}
 
 	dict = getMatchDictionary(jsonPathSimpleNodePattern, jsonPathSubNode)
@@ -503,52 +572,153 @@ func isArrayNode(n nodeDataAccessor) bool {
 	return false
 }
 
+// Branches of walkNodes recorded in a trace.
+const (
+	traceBranchWildcard         = "wildcard"
+	traceBranchRecursiveMarker  = "recursive descent marker"
+	traceBranchArrayFanOut      = "array fan out"
+	traceBranchRecursiveDescent = "recursive descent search"
+	traceBranchFilteredDescent  = "filtered recursive descent search"
+	traceBranchNodeGet          = "node get"
+)
+
+// TraceStep describes a single step of the evaluation of a JSONPath.
+type TraceStep struct {
+
+	// Segment is the JSONPath segment applied in canonical bracket notation.
+	Segment string
+
+	// Branch describes how the segment was applied, i.e. `node get`, `array fan out`, `recursive descent search` etc.
+	Branch string
+
+	// InputCount is the number of values the segment applied on. It is the length of an array or 1 for any other value.
+	InputCount int
+
+	// Matches is the number of values the segment resolved to. It is the length of an array or 1 for any other value.
+	Matches int
+
+	// Error holds the error occured in the step, if any.
+	Error string
+}
+
+// traceCount returns the number of values held in a walked value.
+func traceCount(value any) int {
+	if items, ok := value.([]any); ok {
+		return len(items)
+	}
+
+	return 1
+}
+
 // walkNodes iterates through a slice of nodes and at the same time descends in the given `data` map object replacing it with the new value.
 // The value held in data at the end of the itaration will be returned.
 func walkNodes(data map[string]any, nodes []nodeDataAccessor) (walkedData any, err error) {
+	return walkNodesTraced(data, nodes, nil)
+}
+
+// walkNodesTraced works like walkNodes but it reports each step to the provided trace function if not nil.
+func walkNodesTraced(data map[string]any, nodes []nodeDataAccessor, trace func(TraceStep)) (walkedData any, err error) {
+	return walkNodesParallel(data, nodes, trace, 1)
+}
+
+// walkNodesParallel works like walkNodesTraced but the recursive descents are searched by the provided number of
+// workers if more than one.
+func walkNodesParallel(data map[string]any, nodes []nodeDataAccessor, trace func(TraceStep), workers int) (walkedData any, err error) {
 	walkedData = data
 
+	record := func(n nodeDataAccessor, branch string, input any, err error) {
+		if trace == nil {
+			return
+		}
+
+		step := TraceStep{Branch: branch, InputCount: traceCount(input)}
+		if n.getName() == "" {
+			step.Segment = ".."
+		} else {
+			step.Segment = canonicalNode(n)
+		}
+		if err != nil {
+			step.Error = err.Error()
+		} else {
+			step.Matches = traceCount(walkedData)
+		}
+
+		trace(step)
+	}
+
 	prevHasReccursiveDescent := false
 	for _, n := range nodes {
+		input := walkedData
+
 		if n.getName() == "*" {
+			record(n, traceBranchWildcard, input, nil)
 			continue
 		}
 
 		if n.getName() == "" {
 			prevHasReccursiveDescent = true
+			record(n, traceBranchRecursiveMarker, input, nil)
 			continue
 		}
 
 		if gu.IsSlice(walkedData) {
 			var items []any
 			for _, item := range walkedData.([]any) {
-				value, err := n.get(item.(map[string]any))
+				itemMap, err := asMap(item)
+				if err != nil {
+					record(n, traceBranchArrayFanOut, input, err)
+					return nil, err
+				}
+				value, err := n.get(itemMap)
 				if err != nil {
+					record(n, traceBranchArrayFanOut, input, err)
 					return nil, err
 				}
 				items = append(items, value)
 			}
 			walkedData = items
+			record(n, traceBranchArrayFanOut, input, nil)
 			continue
 		}
 
 		if prevHasReccursiveDescent {
-			walkedData = gu.MapGetDeepFlattened(walkedData, n.getName())
+			// the filters are applied while searching instead of filtering all the values found afterwards
+			collect, filtered := planDeepSearch(n)
+			if workers > 1 {
+				walkedData = parallelDeepSearch(walkedData, n.getName(), collect, workers)
+			} else {
+				walkedData = deepSearch(walkedData, n.getName(), collect, nil)
+			}
+			if filtered {
+				prevHasReccursiveDescent = false
+				record(n, traceBranchFilteredDescent, input, nil)
+				continue
+			}
 			if isArrayNode(n) {
 				walkedDataWithkey := map[string]any{n.getName(): walkedData}
 				walkedData, err = n.get(walkedDataWithkey)
 				if err != nil {
+					record(n, traceBranchRecursiveDescent, input, err)
 					return nil, err
 				}
 			}
 			prevHasReccursiveDescent = false
+			record(n, traceBranchRecursiveDescent, input, nil)
 			continue
 		}
 
-		walkedData, err = n.get(walkedData.(map[string]any))
+		walkedDataMap, err := asMap(walkedData)
+		if err != nil {
+			record(n, traceBranchNodeGet, input, err)
+			return nil, err
+		}
+
+		walkedData, err = n.get(walkedDataMap)
 		if err != nil {
+			record(n, traceBranchNodeGet, input, err)
 			return nil, err
 		}
+		record(n, traceBranchNodeGet, input, nil)
 	}
 
 	return walkedData, nil
@@ -69,6 +69,11 @@ func TestNnodeFromJsonPathSubNode(t *testing.T) {
 		{"books[?(@.price == 10)]", arrayFilteredNode{node: node{name: "books"}, key: "price", op: "==", value: "10"}},
 		{"books[?(@.price != 10)]", arrayFilteredNode{node: node{name: "books"}, key: "price", op: "!=", value: "10"}},
 		{"books[?(@.price)]", arrayFilteredNode{node: node{name: "books"}, key: "price", op: "", value: ""}},
+		{"books[?(@.price < 10.5)]", arrayFilteredNode{node: node{name: "books"}, key: "price", op: "<", value: "10.5"}},
+		{"events[?(@.at > '2023-01-01T00:00:00Z')]", arrayFilteredNode{node: node{name: "events"}, key: "at", op: ">", value: "2023-01-01T00:00:00Z"}},
+		{`events[?(@.at > "2023-01-01 00:00")]`, arrayFilteredNode{node: node{name: "events"}, key: "at", op: ">", value: "2023-01-01 00:00"}},
+		{"books[?(@.price < @.listPrice)]", arrayFilteredNode{node: node{name: "books"}, key: "price", op: "<", valueKey: "listPrice"}},
+		{"books[?(@.price < '@.listPrice')]", arrayFilteredNode{node: node{name: "books"}, key: "price", op: "<", value: "@.listPrice"}},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,197 @@
+package jsonmanu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// KeyOrder records the order of the keys of the objects of a document, i.e. as found in the JSON it was decoded from
+// with DecodeOrdered, so that it can be written back with Marshal in the same order.
+//
+// The objects are tracked by identity so the data remains a plain `map[string]any` which can be queried and updated
+// with Get, Put and Map as usual. The keys added since the order was last updated follow the original ones in
+// lexicographical order and the removed ones are skipped. Objects which are not tracked, i.e. the ones put as new
+// values, have their keys sorted.
+type KeyOrder struct {
+	objects map[uintptr]*orderedObject
+}
+
+// orderedObject is an object tracked by a KeyOrder along with the order of its keys.
+type orderedObject struct {
+
+	// object is kept so that its address cannot be reused by another object while it is tracked.
+	object map[string]any
+
+	keys []string
+}
+
+// NewKeyOrder creates an empty KeyOrder.
+func NewKeyOrder() *KeyOrder {
+	return &KeyOrder{objects: make(map[uintptr]*orderedObject)}
+}
+
+// objectID returns the identity of an object.
+func objectID(object map[string]any) uintptr {
+	return reflect.ValueOf(object).Pointer()
+}
+
+// track records the order of the keys of the object.
+func (o *KeyOrder) track(object map[string]any, keys []string) {
+	o.objects[objectID(object)] = &orderedObject{object: object, keys: keys}
+}
+
+// Keys returns the keys of the object in their recorded order. The keys of objects which are not tracked are sorted.
+func (o *KeyOrder) Keys(object map[string]any) []string {
+	if o == nil {
+		return sortedKeys(object)
+	}
+
+	tracked, ok := o.objects[objectID(object)]
+	if !ok {
+		return sortedKeys(object)
+	}
+
+	keys := make([]string, 0, len(object))
+	recorded := make(map[string]bool, len(tracked.keys))
+	for _, key := range tracked.keys {
+		if _, ok := object[key]; ok && !recorded[key] {
+			keys = append(keys, key)
+			recorded[key] = true
+		}
+	}
+
+	if len(keys) == len(object) {
+		return keys
+	}
+
+	for _, key := range sortedKeys(object) {
+		if !recorded[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// Update records the current order of the keys of all the objects nested in the data, appending the keys added since
+// the last update and tracking the objects added since then. The objects which are no longer found in the data are
+// not tracked anymore.
+//
+// Calling it after each change records the added keys in the order they were added.
+func (o *KeyOrder) Update(data map[string]any) {
+	objects := make(map[uintptr]*orderedObject, len(o.objects))
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			id := objectID(v)
+			if _, ok := objects[id]; ok {
+				return
+			}
+			objects[id] = &orderedObject{object: v, keys: o.Keys(v)}
+			for _, item := range v {
+				walk(item)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(data)
+
+	o.objects = objects
+}
+
+// decodeOrderedValue decodes the next value of the decoder tracking the order of the keys of its objects.
+func decodeOrderedValue(decoder *json.Decoder, order *KeyOrder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := make(map[string]any)
+		var keys []string
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyToken.(string)
+
+			value, err := decodeOrderedValue(decoder, order)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := object[key]; !ok {
+				keys = append(keys, key)
+			}
+			object[key] = value
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		order.track(object, keys)
+
+		return object, nil
+	case json.Delim('['):
+		items := []any{}
+		for decoder.More() {
+			item, err := decodeOrderedValue(decoder, order)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return items, nil
+	}
+
+	return token, nil
+}
+
+// DecodeOrdered reads a JSON document out of `r` like Decode does, along with the order of the keys of its objects so
+// that the document can be written back with Marshal in its original order once it is edited:
+//
+//	data, order, err := DecodeOrdered(file)
+//	...
+//	err = Put(data, "$.version", "2.0.0")
+//	...
+//	out, err := Marshal(data, MarshalOptions{Indent: "  ", PreserveOrder: order})
+//
+// The document's root must be an object.
+func DecodeOrdered(r io.Reader) (map[string]any, *KeyOrder, error) {
+	decoder := json.NewDecoder(r)
+	order := NewKeyOrder()
+
+	value, err := decodeOrderedValue(decoder, order)
+	if err == io.EOF {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := decoder.Token(); err == nil {
+		return nil, nil, &Error{Code: ErrCodeInvalidDocument, Message: "Unexpected data after the document root"}
+	} else if err != io.EOF {
+		return nil, nil, err
+	}
+
+	dataMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, nil, &Error{Code: ErrCodeNotMap, Message: fmt.Sprintf("Document root should be an object, got %T", value), Path: "$"}
+	}
+
+	return dataMap, order, nil
+}
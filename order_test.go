@@ -0,0 +1,136 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type DecodeOrderedTestCase struct {
+	input                string
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestDecodeOrdered(t *testing.T) {
+	testCases := []DecodeOrderedTestCase{
+		{
+			input: `{"z": {"b": 1, "a": [true, null, {"d": "x", "c": []}]}, "y": "", "z": {"b": 2}}`,
+			expectedData: map[string]any{
+				"z": map[string]any{"b": 2.0},
+				"y": "",
+			},
+		},
+		{
+			input:                "",
+			expectedErrorMessage: "unexpected EOF",
+		},
+		{
+			input:                `{"a": 1} {"b": 2}`,
+			expectedErrorMessage: "Unexpected data after the document root",
+		},
+		{
+			input:                `{"a": [1, }`,
+			expectedErrorMessage: "invalid character ',' looking for beginning of value",
+		},
+		{
+			input:                `["a"]`,
+			expectedErrorMessage: "Document root should be an object, got []interface {}",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - DecodeOrdered(%v)=%v", i, tc.input, tc.expectedErrorMessage), func(t *testing.T) {
+			data, _, err := DecodeOrdered(strings.NewReader(tc.input))
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedData), gu.Prettify(data))
+			}
+		})
+	}
+}
+
+func TestKeyOrder(t *testing.T) {
+	input := `{"name": "app", "version": "1.0.0", "dependencies": {"zlib": "1.2", "abc": "3.0"}, "scripts": [{"run": "go run", "build": "go build"}]}`
+
+	data, order, err := DecodeOrdered(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	marshal := func(opts MarshalOptions) string {
+		opts.PreserveOrder = order
+		result, err := Marshal(data, opts)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return string(result)
+	}
+
+	expected := `{"name":"app","version":"1.0.0","dependencies":{"zlib":"1.2","abc":"3.0"},"scripts":[{"run":"go run","build":"go build"}]}`
+	if result := marshal(MarshalOptions{}); result != expected {
+		t.Errorf("Expected '%v', but got '%v'", expected, result)
+	}
+
+	// the added keys follow the original ones sorted until the order is updated
+	Put(data, "$.license", "MIT")
+	Put(data, "$.author", "someone")
+	Put(data, "$.dependencies.mux", "1.8")
+	Put(data, "$.engines", map[string]any{"node": ">=18", "go": ">=1.19"})
+	Delete(data, "$.version")
+
+	expected = `{"name":"app","dependencies":{"zlib":"1.2","abc":"3.0","mux":"1.8"},"scripts":[{"run":"go run","build":"go build"}],"author":"someone","engines":{"go":">=1.19","node":">=18"},"license":"MIT"}`
+	if result := marshal(MarshalOptions{}); result != expected {
+		t.Errorf("Expected '%v', but got '%v'", expected, result)
+	}
+
+	order.Update(data)
+	Put(data, "$.version", "2.0.0")
+	order.Update(data)
+	Put(data, "$.description", "an app")
+
+	expected = `{"name":"app","dependencies":{"zlib":"1.2","abc":"3.0","mux":"1.8"},"scripts":[{"run":"go run","build":"go build"}],"author":"someone","engines":{"go":">=1.19","node":">=18"},"license":"MIT","version":"2.0.0","description":"an app"}`
+	if result := marshal(MarshalOptions{}); result != expected {
+		t.Errorf("Expected '%v', but got '%v'", expected, result)
+	}
+
+	expected = `{"version":"2.0.0","name":"app","dependencies":{"zlib":"1.2","abc":"3.0","mux":"1.8"},"scripts":[{"run":"go run","build":"go build"}],"author":"someone","engines":{"go":">=1.19","node":">=18"},"license":"MIT","description":"an app"}`
+	if result := marshal(MarshalOptions{KeyOrder: []string{"version"}}); result != expected {
+		t.Errorf("Expected '%v', but got '%v'", expected, result)
+	}
+}
+
+func TestMapWithPreserveOrder(t *testing.T) {
+	src := map[string]any{"title": "Book1", "price": 15.5, "author": "Nietzsche"}
+
+	dst, order, err := DecodeOrdered(strings.NewReader(`{"id": 1, "book": {"title": ""}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	mappers := []Mapper{
+		{SrcJsonPath: "$.price", DstJsonPath: "$.book.price"},
+		{SrcJsonPath: "$.author", DstJsonPath: "$.book.author"},
+		{SrcJsonPath: "$.title", DstJsonPath: "$.book.title"},
+		{SrcJsonPath: "$.title", DstJsonPath: "$.name"},
+	}
+
+	if errors := MapWithOptions(src, dst, mappers, MapOptions{PreserveOrder: order}); len(errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", errors)
+	}
+
+	result, err := Marshal(dst, MarshalOptions{PreserveOrder: order})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"id":1,"book":{"title":"Book1","price":15.5,"author":"Nietzsche"},"name":"Book1"}`
+	if string(result) != expected {
+		t.Errorf("Expected '%v', but got '%s'", expected, result)
+	}
+}
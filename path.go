@@ -0,0 +1,269 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// canonicalNode returns the canonical bracket notation of a node.
+func canonicalNode(n nodeDataAccessor) string {
+	switch n := n.(type) {
+	case arrayIndexedNode:
+		if len(n.indices) == 0 {
+			return fmt.Sprintf("['%v'][*]", n.name)
+		}
+		indices := make([]string, len(n.indices))
+		for i, index := range n.indices {
+			indices[i] = fmt.Sprintf("%v", index)
+		}
+		return fmt.Sprintf("['%v'][%v]", n.name, strings.Join(indices, ","))
+	case arraySlicedNode:
+		start, end := "", ""
+		if n.start != 0 {
+			start = fmt.Sprintf("%v", n.start)
+		}
+		if n.end != 0 {
+			end = fmt.Sprintf("%v", n.end)
+		}
+		return fmt.Sprintf("['%v'][%v:%v]", n.name, start, end)
+	case arrayFilteredNode:
+		if len(n.op) == 0 {
+			return fmt.Sprintf("['%v'][?(@.%v)]", n.name, n.key)
+		}
+		if n.valueKey != "" {
+			return fmt.Sprintf("['%v'][?(@.%v %v @.%v)]", n.name, n.key, n.op, n.valueKey)
+		}
+		return fmt.Sprintf("['%v'][?(@.%v %v %v)]", n.name, n.key, n.op, quoteFilterValue(n.value))
+	}
+
+	if n.getName() == "*" {
+		return "[*]"
+	}
+
+	return fmt.Sprintf("['%v']", n.getName())
+}
+
+// NormalizePath validates a JSONPath and converts it to its canonical bracket notation, i.e. `$.store.books[0]`
+// becomes `$['store']['books'][0]` and `$..books[?(@.price<10)]` becomes `$..['books'][?(@.price < 10)]`.
+//
+// Equivalent JSONPaths have the same canonical form so it can be used to compare or deduplicate them. Note that the
+// canonical form is meant for comparison and it is not accepted by Get, Put or Map.
+func NormalizePath(jsonPath string) (string, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("$")
+
+	for _, n := range nodes {
+		if n.getName() == "" {
+			sb.WriteString("..")
+			continue
+		}
+		sb.WriteString(canonicalNode(n))
+	}
+
+	return sb.String(), nil
+}
+
+// PathsOptions holds the configuration of the paths enumeration.
+type PathsOptions struct {
+
+	// IncludeIntermediate determines whether the paths of objects and arrays are included as well, besides those of the leaves.
+	IncludeIntermediate bool
+}
+
+// PathInfo describes a path found in data.
+type PathInfo struct {
+
+	// Path is the JSONPath of the value.
+	Path string
+
+	// Type is the JSON type of the value, i.e. `object`, `array`, `string`, `number`, `boolean` or `null`.
+	Type string
+
+	// Length is the number of the elements of an array, the number of the keys of an object or the number of the
+	// characters of a string. It is zero for the rest of the types.
+	Length int
+}
+
+// sortedKeys returns the keys of a map in lexicographical order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// newPathInfo creates the PathInfo of a value.
+func newPathInfo(path string, value any) PathInfo {
+	info := PathInfo{Path: path, Type: schemaTypeOf(value)}
+
+	switch v := value.(type) {
+	case map[string]any:
+		info.Length = len(v)
+	case []any:
+		info.Length = len(v)
+	case string:
+		info.Length = utf8.RuneCountInString(v)
+	}
+
+	return info
+}
+
+// collectPaths walks the value reccursively and collects the info of its paths.
+func collectPaths(path string, value any, opts PathsOptions, infos []PathInfo) []PathInfo {
+	switch v := value.(type) {
+	case map[string]any:
+		if opts.IncludeIntermediate || len(v) == 0 {
+			infos = append(infos, newPathInfo(path, value))
+		}
+		for _, key := range sortedKeys(v) {
+			infos = collectPaths(path+"."+key, v[key], opts, infos)
+		}
+		return infos
+	case []any:
+		if opts.IncludeIntermediate || len(v) == 0 {
+			infos = append(infos, newPathInfo(path, value))
+		}
+		for i, item := range v {
+			itemPath := fmt.Sprintf("%v[%v]", path, i)
+			// arrays directly nested in arrays cannot be expressed in JSONPath so they are considered leaves
+			if _, ok := item.([]any); ok {
+				infos = append(infos, newPathInfo(itemPath, item))
+				continue
+			}
+			infos = collectPaths(itemPath, item, opts, infos)
+		}
+		return infos
+	}
+
+	return append(infos, newPathInfo(path, value))
+}
+
+// PathInfos returns the info of every leaf path found in data, or of every path if IncludeIntermediate is set.
+//
+// The paths are in dot notation, i.e. `$.store.books[0].title`. The object keys are visited in lexicographical order
+// and the array elements in their order.
+//
+// The JSONPath syntax supports keys of letters, digits and underscores only, without quoting, so the paths which go
+// through other keys, i.e. `first name` or `a.b`, are listed as they are but cannot be used with Get, Put or Map.
+func PathInfos(data map[string]any, opts PathsOptions) []PathInfo {
+	var infos []PathInfo
+
+	for _, key := range sortedKeys(data) {
+		infos = collectPaths("$."+key, data[key], opts, infos)
+	}
+
+	return infos
+}
+
+// Paths returns every leaf path found in data, or every path if IncludeIntermediate is set.
+//
+// See PathInfos for the type and length information of each path.
+func Paths(data map[string]any, opts PathsOptions) []string {
+	var paths []string
+
+	for _, info := range PathInfos(data, opts) {
+		paths = append(paths, info.Path)
+	}
+
+	return paths
+}
+
+// childKeys returns the keys of the provided value along with their values. If the value is an array
+// (i.e. due to grouping) the keys of all its object elements are merged.
+func childKeys(value any) map[string]any {
+	children := make(map[string]any)
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			children[key] = val
+		}
+	case []any:
+		for _, item := range v {
+			if itemMap, ok := item.(map[string]any); ok {
+				for key, val := range itemMap {
+					if _, ok := children[key]; !ok {
+						children[key] = val
+					}
+				}
+			}
+		}
+	}
+
+	return children
+}
+
+// SuggestPaths returns the valid continuations of a partially typed JSONPath based on the provided data.
+//
+// If the last segment of the path is incomplete, i.e. `$.store.bo`, the paths of the child keys starting with it
+// are returned. If it names an array, i.e. `$.store.books` or `$.store.books[`, the paths of its elements are
+// returned as well. Paths with recursive descent are not supported and no suggestions are returned for them.
+//
+// The suggestions are sorted lexicographically.
+func SuggestPaths(data map[string]any, partialPath string) []string {
+	if partialPath == "$" {
+		partialPath = "$."
+	}
+
+	if !strings.HasPrefix(partialPath, "$.") || jsonPathHasReccursiveDescent(partialPath) {
+		return nil
+	}
+
+	lastDot := strings.LastIndex(partialPath, ".")
+	parentPath, segment := partialPath[:lastDot], partialPath[lastDot+1:]
+
+	var parent any = data
+	if parentPath != "$" {
+		var err error
+		if parent, err = Get(data, parentPath); err != nil {
+			return nil
+		}
+	}
+
+	var suggestions []string
+
+	if bracket := strings.Index(segment, "["); bracket >= 0 {
+		segment = segment[:bracket]
+		if items, ok := childKeys(parent)[segment].([]any); ok {
+			suggestions = append(suggestions, arrayElementPaths(parentPath+"."+segment, items)...)
+		}
+		return suggestions
+	}
+
+	for key, value := range childKeys(parent) {
+		if !strings.HasPrefix(key, segment) {
+			continue
+		}
+
+		suggestions = append(suggestions, parentPath+"."+key)
+
+		if items, ok := value.([]any); ok && key == segment {
+			suggestions = append(suggestions, arrayElementPaths(parentPath+"."+key, items)...)
+		}
+	}
+
+	sort.Strings(suggestions)
+
+	return suggestions
+}
+
+// arrayElementPaths returns the wildcard path and the indexed paths of the elements of an array.
+func arrayElementPaths(path string, items []any) []string {
+	paths := []string{path + "[*]"}
+
+	for i := range items {
+		paths = append(paths, fmt.Sprintf("%v[%v]", path, i))
+	}
+
+	return paths
+}
@@ -0,0 +1,141 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type NormalizePathTestCase struct {
+	jsonPath             string
+	expectedPath         string
+	expectedErrorMessage string
+}
+
+func TestNormalizePath(t *testing.T) {
+	testCases := []NormalizePathTestCase{
+		{jsonPath: "$", expectedPath: "$"},
+		{jsonPath: "$.store.books", expectedPath: "$['store']['books']"},
+		{jsonPath: "$.store.*", expectedPath: "$['store'][*]"},
+		{jsonPath: "$.store.books[*]", expectedPath: "$['store']['books'][*]"},
+		{jsonPath: "$.store.books[0]", expectedPath: "$['store']['books'][0]"},
+		{jsonPath: "$.store.books[ 0, 2 ]", expectedPath: "$['store']['books'][0,2]"},
+		{jsonPath: "$.store.books[1:3]", expectedPath: "$['store']['books'][1:3]"},
+		{jsonPath: "$.store.books[:3]", expectedPath: "$['store']['books'][:3]"},
+		{jsonPath: "$.store.books[?(@.isbn)]", expectedPath: "$['store']['books'][?(@.isbn)]"},
+		{jsonPath: "$.store.books[?(@.price<10)]", expectedPath: "$['store']['books'][?(@.price < 10)]"},
+		{jsonPath: "$.store.books[?(@.price   <   10)]", expectedPath: "$['store']['books'][?(@.price < 10)]"},
+		{jsonPath: "$..books.title", expectedPath: "$..['books']['title']"},
+		{jsonPath: "store.books", expectedErrorMessage: "JSONPath should start with '$.'"},
+		{jsonPath: "$.store.books.", expectedErrorMessage: "JSONPath should not end with '.'"},
+		{jsonPath: "$.store.books[a]", expectedErrorMessage: "Couldn't parse JSONPath substring 1: 'books[a]'"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - NormalizePath(%v)=%v", i, tc.jsonPath, tc.expectedPath), func(t *testing.T) {
+			path, err := NormalizePath(tc.jsonPath)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if path != tc.expectedPath {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedPath, path)
+			}
+		})
+	}
+}
+
+var pathsTestData = map[string]any{
+	"store": map[string]any{
+		"name": "Store1",
+		"books": []any{
+			map[string]any{"title": "Book1", "price": 15},
+			map[string]any{"title": "Βιβλίο", "tags": []any{}},
+		},
+		"matrix": []any{[]any{1, 2}},
+		"owner":  nil,
+		"open":   true,
+	},
+	"empty": map[string]any{},
+}
+
+func TestPaths(t *testing.T) {
+	expectedPaths := []string{
+		"$.empty",
+		"$.store.books[0].price",
+		"$.store.books[0].title",
+		"$.store.books[1].tags",
+		"$.store.books[1].title",
+		"$.store.matrix[0]",
+		"$.store.name",
+		"$.store.open",
+		"$.store.owner",
+	}
+
+	paths := Paths(pathsTestData, PathsOptions{})
+	if !cmp.Equal(expectedPaths, paths) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedPaths, paths)
+	}
+
+	for _, path := range paths {
+		if _, err := Get(pathsTestData, path); err != nil {
+			t.Errorf("Path '%v' cannot be resolved: %v", path, err)
+		}
+	}
+}
+
+func TestPathInfos(t *testing.T) {
+	expectedInfos := []PathInfo{
+		{Path: "$.empty", Type: "object", Length: 0},
+		{Path: "$.store", Type: "object", Length: 5},
+		{Path: "$.store.books", Type: "array", Length: 2},
+		{Path: "$.store.books[0]", Type: "object", Length: 2},
+		{Path: "$.store.books[0].price", Type: "number", Length: 0},
+		{Path: "$.store.books[0].title", Type: "string", Length: 5},
+		{Path: "$.store.books[1]", Type: "object", Length: 2},
+		{Path: "$.store.books[1].tags", Type: "array", Length: 0},
+		{Path: "$.store.books[1].title", Type: "string", Length: 6},
+		{Path: "$.store.matrix", Type: "array", Length: 1},
+		{Path: "$.store.matrix[0]", Type: "array", Length: 2},
+		{Path: "$.store.name", Type: "string", Length: 6},
+		{Path: "$.store.open", Type: "boolean", Length: 0},
+		{Path: "$.store.owner", Type: "null", Length: 0},
+	}
+
+	infos := PathInfos(pathsTestData, PathsOptions{IncludeIntermediate: true})
+	if !cmp.Equal(expectedInfos, infos) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedInfos, infos)
+	}
+}
+
+type SuggestPathsTestCase struct {
+	partialPath         string
+	expectedSuggestions []string
+}
+
+func TestSuggestPaths(t *testing.T) {
+	testCases := []SuggestPathsTestCase{
+		{partialPath: "$", expectedSuggestions: []string{"$.empty", "$.store"}},
+		{partialPath: "$.", expectedSuggestions: []string{"$.empty", "$.store"}},
+		{partialPath: "$.st", expectedSuggestions: []string{"$.store"}},
+		{partialPath: "$.store.", expectedSuggestions: []string{"$.store.books", "$.store.matrix", "$.store.name", "$.store.open", "$.store.owner"}},
+		{partialPath: "$.store.o", expectedSuggestions: []string{"$.store.open", "$.store.owner"}},
+		{partialPath: "$.store.books", expectedSuggestions: []string{"$.store.books", "$.store.books[*]", "$.store.books[0]", "$.store.books[1]"}},
+		{partialPath: "$.store.books[", expectedSuggestions: []string{"$.store.books[*]", "$.store.books[0]", "$.store.books[1]"}},
+		{partialPath: "$.store.books.t", expectedSuggestions: []string{"$.store.books.tags", "$.store.books.title"}},
+		{partialPath: "$.store.books[0].", expectedSuggestions: []string{"$.store.books[0].price", "$.store.books[0].title"}},
+		{partialPath: "$.store.x", expectedSuggestions: nil},
+		{partialPath: "$.missing.", expectedSuggestions: nil},
+		{partialPath: "$..books", expectedSuggestions: nil},
+		{partialPath: "store", expectedSuggestions: nil},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - SuggestPaths(%v)", i, tc.partialPath), func(t *testing.T) {
+			suggestions := SuggestPaths(pathsTestData, tc.partialPath)
+			if !cmp.Equal(tc.expectedSuggestions, suggestions) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedSuggestions, suggestions)
+			}
+		})
+	}
+}
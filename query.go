@@ -2,7 +2,12 @@ package jsonmanu
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	gu "github.com/antavelos/go-utils"
 )
@@ -12,29 +17,44 @@ func jsonPathHasReccursiveDescent(path string) bool {
 }
 
 // splitJsonPath splits a string based on a `.` separator. However, the string is supposed to be a JSONPath so
-// the case of `@.` shall be specially handled.
+// the separators within brackets, i.e. `[?(@.price < 10.5)]`, and within quotes are not taken into account.
 func splitJsonPath(jsonPath string) []string {
-	tempJsonPath := strings.Replace(jsonPath, "@.", "@:", -1)
+	var tokens []string
 
-	tokens := strings.Split(tempJsonPath, ".")
-
-	for i := 0; i < len(tokens); i++ {
-		if strings.Contains(tokens[i], "@:") {
-			tokens[i] = strings.Replace(tokens[i], "@:", "@.", -1)
+	depth, quote, start := 0, rune(0), 0
+	for i, r := range jsonPath {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '.' && depth == 0:
+			tokens = append(tokens, jsonPath[start:i])
+			start = i + 1
 		}
 	}
 
-	return tokens
+	return append(tokens, jsonPath[start:])
 }
 
 // parseJsonPath translates a provided JSONPath to an array of node data accessors that can be used to retrieve values from or update a given map.
 func parseJsonPath(jsonPath string) ([]nodeDataAccessor, error) {
+	if jsonPath == "$" {
+		return nil, nil
+	}
+
 	if !strings.HasPrefix(jsonPath, "$.") {
-		return nil, fmt.Errorf("JSONPath should start with '$.'")
+		return nil, &Error{Code: ErrCodeInvalidPath, Message: "JSONPath should start with '$.'", Path: jsonPath}
 	}
 
 	if strings.HasSuffix(jsonPath, ".") {
-		return nil, fmt.Errorf("JSONPath should not end with '.'")
+		return nil, &Error{Code: ErrCodeInvalidPath, Message: "JSONPath should not end with '.'", Path: jsonPath}
 	}
 
 	jsonPathSubNodes := splitJsonPath(jsonPath)
@@ -43,7 +63,12 @@ func parseJsonPath(jsonPath string) ([]nodeDataAccessor, error) {
 	for i, jsonPathSubNode := range jsonPathSubNodes[1:] {
 		node := nodeFromJsonPathSubNode(jsonPathSubNode)
 		if node == nil {
-			return nil, fmt.Errorf("Couldn't parse JSONPath substring %v: '%v'", i, jsonPathSubNode)
+			return nil, &Error{
+				Code:    ErrCodeInvalidPath,
+				Message: fmt.Sprintf("Couldn't parse JSONPath substring %v: '%v'", i, jsonPathSubNode),
+				Path:    jsonPath,
+				Segment: jsonPathSubNode,
+			}
 		}
 
 		nodes = append(nodes, node)
@@ -54,26 +79,290 @@ func parseJsonPath(jsonPath string) ([]nodeDataAccessor, error) {
 
 // Get retrieves a value out of a given map or a slice of maps as it is described in the provided JSONPath.
 //
-// The `data` must not be nil.
+// The `data` must not be nil. The root path `$` returns the `data` itself. The values found by recursive descents,
+// i.e. `$..price`, are returned in a stable order, with the keys of the objects visited in lexicographical order.
 //
 // It returns the retrieved data if everything goes well. Otherwise nil along with the relevant error.
 func Get(data map[string]any, jsonPath string) (any, error) {
+	return get(data, jsonPath, 1, nil)
+}
+
+// get works like Get but the recursive descents are searched by the provided number of workers if more than one and
+// the filters use the provided options if not nil.
+func get(data map[string]any, jsonPath string, workers int, filterOpts *FilterOptions) (_ any, err error) {
+	defer func(start time.Time) { metrics.QueryExecuted("get", time.Since(start), err) }(time.Now())
+
 	nodes, err := parseJsonPath(jsonPath)
 	if err != nil {
+		logger.Debug("Get failed", "path", jsonPath, "error", err)
 		return nil, err
 	}
 
-	result, err := walkNodes(data, nodes)
+	result, err := walkNodesParallel(data, bindFilterOptions(nodes, filterOpts), nil, workers)
+	if err != nil {
+		logger.Debug("Get failed", "path", jsonPath, "error", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetOptions holds the options of GetWithOptions.
+type GetOptions struct {
+
+	// ReadOnly makes the retrieved value a deep copy of the matched branch(es) so that changing it has no side
+	// effects on the source data.
+	ReadOnly bool
+
+	// Limit, if positive, stops the traversal as soon as as many values are matched and returns them as a list,
+	// even if a single one is matched. As with the rest of the operations that act per match, missing keys and
+	// array elements that are not objects are skipped instead of causing an error.
+	Limit int
+
+	// Dialect is the notation of the path. It defaults to JSONPath.
+	Dialect Dialect
+
+	// Workers, if more than one, is the number of goroutines searching the recursive descents, i.e. `$..price`, in
+	// parallel, which pays off for documents with millions of values. The matched values are merged in the same
+	// order Get returns them regardless of how the search was scheduled. It is ignored if Limit is set.
+	Workers int
+
+	// FilterOptions, if set, configures the comparisons of the array filters of this retrieval only. The zero values
+	// of the options are replaced by their defaults. It defaults to the options set by SetFilterOptions.
+	FilterOptions *FilterOptions
+}
+
+// GetWithOptions works like Get but it also accepts options which adjust the retrieval.
+func GetWithOptions(data map[string]any, jsonPath string, opts GetOptions) (any, error) {
+	jsonPath, err := ToJsonPath(jsonPath, opts.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if opts.Limit > 0 {
+		result, err = getLimited(data, jsonPath, opts.Limit, opts.FilterOptions)
+	} else {
+		result, err = get(data, jsonPath, opts.Workers, opts.FilterOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ReadOnly {
+		result = deepCopy(result)
+	}
+
+	return result, nil
+}
+
+// getLimited returns a list of at most limit values matched by the JSONPath, in the order of Get. The filters use the
+// provided options if not nil.
+func getLimited(data map[string]any, jsonPath string, limit int, filterOpts *FilterOptions) ([]any, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []any{}
+	visitValues(data, bindFilterOptions(nodes, filterOpts), false, func(value any) bool {
+		values = append(values, value)
+		return len(values) < limit
+	})
+
+	return values, nil
+}
+
+// GetAppend appends the values matched by the JSONPath to dst and returns the extended slice, so that services
+// retrieving values at a high rate can reuse the same buffer across calls and amortize its allocations, i.e.
+// `buf, err = GetAppend(buf[:0], data, jsonPath)`. The values are appended in the order of Get.
+//
+// As with GetOptions.Limit, the values are matched one by one so missing keys and array elements that are not
+// objects are skipped instead of causing an error. dst is returned as it is along with the error of an invalid
+// JSONPath.
+func GetAppend(dst []any, data map[string]any, jsonPath string) ([]any, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return dst, err
+	}
+
+	visitValues(data, nodes, false, func(value any) bool {
+		dst = append(dst, value)
+		return true
+	})
+
+	return dst, nil
+}
+
+// GetFirst returns the first value matched by the JSONPath without traversing the rest of the data, which is
+// cheaper than Get on large documents when a single value is needed. The values are matched in the order of Get.
+//
+// An error is returned if no value is matched.
+func GetFirst(data map[string]any, jsonPath string) (any, error) {
+	values, err := getLimited(data, jsonPath, 1, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return nil, &Error{Code: ErrCodeKeyNotFound, Message: "No value matched.", Path: jsonPath}
+	}
+
+	return values[0], nil
+}
+
+// GetFirstOf tries the provided JSONPaths in order and returns the value of the first one that can be retrieved,
+// i.e. to handle a field that moved between versions of a schema with `$.user.email` and `$.account.email`.
+//
+// An error is returned if any of the JSONPaths is invalid or if none of them can be retrieved.
+func GetFirstOf(data map[string]any, jsonPaths ...string) (any, error) {
+	parsed := make([][]nodeDataAccessor, len(jsonPaths))
+	for i, jsonPath := range jsonPaths {
+		nodes, err := parseJsonPath(jsonPath)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = nodes
+	}
+
+	for _, nodes := range parsed {
+		if result, err := walkNodes(data, nodes); err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, &Error{Code: ErrCodeKeyNotFound, Message: fmt.Sprintf("None of the paths %v exists.", strings.Join(jsonPaths, ", "))}
+}
+
+// GetOr works like Get but it returns the provided default value instead of an error if the JSONPath cannot be
+// retrieved, i.e. when an optional setting is missing from a configuration. A value that exists, even if nil, is
+// returned as is.
+//
+// An error is returned only if the JSONPath is invalid.
+func GetOr(data map[string]any, jsonPath string, defaultValue any) (any, error) {
+	nodes, err := parseJsonPath(jsonPath)
 	if err != nil {
 		return nil, err
 	}
 
+	result, err := walkNodes(data, nodes)
+	if err != nil {
+		return defaultValue, nil
+	}
+
 	return result, nil
 }
 
+// GetOrZero works like GetOr but it returns the retrieved value as a T, or the zero value of T if the JSONPath cannot
+// be retrieved or its value is nil, i.e. `GetOrZero[string](data, "$.server.host")`.
+//
+// An error is returned if the JSONPath is invalid or if the retrieved value is not a T.
+func GetOrZero[T any](data map[string]any, jsonPath string) (T, error) {
+	var zero T
+
+	result, err := GetOr(data, jsonPath, nil)
+	if err != nil || result == nil {
+		return zero, err
+	}
+
+	value, ok := result.(T)
+	if !ok {
+		return zero, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Value %#v is not of type %T", result, zero), Path: jsonPath}
+	}
+
+	return value, nil
+}
+
+// GetWithTrace works like Get but it also returns the steps taken while evaluating the JSONPath so that it can be
+// examined why a path resolved to an unexpected value or failed.
+//
+// The steps are returned even if an error occurs. If the JSONPath cannot be parsed no steps are returned.
+func GetWithTrace(data map[string]any, jsonPath string) (any, []TraceStep, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var steps []TraceStep
+	result, err := walkNodesTraced(data, nodes, func(step TraceStep) {
+		steps = append(steps, step)
+	})
+	if err != nil {
+		return nil, steps, err
+	}
+
+	return result, steps, nil
+}
+
+// createdKey is a key of a map added or replaced in preparation of a Put.
+type createdKey struct {
+	parent   map[string]any
+	key      string
+	previous any
+	existed  bool
+}
+
+// newCreatedKey records the current state of the key of the parent map before it is changed.
+func newCreatedKey(parent map[string]any, key string) createdKey {
+	previous, existed := parent[key]
+
+	return createdKey{parent: parent, key: key, previous: previous, existed: existed}
+}
+
+// growIndexedItems returns the items of an indexed array node grown so that the explicit indices of the node are
+// within them. An index equal to the length of the items appends an element while a greater one pads the items with
+// nils first if the options allow it, otherwise it causes an error. Unless terminal, the addressed elements which are
+// missing or nil become empty maps so that the rest of the path can be created in them.
+//
+// The items are copied before any change and it is returned whether there was any.
+func growIndexedItems(n arrayIndexedNode, items []any, terminal bool, opts PutOptions) ([]any, bool, error) {
+	indices := append([]int(nil), n.indices...)
+	sort.Ints(indices)
+
+	result, changed := items, false
+	change := func() {
+		if !changed {
+			result = append(make([]any, 0, len(items)+1), items...)
+			changed = true
+		}
+	}
+
+	for _, i := range indices {
+		if i < 0 {
+			continue
+		}
+		if i > len(result) && !opts.PadArrays {
+			return items, false, &Error{
+				Code:    ErrCodeInvalidOperation,
+				Message: fmt.Sprintf("Index %v is beyond the length %v of '%v'", i, len(result), n.name),
+				Segment: n.name,
+			}
+		}
+
+		for len(result) <= i {
+			change()
+			result = append(result, nil)
+		}
+
+		if !terminal && result[i] == nil {
+			change()
+			result[i] = make(map[string]any)
+		}
+	}
+
+	return result, changed, nil
+}
+
 // ensureDataStrunctureFromNodes creates the map tree structure in case in is not present so it can be safely used later by Put.
 // The data argument is any because the function runs reccursively and besides a map it can be of any type.
-func ensureDataStrunctureFromNodes(data any, nodes []nodeDataAccessor) {
+//
+// Missing keys are created as maps except for the ones of array nodes with explicit indices, i.e. `items[0]`, which are
+// created as arrays grown by growIndexedItems. Below array nodes the structure is created only in the elements they
+// select, i.e. the ones satisfying the condition of a filter.
+//
+// It returns the keys it created so that they can be removed by removeCreatedKeys should the Put fail, along with the
+// error of an index which cannot be reached, in which case the keys created so far are returned.
+func ensureDataStrunctureFromNodes(data any, nodes []nodeDataAccessor, opts PutOptions) (created []createdKey, err error) {
 
 	if len(nodes) == 0 {
 		return
@@ -81,36 +370,213 @@ func ensureDataStrunctureFromNodes(data any, nodes []nodeDataAccessor) {
 
 	if gu.IsSlice(data) {
 		for item := range gu.IterAny(data, nil) {
-			ensureDataStrunctureFromNodes(item, nodes[1:])
+			itemCreated, err := ensureDataStrunctureFromNodes(item, nodes, opts)
+			created = append(created, itemCreated...)
+			if err != nil {
+				return created, err
+			}
 		}
 	} else if gu.IsMap(data) {
+		dataMap := data.(map[string]any)
 		firstNodeName := nodes[0].getName()
+		indexedNode, indexed := nodes[0].(arrayIndexedNode)
+		indexed = indexed && len(indexedNode.indices) > 0
 
-		val, ok := data.(map[string]any)[firstNodeName]
+		val, ok := dataMap[firstNodeName]
 		if !ok || val == nil {
-			data.(map[string]any)[firstNodeName] = make(map[string]any)
-			val, _ = data.(map[string]any)[firstNodeName]
+			created = append(created, newCreatedKey(dataMap, firstNodeName))
+			if indexed {
+				dataMap[firstNodeName] = []any{}
+			} else {
+				dataMap[firstNodeName] = make(map[string]any)
+			}
+			val, _ = dataMap[firstNodeName]
 		}
 
-		ensureDataStrunctureFromNodes(val, nodes[1:])
+		if items, ok := val.([]any); ok && indexed {
+			grown, changed, err := growIndexedItems(indexedNode, items, len(nodes) == 1, opts)
+			if err != nil {
+				return created, err
+			}
+			if changed {
+				created = append(created, newCreatedKey(dataMap, firstNodeName))
+				dataMap[firstNodeName] = grown
+				val = grown
+			}
+		}
+
+		nestedCreated, err := ensureDataStrunctureFromNodes(selectedItems(nodes[0], val), nodes[1:], opts)
+		created = append(created, nestedCreated...)
+		if err != nil {
+			return created, err
+		}
+	}
+
+	return
+}
+
+// selectedItems returns the elements of the value that the node selects if it is an array node, i.e. the ones
+// satisfying the condition of a filtered node. Otherwise, or if the value is not an array, the value is returned as is.
+func selectedItems(n nodeDataAccessor, value any) any {
+	items, isSlice := value.([]any)
+	if !isArrayNode(n) || !isSlice {
+		return value
+	}
+
+	if slicedNode, ok := n.(arraySlicedNode); ok {
+		start, end := slicedNode.bounds(len(items))
+		return items[start:end]
+	}
+
+	selected, err := n.get(map[string]any{n.getName(): items})
+	if err != nil {
+		return value
+	}
+
+	return selected
+}
+
+// removeCreatedKeys reverts the changes made by ensureDataStrunctureFromNodes.
+func removeCreatedKeys(created []createdKey) {
+	for i := len(created) - 1; i >= 0; i-- {
+		if created[i].existed {
+			created[i].parent[created[i].key] = created[i].previous
+		} else {
+			delete(created[i].parent, created[i].key)
+		}
 	}
 }
 
+// flattenSlices returns the elements of the nested slices of items, as grouped by consecutive array nodes, in a single slice.
+func flattenSlices(items []any) []any {
+	var result []any
+	for _, item := range items {
+		if nested, ok := item.([]any); ok {
+			result = append(result, flattenSlices(nested)...)
+			continue
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// putRoot replaces the content of data with the content of value in place. Values other than maps are rejected.
+func putRoot(data map[string]any, value any) error {
+	if data == nil {
+		return dataValidationError{data: data, errorType: dataValidationErrorNotMap}
+	}
+
+	valueMap, ok := value.(map[string]any)
+	if !ok {
+		return &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Root value should be a map, got %T", value), Path: "$"}
+	}
+
+	// copy first so that passing data itself as value is harmless
+	newData := make(map[string]any, len(valueMap))
+	for key, val := range valueMap {
+		newData[key] = val
+	}
+
+	for key := range data {
+		delete(data, key)
+	}
+
+	for key, val := range newData {
+		data[key] = val
+	}
+
+	return nil
+}
+
 // Put updates the branch(es) of a map or a slice of maps as it is described in the provided JSONPath with a new value.
 //
 // The `data` must not be nil. The changes will apply in place.
 //
 // If the path described in the `jsonPath` does not exist then it will be created on the fly. Attibutes referred within an array condition will be ignored.
+// The creation depends on the kind of each node:
+//   - simple nodes, i.e. `hours`, create missing keys as maps.
+//   - indexed nodes, i.e. `items[0]`, create missing keys as arrays and an index equal to the length of an array appends an element to it
+//     whereas a greater index causes an error unless PutWithOptions pads the array.
+//   - wildcard, sliced and filtered nodes, i.e. `items[*]`, `items[1:]` and `items[?(@.open)]`, need an existing array and
+//     the rest of the path is created only within the elements they select.
+//   - nothing is created after recursive descent.
+//
+// The root path `$` replaces the whole content of `data` with the content of `value` which, in that case, must be a map.
+// Writing a scalar, an array or null at the root is not supported: since `data` is a map itself it cannot hold them
+// and changing it in place is the only way the caller sees the change. Such values are rejected with an
+// ErrCodeInvalidOperation error and `data` remains untouched.
 //
 // An error will be returned should anything goes wrong.
 func Put(data map[string]any, jsonPath string, value any) error {
+	return PutWithOptions(data, jsonPath, value, PutOptions{})
+}
+
+// PutMode determines how Put treats the values already found in the paths it updates.
+type PutMode string
+
+const (
+	// PutOverwrite replaces the found values with the new one. It is the default mode.
+	PutOverwrite PutMode = "overwrite"
+
+	// PutAppend appends the new value to the found values which are arrays, i.e. all the `tags` arrays matched by
+	// `$..tags`, and replaces the rest. The path is created as with PutOverwrite if it matches no value.
+	PutAppend PutMode = "append"
+)
+
+// PutOptions holds the options of PutWithOptions.
+type PutOptions struct {
+
+	// Mode determines how the found values are treated. It defaults to PutOverwrite.
+	Mode PutMode
+
+	// PadArrays determines whether an index beyond the length of an array, i.e. `$.items[3]` of an empty array, pads
+	// it with nils up to the index. Such indices cause an error otherwise and nothing is put.
+	PadArrays bool
+}
+
+// PutWithOptions works like Put with the behavior adjusted by the provided options.
+func PutWithOptions(data map[string]any, jsonPath string, value any, opts PutOptions) (err error) {
+	defer func(start time.Time) { metrics.QueryExecuted("put", time.Since(start), err) }(time.Now())
+
+	logger.Debug("Put", "path", jsonPath)
+
 	nodes, err := parseJsonPath(jsonPath)
 	if err != nil {
 		return err
 	}
 
+	if len(nodes) == 0 {
+		return putRoot(data, value)
+	}
+
+	switch opts.Mode {
+	case "", PutOverwrite:
+	case PutAppend:
+		appended, err := putAppend(data, jsonPath, value)
+		if err != nil || appended {
+			return err
+		}
+	default:
+		return &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Unknown put mode '%v'", opts.Mode), Path: jsonPath}
+	}
+
 	if !jsonPathHasReccursiveDescent(jsonPath) && data != nil {
-		ensureDataStrunctureFromNodes(data, nodes)
+		var created []createdKey
+		if created, err = ensureDataStrunctureFromNodes(data, nodes, opts); err != nil {
+			removeCreatedKeys(created)
+			if e, ok := err.(*Error); ok {
+				e.Path = jsonPath
+			}
+			return err
+		}
+
+		// a failed Put should not leave behind the structure created for it
+		defer func() {
+			if err != nil {
+				removeCreatedKeys(created)
+			}
+		}()
 	}
 
 	nodesCount := len(nodes)
@@ -123,24 +589,367 @@ func Put(data map[string]any, jsonPath string, value any) error {
 
 	walkedData, err := walkNodes(data, allButLastNodes)
 	if err != nil {
-		switch err.(dataValidationError).errorType {
-		case dataValidationErrorNotMap, dataValidationErrorValueNotArray:
+		vErr, ok := err.(dataValidationError)
+		if !ok || vErr.errorType != dataValidationErrorKeyNotFound {
 			return err
-		case dataValidationErrorKeyNotFound:
-			walkedData = data
 		}
+		walkedData = data
 	}
 
 	if gu.IsSlice(walkedData) {
-		for _, item := range walkedData.([]any) {
-			if err := lastNode.put(item.(map[string]any), value); err != nil {
+		for _, item := range flattenSlices(walkedData.([]any)) {
+			itemMap, err := asMap(item)
+			if err != nil {
+				return err
+			}
+			if err := lastNode.put(itemMap, value); err != nil {
 				return err
 			}
 		}
 		return nil
 	}
 
-	err = lastNode.put(walkedData.(map[string]any), value)
+	walkedDataMap, err := asMap(walkedData)
+	if err != nil {
+		return err
+	}
+
+	return lastNode.put(walkedDataMap, value)
+}
+
+// putAppend appends the value to the values matched by the JSONPath which are arrays and replaces the rest. It returns
+// false if the JSONPath matches no value.
+func putAppend(data map[string]any, jsonPath string, value any) (bool, error) {
+	matches, err := findMatches(data, jsonPath)
+	if err != nil || len(matches) == 0 {
+		return false, err
+	}
+
+	for _, m := range matches {
+		if items, ok := m.value.([]any); ok {
+			m.set(append(items[:len(items):len(items)], value))
+		} else {
+			m.set(value)
+		}
+	}
+
+	return true, nil
+}
+
+// PutFunc replaces each value matched by the JSONPath with the result of the function called with the value, i.e. to
+// increment all the counters matched by `$..count`. Unlike Put, missing paths are not created.
+//
+// The `data` must not be nil. The changes will apply in place.
+func PutFunc(data map[string]any, jsonPath string, fn func(old any) any) error {
+	return Modify(data, jsonPath, func(old any) (any, error) { return fn(old), nil })
+}
+
+// Modify replaces each value matched by the JSONPath with the result of the function called with the value, i.e. to
+// multiply all the prices matched by `$..price`. Unlike Put, missing paths are not created.
+//
+// The `data` must not be nil. The function is called on all the matched values before any change takes place, so
+// the changes apply in place only if none of the calls fails; otherwise the first error is returned prefixed with the
+// path of the value.
+func Modify(data map[string]any, jsonPath string, fn func(old any) (any, error)) (err error) {
+	defer func(start time.Time) { metrics.QueryExecuted("modify", time.Since(start), err) }(time.Now())
+
+	logger.Debug("Modify", "path", jsonPath)
+
+	matches, err := findMatches(data, jsonPath)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(matches))
+	for i, m := range matches {
+		if values[i], err = fn(m.value); err != nil {
+			return fmt.Errorf("%v: %w", m.path, err)
+		}
+	}
+
+	for i, m := range matches {
+		if m.parent == nil {
+			return putRoot(data, values[i])
+		}
+		m.set(values[i])
+	}
+
+	return nil
+}
+
+// incrementNumber adds the delta to the number keeping the type of the number. Integers remain integers if the delta
+// is integral too, otherwise they become float64. An error is returned if the result overflows the type.
+func incrementNumber(old, delta any) (any, error) {
+	fOld, _ := gu.ToFloat64(old)
+	fDelta, _ := gu.ToFloat64(delta)
+
+	oldValue, deltaValue := reflect.ValueOf(old), reflect.ValueOf(delta)
+	integral := deltaValue.CanInt() || deltaValue.CanUint() || fDelta == math.Trunc(fDelta)
+
+	switch {
+	case oldValue.CanFloat():
+		result := reflect.New(oldValue.Type()).Elem()
+		result.SetFloat(fOld + fDelta)
+		return result.Interface(), nil
+	case (oldValue.CanInt() || oldValue.CanUint()) && integral:
+		sum := new(big.Int).Add(bigInt(oldValue, fOld), bigInt(deltaValue, fDelta))
+		result := reflect.New(oldValue.Type()).Elem()
+		switch {
+		case oldValue.CanInt() && sum.IsInt64() && !result.OverflowInt(sum.Int64()):
+			result.SetInt(sum.Int64())
+		case oldValue.CanUint() && sum.IsUint64() && !result.OverflowUint(sum.Uint64()):
+			result.SetUint(sum.Uint64())
+		default:
+			return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Cannot increment %v by %v since the result overflows %T", old, delta, old)}
+		}
+		return result.Interface(), nil
+	}
+
+	return fOld + fDelta, nil
+}
+
+// bigInt returns the integer held by the value, or the provided integral float if the value is not an integer.
+func bigInt(value reflect.Value, f float64) *big.Int {
+	switch {
+	case value.CanInt():
+		return big.NewInt(value.Int())
+	case value.CanUint():
+		return new(big.Int).SetUint64(value.Uint())
+	}
+
+	i, _ := big.NewFloat(f).Int(nil)
+	return i
+}
+
+// Increment adds the delta, which can be any number, to each number matched by the JSONPath, i.e. to counters, or
+// subtracts it if negative. The numbers keep their type, i.e. an int64 remains an int64, unless they are integers
+// and the delta is not integral, in which case they become float64.
+//
+// If the JSONPath matches no value it is put with the delta as its value, as if it were 0, so it has the type of the
+// delta. An error is returned if the delta or any of the matched values is not a number or if an integer overflows,
+// in which case nothing changes.
+func Increment(data map[string]any, jsonPath string, delta any) error {
+	if !isNumber(delta) {
+		return &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Cannot increment by %#v since it is not a number", delta), Path: jsonPath}
+	}
+
+	matches, err := findMatchesLimit(data, jsonPath, 1)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return Put(data, jsonPath, delta)
+	}
+
+	return Modify(data, jsonPath, func(old any) (any, error) {
+		if !isNumber(old) {
+			return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Cannot increment %#v since it is not a number", old)}
+		}
+
+		return incrementNumber(old, delta)
+	})
+}
+
+// Toggle negates each boolean matched by the JSONPath, i.e. flags. Unlike Increment, missing paths are not created.
+//
+// An error is returned if any of the matched values is not a boolean, in which case nothing changes.
+func Toggle(data map[string]any, jsonPath string) error {
+	return Modify(data, jsonPath, func(old any) (any, error) {
+		b, ok := old.(bool)
+		if !ok {
+			return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Cannot toggle %#v since it is not a boolean", old)}
+		}
+
+		return !b, nil
+	})
+}
+
+// deepCopy returns a copy of the provided value where all nested maps and slices are copied as well.
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, val := range v {
+			result[key] = deepCopy(val)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, val := range v {
+			result[i] = deepCopy(val)
+		}
+		return result
+	}
+
+	return value
+}
+
+// PutMany updates the provided map with multiple values at once, each one described by its own JSONPath.
+//
+// The `data` must not be nil. The paths are applied in lexicographical order.
+//
+// All the JSONPaths are validated, in the same order, before any change takes place. The changes apply in place and
+// if any of them fails the ones applied so far are reverted, so that `data` remains untouched, and the error is
+// returned. Only the objects and the arrays along the paths are copied, shallowly, to be able to revert them.
+func PutMany(data map[string]any, values map[string]any) error {
+	if data == nil {
+		return dataValidationError{data: data, errorType: dataValidationErrorNotMap}
+	}
+
+	jsonPaths := make([]string, 0, len(values))
+	for jsonPath := range values {
+		jsonPaths = append(jsonPaths, jsonPath)
+	}
+	sort.Strings(jsonPaths)
+
+	for _, jsonPath := range jsonPaths {
+		if _, err := parseJsonPath(jsonPath); err != nil {
+			return fmt.Errorf("%v: %w", jsonPath, err)
+		}
+	}
+
+	j := newJournal()
+	for _, jsonPath := range jsonPaths {
+		j.recordPath(data, jsonPath)
+		if err := Put(data, jsonPath, values[jsonPath]); err != nil {
+			j.restore()
+			return fmt.Errorf("%v: %w", jsonPath, err)
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the branch(es) of a map or a slice of maps as it is described in the provided JSONPath.
+//
+// The `data` must not be nil. The changes will apply in place.
+//
+// The last node of the `jsonPath` must be a simple property since removing array elements is not supported.
+// Recursive descent is not allowed either.
+//
+// Deleting a non existing path is not considered an error.
+func Delete(data map[string]any, jsonPath string) (err error) {
+	defer func(start time.Time) { metrics.QueryExecuted("delete", time.Since(start), err) }(time.Now())
+
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) == 0 {
+		return &Error{Code: ErrCodeInvalidOperation, Message: "Root cannot be deleted.", Path: jsonPath}
+	}
 
-	return err
+	if jsonPathHasReccursiveDescent(jsonPath) {
+		return &Error{Code: ErrCodeInvalidPath, Message: "Reccursive descent not allowed in delete path.", Path: jsonPath}
+	}
+
+	allButLastNodes, lastNode := nodes[:len(nodes)-1], nodes[len(nodes)-1]
+	if isArrayNode(lastNode) || lastNode.getName() == "*" {
+		return &Error{Code: ErrCodeInvalidOperation, Message: "Deleting array elements is not supported.", Path: jsonPath}
+	}
+
+	walkedData, err := walkNodes(data, allButLastNodes)
+	if err != nil {
+		if vErr, ok := err.(dataValidationError); ok && vErr.errorType == dataValidationErrorKeyNotFound {
+			return nil
+		}
+		return err
+	}
+
+	for item := range gu.IterAny(walkedData, nil) {
+		if itemMap, ok := item.(map[string]any); ok {
+			delete(itemMap, lastNode.getName())
+		}
+	}
+
+	if walkedDataMap, ok := walkedData.(map[string]any); ok {
+		delete(walkedDataMap, lastNode.getName())
+	}
+
+	return nil
+}
+
+// PruneOptions configures Prune.
+type PruneOptions struct {
+
+	// KeepNulls keeps the null values.
+	KeepNulls bool
+
+	// KeepEmptyObjects keeps the empty objects.
+	KeepEmptyObjects bool
+
+	// KeepEmptyArrays keeps the empty arrays.
+	KeepEmptyArrays bool
+}
+
+// pruned returns whether the value should be removed according to the options.
+func (opts PruneOptions) pruned(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return !opts.KeepNulls
+	case map[string]any:
+		return len(v) == 0 && !opts.KeepEmptyObjects
+	case []any:
+		return len(v) == 0 && !opts.KeepEmptyArrays
+	}
+
+	return false
+}
+
+// pruneValue prunes the nested values of the provided value and returns the result.
+func pruneValue(value any, opts PruneOptions) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			val = pruneValue(val, opts)
+			if opts.pruned(val) {
+				delete(v, key)
+			} else {
+				v[key] = val
+			}
+		}
+		return v
+	case []any:
+		result := make([]any, 0, len(v))
+		for _, val := range v {
+			val = pruneValue(val, opts)
+			if !opts.pruned(val) {
+				result = append(result, val)
+			}
+		}
+		return result
+	}
+
+	return value
+}
+
+// Prune removes recursively the nulls, the empty objects and the empty arrays from the provided map, unless
+// the options say otherwise. Objects and arrays which become empty after pruning are removed as well.
+//
+// The changes will apply in place. Array elements are removed too so the indices of the remaining ones may change.
+// The root itself is never removed.
+func Prune(data map[string]any, opts PruneOptions) {
+	pruneValue(data, opts)
+}
+
+// ApplyDefaults deep-merges the defaults into the provided map in place, only where keys are missing or null, so that
+// the code reading it can assume the fields exist. Nested objects are merged key by key whereas any other existing
+// value, including arrays, is kept as it is.
+//
+// The default values are copied so the defaults can be reused.
+func ApplyDefaults(data map[string]any, defaults map[string]any) {
+	for key, defaultValue := range defaults {
+		value, ok := data[key]
+		if !ok || value == nil {
+			data[key] = deepCopy(defaultValue)
+			continue
+		}
+
+		valueMap, ok := value.(map[string]any)
+		defaultMap, defaultOk := defaultValue.(map[string]any)
+		if ok && defaultOk {
+			ApplyDefaults(valueMap, defaultMap)
+		}
+	}
 }
@@ -35,6 +35,14 @@ func TestSplitJsonPath(t *testing.T) {
 			jsonPath:       "$.library.books[?(@.price < 10)]",
 			expectedTokens: []string{"$", "library", "books[?(@.price < 10)]"},
 		},
+		{
+			jsonPath:       "$.library.books[?(@.lat == 45.4422445)].title",
+			expectedTokens: []string{"$", "library", "books[?(@.lat == 45.4422445)]", "title"},
+		},
+		{
+			jsonPath:       "$.events[?(@.at > '2023.01.01')]",
+			expectedTokens: []string{"$", "events[?(@.at > '2023.01.01')]"},
+		},
 		{
 			jsonPath:       "$..books",
 			expectedTokens: []string{"$", "", "books"},
@@ -243,6 +251,20 @@ type GetTestCase struct {
 
 func TestGet(t *testing.T) {
 	testCases := []GetTestCase{
+		{
+			jsonPath: "$",
+			data: map[string]any{
+				"books": []any{
+					map[string]any{"author": "Nietzsche", "title": "Book1"},
+				},
+			},
+			expectedErrorMessage: "",
+			expectedData: map[string]any{
+				"books": []any{
+					map[string]any{"author": "Nietzsche", "title": "Book1"},
+				},
+			},
+		},
 		{
 			jsonPath: ".books",
 			data: map[string]any{
@@ -725,6 +747,126 @@ type PutTestCase struct {
 
 func TestPut(t *testing.T) {
 	testCases := []PutTestCase{
+		{
+			jsonPath: "$",
+			data: map[string]any{
+				"book": map[string]any{"author": "Someone"},
+			},
+			value:                map[string]any{"author": "Someone else"},
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"author": "Someone else",
+			},
+		},
+		{
+			jsonPath: "$",
+			data: map[string]any{
+				"book": map[string]any{"author": "Someone"},
+			},
+			value:                "Someone else",
+			expectedErrorMessage: "Root value should be a map, got string",
+			expectedUpdatedData: map[string]any{
+				"book": map[string]any{"author": "Someone"},
+			},
+		},
+		{
+			jsonPath: "$.books.info.isbn",
+			data: map[string]any{
+				"books": []any{
+					map[string]any{"title": "Book1"},
+					map[string]any{"title": "Book2", "info": map[string]any{"pages": 100}},
+				},
+			},
+			value:                "123",
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"books": []any{
+					map[string]any{"title": "Book1", "info": map[string]any{"isbn": "123"}},
+					map[string]any{"title": "Book2", "info": map[string]any{"pages": 100, "isbn": "123"}},
+				},
+			},
+		},
+		{
+			jsonPath: "$.groups[1].members[0].name",
+			data: map[string]any{
+				"groups": []any{
+					map[string]any{"members": []any{map[string]any{"name": "A"}}},
+					map[string]any{"members": []any{map[string]any{"name": "B"}, map[string]any{"name": "C"}}},
+				},
+			},
+			value:                "D",
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"groups": []any{
+					map[string]any{"members": []any{map[string]any{"name": "A"}}},
+					map[string]any{"members": []any{map[string]any{"name": "D"}, map[string]any{"name": "C"}}},
+				},
+			},
+		},
+		{
+			jsonPath: "$.stores[?(@.open == true)].hours.monday",
+			data: map[string]any{
+				"stores": []any{
+					map[string]any{"open": true},
+					map[string]any{"open": false},
+					map[string]any{"name": "Store3"},
+				},
+			},
+			value:                "9-17",
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"stores": []any{
+					map[string]any{"open": true, "hours": map[string]any{"monday": "9-17"}},
+					map[string]any{"open": false},
+					map[string]any{"name": "Store3"},
+				},
+			},
+		},
+		{
+			jsonPath: "$.stores[*].hours.monday",
+			data: map[string]any{
+				"stores": []any{
+					map[string]any{"open": true},
+					map[string]any{"hours": map[string]any{"sunday": "closed"}},
+				},
+			},
+			value:                "9-17",
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"stores": []any{
+					map[string]any{"open": true, "hours": map[string]any{"monday": "9-17"}},
+					map[string]any{"hours": map[string]any{"sunday": "closed", "monday": "9-17"}},
+				},
+			},
+		},
+		{
+			jsonPath: "$.stores[1:].hours[0].from",
+			data: map[string]any{
+				"stores": []any{
+					map[string]any{},
+					map[string]any{},
+				},
+			},
+			value:                9,
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"stores": []any{
+					map[string]any{},
+					map[string]any{"hours": []any{map[string]any{"from": 9}}},
+				},
+			},
+		},
+		{
+			jsonPath: "$.store.books[*].price",
+			data: map[string]any{
+				"author": "Nietzsche",
+			},
+			value:                1,
+			expectedErrorMessage: "dataValidationError: Value of key 'books' is not an array: map[string]interface {}{}",
+			expectedUpdatedData: map[string]any{
+				"author": "Nietzsche",
+			},
+		},
 		{
 			jsonPath: ".books",
 			data: map[string]any{
@@ -1099,3 +1241,1000 @@ func TestPut(t *testing.T) {
 		})
 	}
 }
+
+func TestPutRootRejectsNonObjects(t *testing.T) {
+	values := []any{"Someone else", 42, true, nil, []any{map[string]any{"author": "Someone else"}}}
+
+	for i, value := range values {
+		t.Run(fmt.Sprintf("(%v) - Put($, %#v)", i, value), func(t *testing.T) {
+			data := map[string]any{"book": map[string]any{"author": "Someone"}}
+
+			err := Put(data, "$", value)
+			if ErrorCode(err) != ErrCodeInvalidOperation {
+				t.Errorf("Expected code '%v', but got '%v'", ErrCodeInvalidOperation, ErrorCode(err))
+			}
+			if expected := fmt.Sprintf("Root value should be a map, got %T", value); err == nil || err.Error() != expected {
+				t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+			}
+
+			expectedData := map[string]any{"book": map[string]any{"author": "Someone"}}
+			if !cmp.Equal(expectedData, data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(data))
+			}
+		})
+	}
+}
+
+type PutWithOptionsTestCase struct {
+	jsonPath             string
+	data                 map[string]any
+	opts                 PutOptions
+	expectedErrorMessage string
+	expectedUpdatedData  map[string]any
+}
+
+func TestPutWithOptions(t *testing.T) {
+	testCases := []PutWithOptionsTestCase{
+		{
+			jsonPath:            "$.items[0].name",
+			data:                map[string]any{},
+			expectedUpdatedData: map[string]any{"items": []any{map[string]any{"name": "X"}}},
+		},
+		{
+			jsonPath:            "$.items[0, 1]",
+			data:                map[string]any{},
+			expectedUpdatedData: map[string]any{"items": []any{"X", "X"}},
+		},
+		{
+			jsonPath:            "$.items[1].name",
+			data:                map[string]any{"items": []any{map[string]any{"name": "A"}}},
+			expectedUpdatedData: map[string]any{"items": []any{map[string]any{"name": "A"}, map[string]any{"name": "X"}}},
+		},
+		{
+			jsonPath:             "$.items[2]",
+			data:                 map[string]any{},
+			expectedErrorMessage: "Index 2 is beyond the length 0 of 'items'",
+			expectedUpdatedData:  map[string]any{},
+		},
+		{
+			jsonPath:             "$.items[2].name",
+			data:                 map[string]any{"items": []any{"A"}},
+			expectedErrorMessage: "Index 2 is beyond the length 1 of 'items'",
+			expectedUpdatedData:  map[string]any{"items": []any{"A"}},
+		},
+		{
+			jsonPath:             "$.rows[0].cells[3]",
+			data:                 map[string]any{},
+			expectedErrorMessage: "Index 3 is beyond the length 0 of 'cells'",
+			expectedUpdatedData:  map[string]any{},
+		},
+		{
+			jsonPath:            "$.items[2]",
+			data:                map[string]any{},
+			opts:                PutOptions{PadArrays: true},
+			expectedUpdatedData: map[string]any{"items": []any{nil, nil, "X"}},
+		},
+		{
+			jsonPath: "$.rows[1].cells[2]",
+			data:     map[string]any{"rows": []any{map[string]any{"cells": []any{1}}}},
+			opts:     PutOptions{PadArrays: true},
+			expectedUpdatedData: map[string]any{"rows": []any{
+				map[string]any{"cells": []any{1}},
+				map[string]any{"cells": []any{nil, nil, "X"}},
+			}},
+		},
+		{
+			jsonPath: "$..tags",
+			data: map[string]any{
+				"a": map[string]any{"tags": []any{"A"}},
+				"b": map[string]any{"tags": "B"},
+				"c": map[string]any{},
+			},
+			opts: PutOptions{Mode: PutAppend},
+			expectedUpdatedData: map[string]any{
+				"a": map[string]any{"tags": []any{"A", "X"}},
+				"b": map[string]any{"tags": "X"},
+				"c": map[string]any{},
+			},
+		},
+		{
+			jsonPath:            "$.book.tags",
+			data:                map[string]any{},
+			opts:                PutOptions{Mode: PutAppend},
+			expectedUpdatedData: map[string]any{"book": map[string]any{"tags": "X"}},
+		},
+		{
+			jsonPath:             "$.book.tags",
+			data:                 map[string]any{},
+			opts:                 PutOptions{Mode: "merge"},
+			expectedErrorMessage: "Unknown put mode 'merge'",
+			expectedUpdatedData:  map[string]any{},
+		},
+		{
+			jsonPath:             "$.items[0].tags[*].name",
+			data:                 map[string]any{},
+			expectedErrorMessage: "dataValidationError: Value of key 'tags' is not an array: map[string]interface {}{}",
+			expectedUpdatedData:  map[string]any{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - PutWithOptions(%v, %v, %+v)=%v", i, tc.data, tc.jsonPath, tc.opts, tc.expectedErrorMessage), func(t *testing.T) {
+			err := PutWithOptions(tc.data, tc.jsonPath, "X", tc.opts)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedUpdatedData, tc.data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedUpdatedData), gu.Prettify(tc.data))
+			}
+		})
+	}
+}
+
+func TestPutFunc(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"visits": map[string]any{"count": 1},
+			"books": []any{
+				map[string]any{"title": "Book1", "count": 2},
+				map[string]any{"title": "Book2"},
+			},
+		},
+	}
+
+	err := PutFunc(data, "$..count", func(old any) any { return old.(int) + 1 })
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	expectedData := map[string]any{
+		"store": map[string]any{
+			"visits": map[string]any{"count": 2},
+			"books": []any{
+				map[string]any{"title": "Book1", "count": 3},
+				map[string]any{"title": "Book2"},
+			},
+		},
+	}
+	if !cmp.Equal(expectedData, data) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(data))
+	}
+
+	if err := PutFunc(data, "$.store.", func(old any) any { return old }); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestModify(t *testing.T) {
+	data := map[string]any{
+		"books": []any{
+			map[string]any{"title": "Book1", "price": 10.0},
+			map[string]any{"title": "Book2", "price": 20.0},
+		},
+		"shipping": map[string]any{"price": 5.0},
+	}
+	original := deepCopy(data)
+
+	double := func(old any) (any, error) {
+		price, ok := old.(float64)
+		if !ok {
+			return nil, fmt.Errorf("Price is not a number: %v", old)
+		}
+		return price * 2, nil
+	}
+
+	data["shipping"].(map[string]any)["price"] = "free"
+	err := Modify(data, "$..price", double)
+	if expected := "$.shipping.price: Price is not a number: free"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+	}
+	if !cmp.Equal(original.(map[string]any)["books"], data["books"]) {
+		t.Errorf("Expected no change, but got '%#s'", gu.Prettify(data))
+	}
+
+	data["shipping"].(map[string]any)["price"] = 5.0
+	if err := Modify(data, "$..price", double); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	expectedData := map[string]any{
+		"books": []any{
+			map[string]any{"title": "Book1", "price": 20.0},
+			map[string]any{"title": "Book2", "price": 40.0},
+		},
+		"shipping": map[string]any{"price": 10.0},
+	}
+	if !cmp.Equal(expectedData, data) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(data))
+	}
+}
+
+type IncrementTestCase struct {
+	jsonPath             string
+	delta                any
+	data                 map[string]any
+	expectedErrorMessage string
+	expectedUpdatedData  map[string]any
+}
+
+func TestIncrement(t *testing.T) {
+	testCases := []IncrementTestCase{
+		{
+			jsonPath:            "$.visits",
+			delta:               1,
+			data:                map[string]any{"visits": 41},
+			expectedUpdatedData: map[string]any{"visits": 42},
+		},
+		{
+			jsonPath:            "$.visits",
+			delta:               0.5,
+			data:                map[string]any{"visits": 41},
+			expectedUpdatedData: map[string]any{"visits": 41.5},
+		},
+		{
+			jsonPath:            "$..stock",
+			delta:               -1,
+			data:                map[string]any{"books": []any{map[string]any{"stock": 2.0}, map[string]any{"stock": 5.0}}},
+			expectedUpdatedData: map[string]any{"books": []any{map[string]any{"stock": 1.0}, map[string]any{"stock": 4.0}}},
+		},
+		{
+			jsonPath:            "$.stats.visits",
+			delta:               1,
+			data:                map[string]any{},
+			expectedUpdatedData: map[string]any{"stats": map[string]any{"visits": 1}},
+		},
+		{
+			jsonPath:            "$.stats.visits",
+			delta:               int64(2),
+			data:                map[string]any{},
+			expectedUpdatedData: map[string]any{"stats": map[string]any{"visits": int64(2)}},
+		},
+		{
+			jsonPath:            "$.visits",
+			delta:               1.0,
+			data:                map[string]any{"visits": int64(41)},
+			expectedUpdatedData: map[string]any{"visits": int64(42)},
+		},
+		{
+			jsonPath:            "$.visits",
+			delta:               -2,
+			data:                map[string]any{"visits": uint16(2)},
+			expectedUpdatedData: map[string]any{"visits": uint16(0)},
+		},
+		{
+			jsonPath:            "$.ratio",
+			delta:               1,
+			data:                map[string]any{"ratio": float32(0.5)},
+			expectedUpdatedData: map[string]any{"ratio": float32(1.5)},
+		},
+		{
+			jsonPath:             "$.visits",
+			delta:                -3,
+			data:                 map[string]any{"visits": uint16(2)},
+			expectedErrorMessage: "$.visits: Cannot increment 2 by -3 since the result overflows uint16",
+			expectedUpdatedData:  map[string]any{"visits": uint16(2)},
+		},
+		{
+			jsonPath:             "$.visits",
+			delta:                1,
+			data:                 map[string]any{"visits": int8(127)},
+			expectedErrorMessage: "$.visits: Cannot increment 127 by 1 since the result overflows int8",
+			expectedUpdatedData:  map[string]any{"visits": int8(127)},
+		},
+		{
+			jsonPath:             "$.visits",
+			delta:                "1",
+			data:                 map[string]any{"visits": 1},
+			expectedErrorMessage: "Cannot increment by \"1\" since it is not a number",
+			expectedUpdatedData:  map[string]any{"visits": 1},
+		},
+		{
+			jsonPath:             "$..visits",
+			delta:                1,
+			data:                 map[string]any{"a": map[string]any{"visits": 1}, "b": map[string]any{"visits": "many"}},
+			expectedErrorMessage: "$.b.visits: Cannot increment \"many\" since it is not a number",
+			expectedUpdatedData:  map[string]any{"a": map[string]any{"visits": 1}, "b": map[string]any{"visits": "many"}},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Increment(%v, %v, %v)=%v", i, tc.data, tc.jsonPath, tc.delta, tc.expectedErrorMessage), func(t *testing.T) {
+			err := Increment(tc.data, tc.jsonPath, tc.delta)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedUpdatedData, tc.data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedUpdatedData), gu.Prettify(tc.data))
+			}
+		})
+	}
+}
+
+func TestToggle(t *testing.T) {
+	data := map[string]any{
+		"features": map[string]any{"search": true, "export": false},
+		"name":     "config",
+	}
+
+	if err := Toggle(data, "$.features.search"); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	if err := Toggle(data, "$.features.missing"); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	expectedData := map[string]any{
+		"features": map[string]any{"search": false, "export": false},
+		"name":     "config",
+	}
+	if !cmp.Equal(expectedData, data) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(data))
+	}
+
+	err := Toggle(data, "$.name")
+	if expected := "$.name: Cannot toggle \"config\" since it is not a boolean"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+	}
+}
+
+func TestGetDoesNotMutateData(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": 15},
+				map[string]any{"author": "Stirner", "price": 5},
+				"unknown",
+			},
+		},
+	}
+	original := deepCopy(data)
+
+	jsonPaths := []string{
+		"$.store.books[*].author",
+		"$.store.books[0,1].price",
+		"$.store.books[1:].author",
+		"$.store.books[?(@.price > 10)].author",
+		"$..author",
+		"$..books[0]",
+		"$.store.books.isbn",
+		"$.store.shelves[0].author",
+	}
+
+	for _, jsonPath := range jsonPaths {
+		t.Run(fmt.Sprintf("Get(%v)", jsonPath), func(t *testing.T) {
+			Get(data, jsonPath)
+			if !cmp.Equal(original, data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(original), gu.Prettify(data))
+			}
+		})
+	}
+}
+
+func TestGetWithOptionsReadOnly(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": 15},
+			},
+		},
+	}
+	original := deepCopy(data)
+
+	result, err := GetWithOptions(data, "$.store.books", GetOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	result.([]any)[0].(map[string]any)["price"] = 5
+	if !cmp.Equal(original, data) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(original), gu.Prettify(data))
+	}
+
+	result, _ = GetWithOptions(data, "$.store.books", GetOptions{})
+	result.([]any)[0].(map[string]any)["price"] = 5
+	if cmp.Equal(original, data) {
+		t.Errorf("Expected the data to share the retrieved value")
+	}
+}
+
+type GetLimitTestCase struct {
+	jsonPath       string
+	limit          int
+	expectedResult any
+}
+
+func TestGetWithOptionsLimit(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": 15},
+				map[string]any{"author": "Stirner"},
+				map[string]any{"author": "Nietzsche", "price": 20},
+			},
+			"name": "Store",
+		},
+	}
+
+	testCases := []GetLimitTestCase{
+		{"$.store.books.price", 1, []any{15}},
+		{"$.store.books.price", 5, []any{15, 20}},
+		{"$.store.books[?(@.author == Nietzsche)]", 1, []any{map[string]any{"author": "Nietzsche", "price": 15}}},
+		{"$..author", 2, []any{"Nietzsche", "Stirner"}},
+		{"$.store.name", 1, []any{"Store"}},
+		{"$.store.missing", 1, []any{}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - GetWithOptions(%v, Limit: %v)=%v", i, tc.jsonPath, tc.limit, tc.expectedResult), func(t *testing.T) {
+			result, err := GetWithOptions(data, tc.jsonPath, GetOptions{Limit: tc.limit})
+			if err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestGetWithOptionsLimitMatchesGet(t *testing.T) {
+	data := map[string]any{
+		"a": map[string]any{"price": map[string]any{"price": 1}, "books": []any{"A1", "A2"}},
+		"b": map[string]any{"price": []any{2, []any{3}}, "books": []any{"B1"}},
+		"c": []any{map[string]any{"price": 4, "name": map[string]any{"price": 5}}},
+	}
+
+	jsonPaths := []string{"$..price", "$.a..price.price", "$..books", "$..books[0]", "$..books[1:]", "$.a..price", "$.c.price"}
+
+	for i, jsonPath := range jsonPaths {
+		t.Run(fmt.Sprintf("(%v) - GetWithOptions(%v, Limit)", i, jsonPath), func(t *testing.T) {
+			expected, err := Get(data, jsonPath)
+			if err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+
+			result, err := GetWithOptions(data, jsonPath, GetOptions{Limit: 100})
+			if err != nil || !cmp.Equal(expected, result) {
+				t.Errorf("Expected '%v', but got '%v' (%v)", expected, result, err)
+			}
+
+			first, err := GetFirst(data, jsonPath)
+			if err != nil || !cmp.Equal(expected.([]any)[0], first) {
+				t.Errorf("Expected '%v', but got '%v' (%v)", expected.([]any)[0], first, err)
+			}
+		})
+	}
+}
+
+func TestGetFirst(t *testing.T) {
+	data := map[string]any{
+		"books": []any{
+			map[string]any{"author": "Stirner"},
+			map[string]any{"author": "Nietzsche", "price": 15},
+		},
+	}
+
+	price, err := GetFirst(data, "$.books.price")
+	if err != nil || price != 15 {
+		t.Errorf("Expected '15', but got '%v' (%v)", price, err)
+	}
+
+	_, err = GetFirst(data, "$.books.isbn")
+	if expected := "No value matched."; err == nil || err.Error() != expected {
+		t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+	}
+}
+
+func TestGetAppend(t *testing.T) {
+	data := map[string]any{
+		"books": []any{
+			map[string]any{"author": "Stirner"},
+			map[string]any{"author": "Nietzsche", "price": 15},
+			"not a book",
+		},
+	}
+
+	buf := make([]any, 0, 8)
+
+	buf, err := GetAppend(buf, data, "$.books.author")
+	if expected := []any{"Stirner", "Nietzsche"}; err != nil || !cmp.Equal(expected, buf) {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, buf, err)
+	}
+
+	buf, err = GetAppend(buf, data, "$.books[1].price")
+	if expected := []any{"Stirner", "Nietzsche", 15}; err != nil || !cmp.Equal(expected, buf) {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, buf, err)
+	}
+
+	first := &buf[0]
+	buf, err = GetAppend(buf[:0], data, "$.books[*].price")
+	if expected := []any{15}; err != nil || !cmp.Equal(expected, buf) {
+		t.Errorf("Expected '%v', but got '%v' (%v)", expected, buf, err)
+	}
+	if &buf[0] != first {
+		t.Errorf("Expected the buffer to be reused")
+	}
+
+	buf, err = GetAppend(buf, data, "$.books.")
+	if expected := "JSONPath should not end with '.'"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+	}
+	if expected := []any{15}; !cmp.Equal(expected, buf) {
+		t.Errorf("Expected '%v', but got '%v'", expected, buf)
+	}
+
+	// nested keys of the same name are not matched below a match, as with Get
+	nested := map[string]any{
+		"a": map[string]any{"price": map[string]any{"price": 1}},
+		"b": map[string]any{"price": []any{2, 3}},
+	}
+	for _, jsonPath := range []string{"$..price", "$..price[1]", "$.a..price"} {
+		expected, _ := Get(nested, jsonPath)
+		buf, err = GetAppend(buf[:0], nested, jsonPath)
+		if err != nil || !cmp.Equal(expected, buf) {
+			t.Errorf("%v: expected '%v', but got '%v' (%v)", jsonPath, expected, buf, err)
+		}
+	}
+}
+
+type GetFirstOfTestCase struct {
+	jsonPaths            []string
+	expectedResult       any
+	expectedErrorMessage string
+}
+
+func TestGetFirstOf(t *testing.T) {
+	data := map[string]any{
+		"account": map[string]any{"email": "f@example.com", "phone": nil},
+		"user":    map[string]any{"name": "Friedrich"},
+	}
+
+	testCases := []GetFirstOfTestCase{
+		{jsonPaths: []string{"$.user.email", "$.account.email"}, expectedResult: "f@example.com"},
+		{jsonPaths: []string{"$.user.name", "$.account.email"}, expectedResult: "Friedrich"},
+		{jsonPaths: []string{"$.user.phone", "$.account.phone", "$.user.name"}, expectedResult: nil},
+		{jsonPaths: []string{"$.user.email", "$.contact.email"}, expectedErrorMessage: "None of the paths $.user.email, $.contact.email exists."},
+		{jsonPaths: []string{"$.user.name", "$.user."}, expectedErrorMessage: "JSONPath should not end with '.'"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - GetFirstOf(%v)=%v", i, tc.jsonPaths, tc.expectedResult), func(t *testing.T) {
+			result, err := GetFirstOf(data, tc.jsonPaths...)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+type GetOrTestCase struct {
+	jsonPath             string
+	expectedResult       any
+	expectedErrorMessage string
+}
+
+func TestGetOr(t *testing.T) {
+	data := map[string]any{
+		"server": map[string]any{"host": "localhost", "timeout": nil},
+	}
+
+	testCases := []GetOrTestCase{
+		{jsonPath: "$.server.host", expectedResult: "localhost"},
+		{jsonPath: "$.server.port", expectedResult: 80},
+		{jsonPath: "$.server.host.name", expectedResult: 80},
+		{jsonPath: "$.server.timeout", expectedResult: nil},
+		{jsonPath: "$.server.", expectedErrorMessage: "JSONPath should not end with '.'"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - GetOr(%v)=%v", i, tc.jsonPath, tc.expectedResult), func(t *testing.T) {
+			result, err := GetOr(data, tc.jsonPath, 80)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestGetOrZero(t *testing.T) {
+	data := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080, "timeout": nil},
+	}
+
+	if host, err := GetOrZero[string](data, "$.server.host"); err != nil || host != "localhost" {
+		t.Errorf("Expected 'localhost', but got '%v' (%v)", host, err)
+	}
+	if port, err := GetOrZero[int](data, "$.server.port"); err != nil || port != 8080 {
+		t.Errorf("Expected '8080', but got '%v' (%v)", port, err)
+	}
+	if user, err := GetOrZero[string](data, "$.server.user"); err != nil || user != "" {
+		t.Errorf("Expected '', but got '%v' (%v)", user, err)
+	}
+	if timeout, err := GetOrZero[float64](data, "$.server.timeout"); err != nil || timeout != 0 {
+		t.Errorf("Expected '0', but got '%v' (%v)", timeout, err)
+	}
+
+	_, err := GetOrZero[bool](data, "$.server.port")
+	if expected := "Value 8080 is not of type bool"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+	}
+}
+
+type PutManyTestCase struct {
+	data                 map[string]any
+	values               map[string]any
+	expectedErrorMessage string
+	expectedUpdatedData  map[string]any
+}
+
+func TestPutMany(t *testing.T) {
+	testCases := []PutManyTestCase{
+		{
+			data: map[string]any{
+				"book": map[string]any{"author": "Someone"},
+			},
+			values: map[string]any{
+				"$.book.author": "Someone else",
+				"$.book.title":  "Book1",
+				"$.store.name":  "Store1",
+			},
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"book":  map[string]any{"author": "Someone else", "title": "Book1"},
+				"store": map[string]any{"name": "Store1"},
+			},
+		},
+		{
+			data: map[string]any{
+				"book": map[string]any{"author": "Someone"},
+			},
+			values: map[string]any{
+				"$.book.author": "Someone else",
+				"book.title":    "Book1",
+			},
+			expectedErrorMessage: "book.title: JSONPath should start with '$.'",
+			expectedUpdatedData: map[string]any{
+				"book": map[string]any{"author": "Someone"},
+			},
+		},
+		{
+			data: map[string]any{
+				"book":  map[string]any{"author": "Someone"},
+				"store": "Store1",
+			},
+			values: map[string]any{
+				"$.book.author":    "Someone else",
+				"$.store[0].title": "Book1",
+			},
+			expectedErrorMessage: "$.store[0].title: dataValidationError: Value of key 'store' is not an array: \"Store1\"",
+			expectedUpdatedData: map[string]any{
+				"book":  map[string]any{"author": "Someone"},
+				"store": "Store1",
+			},
+		},
+		{
+			data: map[string]any{
+				"book":  map[string]any{"author": "Someone", "tags": []any{"a", "b"}},
+				"store": "Store1",
+			},
+			values: map[string]any{
+				"$.book.author":   "Someone else",
+				"$.book.tags[*]":  "c",
+				"$.book.x.y":      1,
+				"$.store[0].name": "Book1",
+			},
+			expectedErrorMessage: "$.store[0].name: dataValidationError: Value of key 'store' is not an array: \"Store1\"",
+			expectedUpdatedData: map[string]any{
+				"book":  map[string]any{"author": "Someone", "tags": []any{"a", "b"}},
+				"store": "Store1",
+			},
+		},
+		{
+			data: map[string]any{},
+			values: map[string]any{
+				"$.c": 1,
+				"b.":  2,
+				"a.":  3,
+			},
+			expectedErrorMessage: "a.: JSONPath should start with '$.'",
+			expectedUpdatedData:  map[string]any{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - PutMany(%v, %v)=%v", i, tc.data, tc.values, tc.expectedErrorMessage), func(t *testing.T) {
+			err := PutMany(tc.data, tc.values)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedUpdatedData, tc.data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedUpdatedData), gu.Prettify(tc.data))
+			}
+		})
+	}
+}
+
+func TestPutManyInPlace(t *testing.T) {
+	book := map[string]any{"author": "Someone"}
+	data := map[string]any{"book": book, "store": map[string]any{"name": "Store1"}}
+
+	if err := PutMany(data, map[string]any{"$.book.title": "Book1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if book["title"] != "Book1" {
+		t.Errorf("Expected the objects of the data to be updated in place, but got '%v'", book)
+	}
+
+	err := PutMany(data, map[string]any{"$.book.author": "Someone else", "$.store[0]": "Store2"})
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+	if expected := (map[string]any{"author": "Someone", "title": "Book1"}); !cmp.Equal(expected, book) || !cmp.Equal(expected, data["book"]) {
+		t.Errorf("Expected '%v' to be restored in place, but got '%v'", expected, book)
+	}
+	if objectID(data["book"].(map[string]any)) != objectID(book) {
+		t.Errorf("Expected the objects of the data to be kept")
+	}
+}
+
+type DeleteTestCase struct {
+	jsonPath             string
+	data                 map[string]any
+	expectedErrorMessage string
+	expectedUpdatedData  map[string]any
+}
+
+func TestDelete(t *testing.T) {
+	testCases := []DeleteTestCase{
+		{
+			jsonPath:             "$",
+			data:                 map[string]any{"book": map[string]any{"author": "Someone"}},
+			expectedErrorMessage: "Root cannot be deleted.",
+			expectedUpdatedData:  map[string]any{"book": map[string]any{"author": "Someone"}},
+		},
+		{
+			jsonPath:             "$..author",
+			data:                 map[string]any{"book": map[string]any{"author": "Someone"}},
+			expectedErrorMessage: "Reccursive descent not allowed in delete path.",
+			expectedUpdatedData:  map[string]any{"book": map[string]any{"author": "Someone"}},
+		},
+		{
+			jsonPath:             "$.books[0]",
+			data:                 map[string]any{"books": []any{"Book1"}},
+			expectedErrorMessage: "Deleting array elements is not supported.",
+			expectedUpdatedData:  map[string]any{"books": []any{"Book1"}},
+		},
+		{
+			jsonPath:             "$.book.author",
+			data:                 map[string]any{"book": map[string]any{"author": "Someone", "title": "Book1"}},
+			expectedErrorMessage: "",
+			expectedUpdatedData:  map[string]any{"book": map[string]any{"title": "Book1"}},
+		},
+		{
+			jsonPath:             "$.store.book.author",
+			data:                 map[string]any{"book": map[string]any{"author": "Someone"}},
+			expectedErrorMessage: "",
+			expectedUpdatedData:  map[string]any{"book": map[string]any{"author": "Someone"}},
+		},
+		{
+			jsonPath: "$.books[?(@.price > 10)].author",
+			data: map[string]any{
+				"books": []any{
+					map[string]any{"author": "Nietzsche", "price": 15},
+					map[string]any{"author": "Stirner", "price": 5},
+				},
+			},
+			expectedErrorMessage: "",
+			expectedUpdatedData: map[string]any{
+				"books": []any{
+					map[string]any{"price": 15},
+					map[string]any{"author": "Stirner", "price": 5},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Delete(%v, %v)=%v", i, tc.data, tc.jsonPath, tc.expectedErrorMessage), func(t *testing.T) {
+			err := Delete(tc.data, tc.jsonPath)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedUpdatedData, tc.data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedUpdatedData), gu.Prettify(tc.data))
+			}
+		})
+	}
+}
+
+type PruneTestCase struct {
+	data                map[string]any
+	opts                PruneOptions
+	expectedUpdatedData map[string]any
+}
+
+func TestPrune(t *testing.T) {
+	data := func() map[string]any {
+		return map[string]any{
+			"title":   "Book1",
+			"price":   0,
+			"author":  nil,
+			"tags":    []any{},
+			"meta":    map[string]any{},
+			"store":   map[string]any{"address": map[string]any{"city": nil}, "open": false},
+			"reviews": []any{nil, map[string]any{"rating": nil}, map[string]any{"rating": 5}, []any{}, ""},
+		}
+	}
+
+	testCases := []PruneTestCase{
+		{
+			data: data(),
+			opts: PruneOptions{},
+			expectedUpdatedData: map[string]any{
+				"title":   "Book1",
+				"price":   0,
+				"store":   map[string]any{"open": false},
+				"reviews": []any{map[string]any{"rating": 5}, ""},
+			},
+		},
+		{
+			data: data(),
+			opts: PruneOptions{KeepNulls: true},
+			expectedUpdatedData: map[string]any{
+				"title":   "Book1",
+				"price":   0,
+				"author":  nil,
+				"store":   map[string]any{"address": map[string]any{"city": nil}, "open": false},
+				"reviews": []any{nil, map[string]any{"rating": nil}, map[string]any{"rating": 5}, ""},
+			},
+		},
+		{
+			data: data(),
+			opts: PruneOptions{KeepEmptyObjects: true, KeepEmptyArrays: true},
+			expectedUpdatedData: map[string]any{
+				"title":   "Book1",
+				"price":   0,
+				"tags":    []any{},
+				"meta":    map[string]any{},
+				"store":   map[string]any{"address": map[string]any{}, "open": false},
+				"reviews": []any{map[string]any{}, map[string]any{"rating": 5}, []any{}, ""},
+			},
+		},
+		{
+			data:                map[string]any{"book": map[string]any{"author": nil}},
+			opts:                PruneOptions{},
+			expectedUpdatedData: map[string]any{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Prune(%v, %+v)", i, tc.data, tc.opts), func(t *testing.T) {
+			Prune(tc.data, tc.opts)
+			if !cmp.Equal(tc.expectedUpdatedData, tc.data) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedUpdatedData), gu.Prettify(tc.data))
+			}
+		})
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	defaults := map[string]any{
+		"port":    8080,
+		"host":    "localhost",
+		"tags":    []any{"default"},
+		"tls":     map[string]any{"enabled": false, "cert": "cert.pem"},
+		"logging": map[string]any{"level": "info", "outputs": []any{"stdout"}},
+		"timeout": "5s",
+	}
+	data := map[string]any{
+		"port":    9090,
+		"host":    nil,
+		"tags":    []any{},
+		"tls":     map[string]any{"enabled": true},
+		"logging": "verbose",
+	}
+
+	ApplyDefaults(data, defaults)
+
+	expectedData := map[string]any{
+		"port":    9090,
+		"host":    "localhost",
+		"tags":    []any{},
+		"tls":     map[string]any{"enabled": true, "cert": "cert.pem"},
+		"logging": "verbose",
+		"timeout": "5s",
+	}
+	if !cmp.Equal(expectedData, data) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedData), gu.Prettify(data))
+	}
+
+	data = map[string]any{}
+	ApplyDefaults(data, defaults)
+	data["logging"].(map[string]any)["level"] = "debug"
+	if level := defaults["logging"].(map[string]any)["level"]; level != "info" {
+		t.Errorf("Expected the defaults to remain untouched, but got level '%v'", level)
+	}
+}
+
+type GetWithTraceTestCase struct {
+	jsonPath             string
+	expectedData         any
+	expectedSteps        []TraceStep
+	expectedErrorMessage string
+}
+
+func TestGetWithTrace(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": 15},
+				map[string]any{"author": "Stirner", "price": 5},
+			},
+		},
+	}
+
+	testCases := []GetWithTraceTestCase{
+		{
+			jsonPath:     "$.store.books[?(@.price > 10)].author",
+			expectedData: []any{"Nietzsche"},
+			expectedSteps: []TraceStep{
+				{Segment: "['store']", Branch: "node get", InputCount: 1, Matches: 1},
+				{Segment: "['books'][?(@.price > 10)]", Branch: "node get", InputCount: 1, Matches: 1},
+				{Segment: "['author']", Branch: "array fan out", InputCount: 1, Matches: 1},
+			},
+		},
+		{
+			jsonPath:     "$..price",
+			expectedData: []any{15, 5},
+			expectedSteps: []TraceStep{
+				{Segment: "..", Branch: "recursive descent marker", InputCount: 1, Matches: 1},
+				{Segment: "['price']", Branch: "recursive descent search", InputCount: 1, Matches: 2},
+			},
+		},
+		{
+			jsonPath:     "$.store.*",
+			expectedData: map[string]any{"books": data["store"].(map[string]any)["books"]},
+			expectedSteps: []TraceStep{
+				{Segment: "['store']", Branch: "node get", InputCount: 1, Matches: 1},
+				{Segment: "[*]", Branch: "wildcard", InputCount: 1, Matches: 1},
+			},
+		},
+		{
+			jsonPath:     "$.store.books.isbn",
+			expectedData: nil,
+			expectedSteps: []TraceStep{
+				{Segment: "['store']", Branch: "node get", InputCount: 1, Matches: 1},
+				{Segment: "['books']", Branch: "node get", InputCount: 1, Matches: 2},
+				{Segment: "['isbn']", Branch: "array fan out", InputCount: 2, Error: "dataValidationError: Source key not found: 'isbn'"},
+			},
+			expectedErrorMessage: "dataValidationError: Source key not found: 'isbn'",
+		},
+		{
+			jsonPath:             "store",
+			expectedData:         nil,
+			expectedSteps:        nil,
+			expectedErrorMessage: "JSONPath should start with '$.'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - GetWithTrace(%v)=%v", i, tc.jsonPath, tc.expectedErrorMessage), func(t *testing.T) {
+			result, steps, err := GetWithTrace(data, tc.jsonPath)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedData, result) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedData), gu.Prettify(result))
+			}
+			if !cmp.Equal(tc.expectedSteps, steps) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedSteps, steps)
+			}
+		})
+	}
+}
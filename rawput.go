@@ -0,0 +1,319 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rawSpan is the byte range of a value of raw JSON along with the layout of the members of an object.
+type rawSpan struct {
+	start int
+	end   int
+
+	object bool
+
+	// lastMemberEnd is the end of the last member of an object. It is -1 if the object is empty.
+	lastMemberEnd int
+
+	// multiline determines whether the members of an object are written on their own lines.
+	multiline bool
+
+	// indent is the indentation of the members of a multiline object.
+	indent string
+
+	// colon is what separates the keys of the members of an object from their values, i.e. `: `.
+	colon string
+}
+
+// rawLocation returns the location of the provided steps, keys or indices, as a string which is unambiguous and which
+// has the locations of all the ancestors as prefixes.
+func rawLocation(steps []any) string {
+	var sb strings.Builder
+	for _, step := range steps {
+		sb.WriteByte('[')
+		if key, ok := step.(string); ok {
+			sb.WriteString(strconv.Quote(key))
+		} else {
+			sb.WriteString(strconv.Itoa(step.(int)))
+		}
+		sb.WriteByte(']')
+	}
+
+	return sb.String()
+}
+
+// rawSteps converts a simple JSONPath, i.e. `$.store.books[1].title`, to the keys and indices it consists of.
+func rawSteps(jsonPath string) ([]any, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	notSimple := &Error{Code: ErrCodeInvalidPath, Message: "Only simple JSONPaths of keys and single indices are supported", Path: jsonPath}
+
+	var steps []any
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case node:
+			if n.name == "" || n.name == "*" {
+				return nil, notSimple
+			}
+			steps = append(steps, n.name)
+		case arrayIndexedNode:
+			if len(n.indices) != 1 {
+				return nil, notSimple
+			}
+			steps = append(steps, n.name, n.indices[0])
+		default:
+			return nil, notSimple
+		}
+	}
+
+	return steps, nil
+}
+
+// scanRawSpans walks the raw JSON and returns the spans of the values of the wanted locations. The rest of the values
+// are skipped without being decoded. It also returns the colon of the first member found.
+func scanRawSpans(raw []byte, wanted map[string]bool) (map[string]*rawSpan, string, error) {
+	spans := make(map[string]*rawSpan)
+	docColon := ""
+	dec := json.NewDecoder(bytes.NewReader(raw))
+
+	// the decoder's offset is the end of the previous token so the separators are skipped
+	nextOffset := func() int {
+		offset := int(dec.InputOffset())
+		for offset < len(raw) && strings.IndexByte(" \t\r\n,:", raw[offset]) >= 0 {
+			offset++
+		}
+		return offset
+	}
+
+	var walk func(location string) error
+	walk = func(location string) error {
+		start := nextOffset()
+		if !wanted[location] {
+			var skipped json.RawMessage
+			return dec.Decode(&skipped)
+		}
+
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		span := &rawSpan{start: start, lastMemberEnd: -1}
+		switch token {
+		case json.Delim('{'):
+			span.object = true
+			for dec.More() {
+				keyOffset := nextOffset()
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				keyEnd := int(dec.InputOffset())
+
+				if span.lastMemberEnd < 0 {
+					span.multiline = bytes.IndexByte(raw[start:keyOffset], '\n') >= 0
+					span.indent = string(raw[bytes.LastIndexByte(raw[:keyOffset], '\n')+1 : keyOffset])
+					span.colon = strings.TrimRight(string(raw[keyEnd:nextOffset()]), ",")
+					if docColon == "" {
+						docColon = span.colon
+					}
+				}
+
+				if err := walk(location + rawLocation([]any{key.(string)})); err != nil {
+					return err
+				}
+				span.lastMemberEnd = int(dec.InputOffset())
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(location + rawLocation([]any{i})); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		if err != nil {
+			return err
+		}
+
+		span.end = int(dec.InputOffset())
+		spans[location] = span
+
+		return nil
+	}
+
+	if err := walk(""); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, "", err
+	}
+
+	if _, err := dec.Token(); err == nil {
+		return nil, "", &Error{Code: ErrCodeInvalidDocument, Message: "Unexpected data after the document root"}
+	} else if err != io.EOF {
+		return nil, "", err
+	}
+
+	if !spans[""].object {
+		return nil, "", &Error{Code: ErrCodeNotMap, Message: "Document root should be an object", Path: "$"}
+	}
+
+	return spans, docColon, nil
+}
+
+// rawValue encodes a value to compact JSON.
+func rawValue(value any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// rawEdit replaces a byte range of raw JSON with a text. Insertions have an empty range.
+type rawEdit struct {
+	start int
+	end   int
+	text  []byte
+}
+
+// PutRaw updates a JSON document, given as raw bytes, with multiple values at once, each one described by its own
+// JSONPath, as PutMany does. Only the byte ranges of the affected values are rewritten instead of decoding and
+// encoding the whole document so that a few values of a document of several megabytes can be updated cheaply. The
+// rest of the document, formatting included, is left as it is.
+//
+// The JSONPaths must be simple ones of keys and single array indices, i.e. `$.store.books[1].title`. Missing keys
+// are added to their objects, along with any missing objects holding them, but arrays cannot be created or grown.
+// The JSONPaths must not overlap, i.e. `$.store` and `$.store.name`. The new values are written compact.
+//
+// The JSONPaths are validated before any change takes place and the first occured error is returned.
+func PutRaw(raw []byte, values map[string]any) ([]byte, error) {
+	jsonPaths := make([]string, 0, len(values))
+	steps := make(map[string][]any, len(values))
+	locations := make(map[string]string, len(values))
+	wanted := map[string]bool{"": true}
+
+	for jsonPath := range values {
+		pathSteps, err := rawSteps(jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", jsonPath, err)
+		}
+
+		jsonPaths = append(jsonPaths, jsonPath)
+		steps[jsonPath] = pathSteps
+		locations[jsonPath] = rawLocation(pathSteps)
+		for i := range pathSteps {
+			wanted[rawLocation(pathSteps[:i+1])] = true
+		}
+	}
+
+	// an ancestor sorts right before its descendants so checking the neighbours is enough
+	sort.Slice(jsonPaths, func(i, j int) bool { return locations[jsonPaths[i]] < locations[jsonPaths[j]] })
+	for i := 1; i < len(jsonPaths); i++ {
+		if strings.HasPrefix(locations[jsonPaths[i]], locations[jsonPaths[i-1]]) {
+			return nil, &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("JSONPaths '%v' and '%v' overlap", jsonPaths[i-1], jsonPaths[i]), Path: jsonPaths[i]}
+		}
+	}
+
+	spans, docColon, err := scanRawSpans(raw, wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []rawEdit
+	created := make(map[string]string)
+	for _, jsonPath := range jsonPaths {
+		pathSteps := steps[jsonPath]
+
+		depth := len(pathSteps)
+		for spans[rawLocation(pathSteps[:depth])] == nil {
+			depth--
+		}
+		span := spans[rawLocation(pathSteps[:depth])]
+
+		if depth == len(pathSteps) {
+			value, err := rawValue(values[jsonPath])
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", jsonPath, err)
+			}
+			edits = append(edits, rawEdit{start: span.start, end: span.end, text: value})
+			continue
+		}
+
+		for _, step := range pathSteps[depth:] {
+			if _, ok := step.(int); ok {
+				return nil, fmt.Errorf("%v: %w", jsonPath, &Error{Code: ErrCodeInvalidOperation, Message: "Arrays cannot be created or grown", Path: jsonPath})
+			}
+		}
+		if !span.object {
+			return nil, fmt.Errorf("%v: %w", jsonPath, &Error{Code: ErrCodeNotMap, Message: "Value is not an object", Path: jsonPath, Segment: pathSteps[depth].(string)})
+		}
+
+		createdLocation := rawLocation(pathSteps[:depth+1])
+		if other, ok := created[createdLocation]; ok {
+			return nil, &Error{Code: ErrCodeInvalidPath, Message: fmt.Sprintf("JSONPaths '%v' and '%v' overlap", other, jsonPath), Path: jsonPath}
+		}
+		created[createdLocation] = jsonPath
+
+		var value any = values[jsonPath]
+		for i := len(pathSteps) - 1; i > depth; i-- {
+			value = map[string]any{pathSteps[i].(string): value}
+		}
+		member, err := rawValue(map[string]any{pathSteps[depth].(string): value})
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", jsonPath, err)
+		}
+		member = member[1 : len(member)-1]
+
+		colon := span.colon
+		if colon == "" {
+			colon = docColon
+		}
+		if colon != "" && colon != ":" {
+			key, _ := rawValue(pathSteps[depth])
+			member = append(append(key, colon...), member[len(key)+1:]...)
+		}
+
+		switch {
+		case span.lastMemberEnd < 0:
+			edits = append(edits, rawEdit{start: span.end - 1, end: span.end - 1, text: member})
+		case span.multiline:
+			text := append([]byte(",\n"+span.indent), member...)
+			edits = append(edits, rawEdit{start: span.lastMemberEnd, end: span.lastMemberEnd, text: text})
+		default:
+			separator := ","
+			if strings.HasSuffix(colon, " ") {
+				separator = ", "
+			}
+			text := append([]byte(separator), member...)
+			edits = append(edits, rawEdit{start: span.lastMemberEnd, end: span.lastMemberEnd, text: text})
+		}
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var buf bytes.Buffer
+	previousEnd := 0
+	for _, edit := range edits {
+		buf.Write(raw[previousEnd:edit.start])
+		buf.Write(edit.text)
+		previousEnd = edit.end
+	}
+	buf.Write(raw[previousEnd:])
+
+	return buf.Bytes(), nil
+}
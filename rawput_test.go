@@ -0,0 +1,101 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+)
+
+type PutRawTestCase struct {
+	raw                  string
+	values               map[string]any
+	expectedResult       string
+	expectedErrorMessage string
+}
+
+func TestPutRaw(t *testing.T) {
+	raw := `{
+    "store": {
+        "name": "Store1",
+        "books": [
+            {"title": "Book1", "price": 1.50},
+            {"title": "Book2", "price": 20, "tags": {}}
+        ]
+    },
+    "owner": null
+}`
+
+	testCases := []PutRawTestCase{
+		{
+			raw:            raw,
+			values:         map[string]any{"$.store.name": "<Store2>", "$.store.books[1].price": 25, "$.owner": map[string]any{"name": "Someone"}},
+			expectedResult: "{\n    \"store\": {\n        \"name\": \"<Store2>\",\n        \"books\": [\n            {\"title\": \"Book1\", \"price\": 1.50},\n            {\"title\": \"Book2\", \"price\": 25, \"tags\": {}}\n        ]\n    },\n    \"owner\": {\"name\":\"Someone\"}\n}",
+		},
+		{
+			raw:            raw,
+			values:         map[string]any{"$.store.address.city": "Athens", "$.store.books[0].isbn": "123", "$.store.books[1].tags.new": true, "$.id": 1},
+			expectedResult: "{\n    \"store\": {\n        \"name\": \"Store1\",\n        \"books\": [\n            {\"title\": \"Book1\", \"price\": 1.50, \"isbn\": \"123\"},\n            {\"title\": \"Book2\", \"price\": 20, \"tags\": {\"new\": true}}\n        ],\n        \"address\": {\"city\":\"Athens\"}\n    },\n    \"owner\": null,\n    \"id\": 1\n}",
+		},
+		{
+			raw:            `{"a":{"b":1},"c":[]}`,
+			values:         map[string]any{"$.a.d": "x", "$.a.e": "y", "$.a.b": 2},
+			expectedResult: `{"a":{"b":2,"d":"x","e":"y"},"c":[]}`,
+		},
+		{
+			raw:            " {} \n",
+			values:         map[string]any{"$": map[string]any{"a": 1}},
+			expectedResult: " {\"a\":1} \n",
+		},
+		{
+			raw:                  raw,
+			values:               map[string]any{"$.store.books[*].price": 1},
+			expectedErrorMessage: "$.store.books[*].price: Only simple JSONPaths of keys and single indices are supported",
+		},
+		{
+			raw:                  raw,
+			values:               map[string]any{"$.store": 1, "$.store.name": "Store2"},
+			expectedErrorMessage: "JSONPaths '$.store' and '$.store.name' overlap",
+		},
+		{
+			raw:                  raw,
+			values:               map[string]any{"$.a.b": 1, "$.a.c": 2},
+			expectedErrorMessage: "JSONPaths '$.a.b' and '$.a.c' overlap",
+		},
+		{
+			raw:                  raw,
+			values:               map[string]any{"$.store.books[2]": 1},
+			expectedErrorMessage: "$.store.books[2]: Arrays cannot be created or grown",
+		},
+		{
+			raw:                  raw,
+			values:               map[string]any{"$.owner.name": "Someone"},
+			expectedErrorMessage: "$.owner.name: Value is not an object",
+		},
+		{
+			raw:                  `{"a": 1,}`,
+			values:               map[string]any{"$.a": 2},
+			expectedErrorMessage: "invalid character ',' looking for beginning of value",
+		},
+		{
+			raw:                  `[{"a": 1}]`,
+			values:               map[string]any{"$.a": 2},
+			expectedErrorMessage: "Document root should be an object",
+		},
+		{
+			raw:                  `{"a": 1} {}`,
+			values:               map[string]any{"$.a": 2},
+			expectedErrorMessage: "Unexpected data after the document root",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - PutRaw(%v)=%v", i, tc.values, tc.expectedErrorMessage), func(t *testing.T) {
+			result, err := PutRaw([]byte(tc.raw), tc.values)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if string(result) != tc.expectedResult {
+				t.Errorf("Expected '%v', but got '%s'", tc.expectedResult, result)
+			}
+		})
+	}
+}
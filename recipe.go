@@ -0,0 +1,127 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// recipePlaceholderPattern matches the `{{param}}` placeholders of a recipe.
+var recipePlaceholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// Recipe is a named and parameterized bundle of mapper configurations which can be instantiated multiple times.
+//
+// The paths and the string fields of the transformations may contain `{{param}}` placeholders which are replaced
+// by the parameters provided on instantiation.
+type Recipe struct {
+
+	// Name is the name a mapper configuration refers to the recipe by.
+	Name string `json:"name"`
+
+	// Mappers are the templates of the mappers the recipe instantiates to.
+	Mappers []MapperConfig `json:"mappers"`
+}
+
+// recipes holds the registered recipes by name.
+var recipes = map[string]Recipe{}
+
+// recipesMutex guards the recipes.
+var recipesMutex sync.RWMutex
+
+// RegisterRecipe makes the recipe available to all mapping configurations under its name, replacing any recipe
+// registered with the same name.
+//
+// Recipes can be registered at any time, i.e. when loaded from a plugin, while configurations are being converted.
+func RegisterRecipe(r Recipe) {
+	recipesMutex.Lock()
+	defer recipesMutex.Unlock()
+
+	recipes[r.Name] = r
+}
+
+// substitute replaces the references of s matched by the pattern, whose first group is the name, with the
+// corresponding values. An error is returned if a value is missing, described as the provided kind.
+func substitute(s string, pattern *regexp.Regexp, values map[string]string, kind string) (string, error) {
+	var err error
+
+	result := pattern.ReplaceAllStringFunc(s, func(reference string) string {
+		name := pattern.FindStringSubmatch(reference)[1]
+		value, ok := values[name]
+		if !ok && err == nil {
+			err = &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Missing %v '%v'", kind, name), Segment: name}
+		}
+		return value
+	})
+
+	return result, err
+}
+
+// fillPlaceholders replaces the placeholders of s with the corresponding parameters.
+// An error is returned if a parameter is missing.
+func fillPlaceholders(s string, params map[string]string) (string, error) {
+	return substitute(s, recipePlaceholderPattern, params, "parameter")
+}
+
+// instantiate returns the mapper configurations of the recipe with their placeholders replaced by the parameters.
+func (r Recipe) instantiate(params map[string]string) ([]MapperConfig, error) {
+	var configs []MapperConfig
+
+	for i, template := range r.Mappers {
+		if template.Recipe != "" {
+			return nil, fmt.Errorf("Mapper[%v]: %w", i, &Error{Code: ErrCodeInvalidOperation, Message: "Recipes cannot refer to other recipes."})
+		}
+
+		config := template
+		config.Transformations = append([]TransformationConfig(nil), template.Transformations...)
+		config.KeyTransformations = append([]TransformationConfig(nil), template.KeyTransformations...)
+
+		fields := []*string{&config.Src, &config.Dst}
+		for j := range config.Transformations {
+			t := &config.Transformations[j]
+			fields = append(fields, &t.Delim, &t.OldVal, &t.NewVal, &t.Regex, &t.TrimPrefix)
+		}
+		for j := range config.KeyTransformations {
+			t := &config.KeyTransformations[j]
+			fields = append(fields, &t.Delim, &t.OldVal, &t.NewVal, &t.Regex, &t.TrimPrefix)
+		}
+
+		for _, field := range fields {
+			var err error
+			if *field, err = fillPlaceholders(*field, params); err != nil {
+				return nil, fmt.Errorf("Mapper[%v]: %w", i, err)
+			}
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+// ToMappers instantiates the recipe with the provided parameters and converts it to a list of mappers.
+func (r Recipe) ToMappers(params map[string]string) ([]Mapper, error) {
+	configs, err := r.instantiate(params)
+	if err != nil {
+		return nil, fmt.Errorf("Recipe '%v': %w", r.Name, err)
+	}
+
+	return MappingConfig{Mappers: configs}.ToMappers()
+}
+
+// findRecipe returns the recipe with the provided name among the provided recipes or else the registered ones.
+func findRecipe(name string, local []Recipe) (Recipe, error) {
+	for _, r := range local {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+
+	recipesMutex.RLock()
+	defer recipesMutex.RUnlock()
+
+	if r, ok := recipes[name]; ok {
+		return r, nil
+	}
+
+	return Recipe{}, &Error{Code: ErrCodeUnknownRecipe, Message: fmt.Sprintf("Unknown recipe '%v'", name)}
+}
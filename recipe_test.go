@@ -0,0 +1,85 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadMappersWithRecipes(t *testing.T) {
+	RegisterRecipe(Recipe{
+		Name: "copy",
+		Mappers: []MapperConfig{
+			{Src: "$.{{field}}", Dst: "$.{{field}}"},
+		},
+	})
+	defer delete(recipes, "copy")
+
+	testCases := []LoadMappersTestCase{
+		{
+			input: `{
+				"recipes": [
+					{"name": "split-coordinates", "mappers": [
+						{"src": "{{src}}", "dst": "{{dst}}.lat", "transformations": [{"type": "split", "delim": "{{delim}}", "index": 0}, {"type": "number"}]},
+						{"src": "{{src}}", "dst": "{{dst}}.lon", "transformations": [{"type": "split", "delim": "{{delim}}", "index": 1}, {"type": "number"}]}
+					]}
+				],
+				"mappers": [
+					{"recipe": "split-coordinates", "params": {"src": "$.home", "dst": "$.geo.home", "delim": ","}},
+					{"src": "$.name", "dst": "$.name"},
+					{"recipe": "copy", "params": {"field": "age"}}
+				]
+			}`,
+			format: FormatJSON,
+			expectedMappers: []Mapper{
+				{
+					SrcJsonPath: "$.home",
+					DstJsonPath: "$.geo.home.lat",
+					Transformations: []Transformation{
+						{Trsnfmr: SplitTransformer{Delim: ",", Index: 0}},
+						{Trsnfmr: NumberTransformer{}},
+					},
+				},
+				{
+					SrcJsonPath: "$.home",
+					DstJsonPath: "$.geo.home.lon",
+					Transformations: []Transformation{
+						{Trsnfmr: SplitTransformer{Delim: ",", Index: 1}},
+						{Trsnfmr: NumberTransformer{}},
+					},
+				},
+				{SrcJsonPath: "$.name", DstJsonPath: "$.name"},
+				{SrcJsonPath: "$.age", DstJsonPath: "$.age"},
+			},
+		},
+		{
+			input:                `{"mappers": [{"recipe": "copy", "params": {}}]}`,
+			format:               FormatJSON,
+			expectedErrorMessage: "Mapper[0]: Recipe 'copy': Mapper[0]: Missing parameter 'field'",
+		},
+		{
+			input:                `{"mappers": [{"recipe": "unknown"}]}`,
+			format:               FormatJSON,
+			expectedErrorMessage: "Mapper[0]: Unknown recipe 'unknown'",
+		},
+		{
+			input:                `{"recipes": [{"name": "nested", "mappers": [{"recipe": "copy"}]}], "mappers": [{"recipe": "nested"}]}`,
+			format:               FormatJSON,
+			expectedErrorMessage: "Mapper[0]: Recipe 'nested': Mapper[0]: Recipes cannot refer to other recipes.",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - LoadMappers(%v)=%v", i, tc.input, tc.expectedErrorMessage), func(t *testing.T) {
+			mappers, err := LoadMappers(strings.NewReader(tc.input), tc.format)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedMappers, mappers) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedMappers, mappers)
+			}
+		})
+	}
+}
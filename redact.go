@@ -0,0 +1,111 @@
+package jsonmanu
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// RedactionStrategy determines how Redact treats the matched values.
+type RedactionStrategy int
+
+const (
+	// RedactMask replaces the values with RedactionMask.
+	RedactMask RedactionStrategy = iota
+
+	// RedactRemove removes the values. Array elements are replaced with null so that the indices of the rest
+	// remain the same.
+	RedactRemove
+
+	// RedactHash replaces the values with the hex encoded HMAC-SHA256 of their JSON encoding so that equal values
+	// can still be correlated. It requires RedactOptions.HashKey since plain hashes of low-entropy values, i.e.
+	// emails or phone numbers, can be reversed by hashing all the candidates.
+	RedactHash
+)
+
+// RedactionMask is the value masked values are replaced with.
+const RedactionMask = "***"
+
+// RedactOptions holds the options of RedactWithOptions.
+type RedactOptions struct {
+
+	// Strategy determines how the matched values are treated. It defaults to RedactMask.
+	Strategy RedactionStrategy
+
+	// HashKey is the secret key of the HMAC of RedactHash. It is required by RedactHash and it should be kept
+	// secret and be long and random enough, i.e. 32 random bytes, otherwise the hashed values can be recovered.
+	HashKey []byte
+}
+
+// redactedValue returns the replacement of a value according to the options.
+func redactedValue(value any, opts RedactOptions) (any, error) {
+	switch opts.Strategy {
+	case RedactMask:
+		return RedactionMask, nil
+	case RedactRemove:
+		return nil, nil
+	case RedactHash:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		mac := hmac.New(sha256.New, opts.HashKey)
+		mac.Write(raw)
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	}
+
+	return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Unknown redaction strategy %v", opts.Strategy)}
+}
+
+// Redact returns a deep copy of the data where the values matched by the provided JSONPaths are masked or removed
+// according to the strategy, so that the data can be safely logged. Recursive descent is supported, i.e.
+// `$..password` redacts the passwords found at any depth.
+//
+// RedactHash requires a key so it is only supported by RedactWithOptions.
+//
+// The data remain untouched. An error is returned if any of the JSONPaths is invalid.
+func Redact(data map[string]any, jsonPaths []string, strategy RedactionStrategy) (map[string]any, error) {
+	return RedactWithOptions(data, jsonPaths, RedactOptions{Strategy: strategy})
+}
+
+// RedactWithOptions works like Redact with the behavior adjusted by the provided options. The values are hashed with
+// RedactHash using the HashKey of the options, i.e.
+//
+//	safe, err := RedactWithOptions(payload, []string{"$..email"}, RedactOptions{Strategy: RedactHash, HashKey: key})
+//
+// where key is a secret loaded from the configuration of the service.
+func RedactWithOptions(data map[string]any, jsonPaths []string, opts RedactOptions) (map[string]any, error) {
+	if opts.Strategy == RedactHash && len(opts.HashKey) == 0 {
+		return nil, &Error{Code: ErrCodeInvalidOperation, Message: "RedactHash requires a HashKey."}
+	}
+
+	result, _ := deepCopy(data).(map[string]any)
+
+	for _, jsonPath := range jsonPaths {
+		matches, err := findMatches(result, jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", jsonPath, err)
+		}
+
+		for _, m := range matches {
+			if m.parent == nil {
+				return nil, &Error{Code: ErrCodeInvalidOperation, Message: "Root cannot be redacted.", Path: jsonPath}
+			}
+
+			if parent, ok := m.parent.(map[string]any); ok && opts.Strategy == RedactRemove {
+				delete(parent, m.key)
+				continue
+			}
+
+			value, err := redactedValue(m.value, opts)
+			if err != nil {
+				return nil, err
+			}
+			m.set(value)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type RedactTestCase struct {
+	jsonPaths            []string
+	strategy             RedactionStrategy
+	hashKey              []byte
+	expectedData         map[string]any
+	expectedErrorMessage string
+}
+
+func TestRedact(t *testing.T) {
+	data := map[string]any{
+		"user":     map[string]any{"name": "Nietzsche", "password": "secret"},
+		"password": "secret",
+		"tokens":   []any{"token1", "token2"},
+		"cards":    []any{map[string]any{"number": "4111", "type": "visa"}},
+	}
+	original := deepCopy(data)
+
+	testCases := []RedactTestCase{
+		{
+			jsonPaths: []string{"$..password", "$.cards[*].number"},
+			strategy:  RedactMask,
+			expectedData: map[string]any{
+				"user":     map[string]any{"name": "Nietzsche", "password": "***"},
+				"password": "***",
+				"tokens":   []any{"token1", "token2"},
+				"cards":    []any{map[string]any{"number": "***", "type": "visa"}},
+			},
+		},
+		{
+			jsonPaths: []string{"$..password", "$.tokens[1]", "$.missing"},
+			strategy:  RedactRemove,
+			expectedData: map[string]any{
+				"user":   map[string]any{"name": "Nietzsche"},
+				"tokens": []any{"token1", nil},
+				"cards":  []any{map[string]any{"number": "4111", "type": "visa"}},
+			},
+		},
+		{
+			jsonPaths: []string{"$.user.password"},
+			strategy:  RedactHash,
+			hashKey:   []byte("k3y"),
+			expectedData: map[string]any{
+				"user":     map[string]any{"name": "Nietzsche", "password": "7466ff948bc8933fc31697ff191efcc370c036f3efca068cb4270e2a9cbb98d0"},
+				"password": "secret",
+				"tokens":   []any{"token1", "token2"},
+				"cards":    []any{map[string]any{"number": "4111", "type": "visa"}},
+			},
+		},
+		{
+			jsonPaths:            []string{"$.user.password"},
+			strategy:             RedactHash,
+			expectedErrorMessage: "RedactHash requires a HashKey.",
+		},
+		{
+			jsonPaths:            []string{"password"},
+			strategy:             RedactMask,
+			expectedErrorMessage: "password: JSONPath should start with '$.'",
+		},
+		{
+			jsonPaths:            []string{"$"},
+			strategy:             RedactMask,
+			expectedErrorMessage: "Root cannot be redacted.",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Redact(%v, %v)=%v", i, tc.jsonPaths, tc.strategy, tc.expectedErrorMessage), func(t *testing.T) {
+			result, err := RedactWithOptions(data, tc.jsonPaths, RedactOptions{Strategy: tc.strategy, HashKey: tc.hashKey})
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err == nil && !cmp.Equal(tc.expectedData, result) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(result))
+			}
+			if !cmp.Equal(original, data) {
+				t.Errorf("Expected the data to remain '%s', but got '%s'", gu.Prettify(original), gu.Prettify(data))
+			}
+		})
+	}
+}
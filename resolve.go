@@ -0,0 +1,133 @@
+package jsonmanu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Resolver looks up values in an external source, i.e. a cache, a database or an HTTP service, by their keys.
+type Resolver interface {
+
+	// Resolve returns the values of the provided keys in the same order. A key without a value should resolve to nil.
+	Resolve(ctx context.Context, keys []any) ([]any, error)
+}
+
+// ResolverFunc is an adapter to use an ordinary function as a Resolver.
+type ResolverFunc func(ctx context.Context, keys []any) ([]any, error)
+
+// Resolve calls f(ctx, keys).
+func (f ResolverFunc) Resolve(ctx context.Context, keys []any) ([]any, error) {
+	return f(ctx, keys)
+}
+
+// resolvers holds the registered resolvers by name.
+var resolvers = map[string]Resolver{}
+
+// resolversMutex guards the resolvers.
+var resolversMutex sync.RWMutex
+
+// RegisterResolver makes the resolver available to the `resolve` transformations of the mapping configurations
+// under the provided name, replacing any resolver registered with the same name.
+//
+// The configurations converted after the call see the resolver, while those already converted keep the resolver
+// they were given.
+func RegisterResolver(name string, r Resolver) {
+	resolversMutex.Lock()
+	defer resolversMutex.Unlock()
+
+	resolvers[name] = r
+}
+
+// findResolver returns the registered resolver with the provided name.
+func findResolver(name string) (Resolver, error) {
+	resolversMutex.RLock()
+	defer resolversMutex.RUnlock()
+
+	if r, ok := resolvers[name]; ok {
+		return r, nil
+	}
+
+	return nil, &Error{Code: ErrCodeUnknownResolver, Message: fmt.Sprintf("Unknown resolver '%v'", name)}
+}
+
+// ResolveTransformer replaces a value by the one its resolver looks up with the value as the key, turning a mapper
+// into an enrichment step, i.e. a customer id into the customer record.
+//
+// Used with Transformation.AsArray the elements of an array value are resolved in batches instead of one by one.
+type ResolveTransformer struct {
+
+	// Resolver looks up the values.
+	Resolver Resolver
+
+	// BatchSize is the maximum number of keys resolved at once. All the keys are resolved at once if it is not positive.
+	BatchSize int
+
+	// Timeout is the time limit of each call of the resolver. There is no limit if it is not positive.
+	Timeout time.Duration
+}
+
+// resolve resolves the keys of a single batch.
+func (t ResolveTransformer) resolve(keys []any) ([]any, error) {
+	ctx := context.Background()
+	if t.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+		defer cancel()
+	}
+
+	values, err := t.Resolver.Resolve(ctx, keys)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, &Error{Code: ErrCodeTimeout, Message: fmt.Sprintf("Resolver timed out after %v", t.Timeout), Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != len(keys) {
+		return nil, &Error{Code: ErrCodeTransformation, Message: fmt.Sprintf("Resolver returned %v values for %v keys.", len(values), len(keys))}
+	}
+
+	return values, nil
+}
+
+// ResolveTransformer Transform applies the resolve transformation.
+//
+// The elements of an array value are resolved in batches of BatchSize keys.
+func (t ResolveTransformer) Transform(value any) (any, error) {
+	if t.Resolver == nil {
+		return nil, &Error{Code: ErrCodeInvalidOperation, Message: "No resolver."}
+	}
+
+	keys, isArray := value.([]any)
+	if !isArray {
+		values, err := t.resolve([]any{value})
+		if err != nil {
+			return nil, err
+		}
+		return values[0], nil
+	}
+
+	batchSize := t.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(keys)
+	}
+
+	result := make([]any, 0, len(keys))
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		values, err := t.resolve(keys[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("Batch[%v:%v]: %w", start, end, err)
+		}
+		result = append(result, values...)
+	}
+
+	return result, nil
+}
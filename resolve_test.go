@@ -0,0 +1,140 @@
+package jsonmanu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+// customersResolver resolves customer ids to customer records and records the batches it is called with.
+type customersResolver struct {
+	batches [][]any
+}
+
+func (r *customersResolver) Resolve(ctx context.Context, keys []any) ([]any, error) {
+	r.batches = append(r.batches, keys)
+
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		if key != "c0" {
+			values[i] = map[string]any{"id": key, "name": fmt.Sprintf("Customer %v", key)}
+		}
+	}
+
+	return values, nil
+}
+
+func TestResolveTransformer(t *testing.T) {
+	slow := ResolverFunc(func(ctx context.Context, keys []any) ([]any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return keys, nil
+		}
+	})
+	short := ResolverFunc(func(ctx context.Context, keys []any) ([]any, error) {
+		return keys[1:], nil
+	})
+
+	cases := []TransformerTestCase{
+		{
+			transformer:              ResolveTransformer{Resolver: &customersResolver{}},
+			value:                    "c1",
+			expectedTransformedValue: map[string]any{"id": "c1", "name": "Customer c1"},
+		},
+		{
+			transformer:              ResolveTransformer{Resolver: &customersResolver{}},
+			value:                    []any{"c1", "c0"},
+			expectedTransformedValue: []any{map[string]any{"id": "c1", "name": "Customer c1"}, nil},
+		},
+		{
+			transformer:          ResolveTransformer{Resolver: slow, Timeout: 10 * time.Millisecond},
+			value:                "c1",
+			expectedErrorMessage: "Resolver timed out after 10ms",
+		},
+		{
+			transformer:          ResolveTransformer{Resolver: short, BatchSize: 2},
+			value:                []any{"c1", "c2", "c3"},
+			expectedErrorMessage: "Batch[0:2]: Resolver returned 1 values for 2 keys.",
+		},
+		{
+			transformer:          ResolveTransformer{},
+			value:                "c1",
+			expectedErrorMessage: "No resolver.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("ResolveTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestMapResolve(t *testing.T) {
+	resolver := &customersResolver{}
+	RegisterResolver("customers", resolver)
+	defer delete(resolvers, "customers")
+
+	mappers, err := LoadMappers(strings.NewReader(`
+mappers:
+  - src: $.orders.customerId
+    dst: $.customers
+    transformations:
+      - type: resolve
+        resolver: customers
+        batchSize: 2
+        timeout: 1s
+        asArray: true
+`), FormatYAML)
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	src := map[string]any{
+		"orders": []any{
+			map[string]any{"customerId": "c1"},
+			map[string]any{"customerId": "c2"},
+			map[string]any{"customerId": "c3"},
+		},
+	}
+	dst := make(map[string]any)
+	if errs := Map(src, dst, mappers); len(errs) > 0 {
+		t.Fatalf("Expected no errors, but got '%v'", errs)
+	}
+
+	expectedDst := map[string]any{
+		"customers": []any{
+			map[string]any{"id": "c1", "name": "Customer c1"},
+			map[string]any{"id": "c2", "name": "Customer c2"},
+			map[string]any{"id": "c3", "name": "Customer c3"},
+		},
+	}
+	if !cmp.Equal(expectedDst, dst) {
+		t.Errorf("Expected '%s', but got '%s'", gu.Prettify(expectedDst), gu.Prettify(dst))
+	}
+	if expectedBatches := [][]any{{"c1", "c2"}, {"c3"}}; !cmp.Equal(expectedBatches, resolver.batches) {
+		t.Errorf("Expected '%v', but got '%v'", expectedBatches, resolver.batches)
+	}
+
+	_, err = LoadMappers(strings.NewReader(`{"mappers": [{"src": "$.a", "dst": "$.b", "transformations": [{"type": "resolve", "resolver": "unknown"}]}]}`), FormatJSON)
+	if expected := "Mapper[0]: Transformation[0]: Unknown resolver 'unknown'"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error message '%v', but got '%v'", expected, err)
+	}
+}
@@ -0,0 +1,206 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// Rule is a lightweight validation rule of the values found in a JSONPath, an alternative to a full JSON Schema.
+type Rule struct {
+
+	// JsonPath describes the values to be validated. It may match multiple values, i.e. `$.items[*].price`.
+	JsonPath string
+
+	// Required requires the values to exist and not be null. If the last segment of the path is a simple key then it
+	// is required in every object matched by the rest of the path, which must exist as well, otherwise at least one
+	// value must be matched.
+	Required bool
+
+	// Type is the JSON Schema type the values must be of, i.e. `string`, `integer` etc.
+	Type string
+
+	// Regex is a regular expression the string values must match.
+	Regex string
+
+	// Min is the minimum of the numeric values, if not nil.
+	Min *float64
+
+	// Max is the maximum of the numeric values, if not nil.
+	Max *float64
+
+	// Func is an optional custom validation of the values. Its error becomes the message of the violation.
+	Func func(value any) error
+}
+
+// Violation describes a value which does not comply with a rule.
+type Violation struct {
+
+	// Path is the concrete JSONPath of the value, i.e. `$.items[1].price`, or the JSONPath of the rule if the value
+	// could not be located.
+	Path string `json:"path"`
+
+	// Requirement is the requirement violated. It can be one of `required`, `type`, `regex`, `range`, `custom` and
+	// `rule` if the rule itself is invalid.
+	Requirement string `json:"requirement"`
+
+	// Message is the human readable description of the violation.
+	Message string `json:"message"`
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%v: %v", v.Path, v.Message)
+}
+
+// requiredKeyPath splits a JSONPath ending in a simple key to the JSONPath of its parent and the key.
+// It returns false if the last segment is not a simple key or if it follows a recursive descent.
+func requiredKeyPath(jsonPath string) (string, string, bool) {
+	tokens := splitJsonPath(jsonPath)
+	if len(tokens) < 2 || tokens[len(tokens)-2] == "" {
+		return "", "", false
+	}
+
+	n, ok := nodeFromJsonPathSubNode(tokens[len(tokens)-1]).(node)
+	if !ok || n.name == "" || n.name == "*" {
+		return "", "", false
+	}
+
+	return strings.Join(tokens[:len(tokens)-1], "."), n.name, true
+}
+
+// validateRequired validates that the values of the rule exist.
+func (r Rule) validateRequired(data map[string]any) []Violation {
+	violation := func(path string) Violation {
+		return Violation{Path: path, Requirement: "required", Message: "value is required"}
+	}
+
+	parentPath, key, ok := requiredKeyPath(r.JsonPath)
+	if !ok {
+		matches, _ := findMatches(data, r.JsonPath)
+		for _, m := range matches {
+			if m.value != nil {
+				return nil
+			}
+		}
+		return []Violation{violation(r.JsonPath)}
+	}
+
+	parents, err := findMatches(data, parentPath)
+	if err != nil {
+		return []Violation{{Path: r.JsonPath, Requirement: "rule", Message: err.Error()}}
+	}
+
+	// a missing parent, i.e. `$.user` of `$.user.email`, misses the key as well unlike an empty array of parents
+	if len(parents) == 0 {
+		if _, err := Get(data, parentPath); err != nil {
+			return []Violation{violation(r.JsonPath)}
+		}
+	}
+
+	var violations []Violation
+	check := func(path string, obj map[string]any) {
+		if obj[key] == nil {
+			violations = append(violations, violation(path+"."+key))
+		}
+	}
+	for _, parent := range parents {
+		switch v := parent.value.(type) {
+		case map[string]any:
+			check(parent.path, v)
+		case []any:
+			for i, item := range v {
+				if itemMap, ok := item.(map[string]any); ok {
+					check(fmt.Sprintf("%v[%v]", parent.path, i), itemMap)
+				}
+			}
+		default:
+			// a null or a scalar parent cannot hold the key
+			violations = append(violations, violation(parent.path+"."+key))
+		}
+	}
+
+	return violations
+}
+
+// validateValue validates a single existing value against the rule.
+func (r Rule) validateValue(path string, value any, re *regexp.Regexp) []Violation {
+	var violations []Violation
+	violation := func(requirement, message string) {
+		violations = append(violations, Violation{Path: path, Requirement: requirement, Message: message})
+	}
+
+	if r.Type != "" && !schemaTypeMatches(value, r.Type) {
+		violation("type", fmt.Sprintf("expected type '%v' but got '%v'", r.Type, schemaTypeOf(value)))
+	}
+
+	if s, ok := value.(string); ok && re != nil && !re.MatchString(s) {
+		violation("regex", fmt.Sprintf("value '%v' does not match pattern '%v'", s, r.Regex))
+	}
+
+	if isNumber(value) {
+		fv, _ := gu.ToFloat64(value)
+		if r.Min != nil && fv < *r.Min {
+			violation("range", fmt.Sprintf("value %v is less than %v", value, *r.Min))
+		}
+		if r.Max != nil && fv > *r.Max {
+			violation("range", fmt.Sprintf("value %v is greater than %v", value, *r.Max))
+		}
+	}
+
+	if r.Func != nil {
+		if err := r.Func(value); err != nil {
+			violation("custom", err.Error())
+		}
+	}
+
+	return violations
+}
+
+// validate validates the data against the rule.
+func (r Rule) validate(data map[string]any) []Violation {
+	invalid := func(err error) []Violation {
+		return []Violation{{Path: r.JsonPath, Requirement: "rule", Message: err.Error()}}
+	}
+
+	var re *regexp.Regexp
+	if r.Regex != "" {
+		var err error
+		if re, err = regexp.Compile(r.Regex); err != nil {
+			return invalid(err)
+		}
+	}
+
+	matches, err := findMatches(data, r.JsonPath)
+	if err != nil {
+		return invalid(err)
+	}
+
+	var violations []Violation
+	if r.Required {
+		violations = append(violations, r.validateRequired(data)...)
+	}
+
+	for _, m := range matches {
+		if m.value == nil {
+			continue
+		}
+		violations = append(violations, r.validateValue(m.path, m.value, re)...)
+	}
+
+	return violations
+}
+
+// Validate validates the data against the provided rules and returns the violations found, in the order of the
+// rules. Null values are only checked by the Required requirement.
+//
+// It reuses the JSONPath engine so that payloads can be validated without a full JSON Schema.
+func Validate(data map[string]any, rules []Rule) []Violation {
+	var violations []Violation
+	for _, rule := range rules {
+		violations = append(violations, rule.validate(data)...)
+	}
+
+	return violations
+}
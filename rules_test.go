@@ -0,0 +1,104 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type ValidateTestCase struct {
+	rules              []Rule
+	expectedViolations []Violation
+}
+
+func TestValidate(t *testing.T) {
+	data := map[string]any{
+		"id":    "abc",
+		"email": "someone@example",
+		"items": []any{
+			map[string]any{"name": "Book1", "price": 15, "quantity": 2},
+			map[string]any{"name": nil, "price": -5, "quantity": 1.5},
+			map[string]any{"price": 150},
+		},
+		"store": map[string]any{"owner": map[string]any{"email": "owner@example.com"}},
+	}
+	min, max := 0.0, 100.0
+	notAbc := func(value any) error {
+		if value == "abc" {
+			return errors.New("value 'abc' is reserved")
+		}
+		return nil
+	}
+
+	testCases := []ValidateTestCase{
+		{
+			rules: []Rule{
+				{JsonPath: "$.id", Required: true, Type: "string"},
+				{JsonPath: "$.items[*].price", Required: true, Type: "number", Min: &min, Max: &max},
+			},
+			expectedViolations: []Violation{
+				{Path: "$.items[1].price", Requirement: "range", Message: "value -5 is less than 0"},
+				{Path: "$.items[2].price", Requirement: "range", Message: "value 150 is greater than 100"},
+			},
+		},
+		{
+			rules: []Rule{
+				{JsonPath: "$.items.name", Required: true},
+				{JsonPath: "$.items[*].quantity", Type: "integer"},
+				{JsonPath: "$.title", Required: true},
+				{JsonPath: "$.missing[*]", Required: true},
+			},
+			expectedViolations: []Violation{
+				{Path: "$.items[1].name", Requirement: "required", Message: "value is required"},
+				{Path: "$.items[2].name", Requirement: "required", Message: "value is required"},
+				{Path: "$.items[1].quantity", Requirement: "type", Message: "expected type 'integer' but got 'number'"},
+				{Path: "$.title", Requirement: "required", Message: "value is required"},
+				{Path: "$.missing[*]", Requirement: "required", Message: "value is required"},
+			},
+		},
+		{
+			rules: []Rule{
+				{JsonPath: "$.user.email", Required: true},
+				{JsonPath: "$.user.address.city", Required: true},
+				{JsonPath: "$.store.owner.email", Required: true},
+				{JsonPath: "$.id.value", Required: true},
+			},
+			expectedViolations: []Violation{
+				{Path: "$.user.email", Requirement: "required", Message: "value is required"},
+				{Path: "$.user.address.city", Requirement: "required", Message: "value is required"},
+				{Path: "$.id.value", Requirement: "required", Message: "value is required"},
+			},
+		},
+		{
+			rules: []Rule{
+				{JsonPath: "$..email", Regex: `^[^@]+@[^@]+\.\w+$`},
+				{JsonPath: "$.id", Func: notAbc},
+			},
+			expectedViolations: []Violation{
+				{Path: "$.email", Requirement: "regex", Message: "value 'someone@example' does not match pattern '^[^@]+@[^@]+\\.\\w+$'"},
+				{Path: "$.id", Requirement: "custom", Message: "value 'abc' is reserved"},
+			},
+		},
+		{
+			rules: []Rule{
+				{JsonPath: "id", Required: true},
+				{JsonPath: "$.id", Regex: "("},
+			},
+			expectedViolations: []Violation{
+				{Path: "id", Requirement: "rule", Message: "JSONPath should start with '$.'"},
+				{Path: "$.id", Requirement: "rule", Message: "error parsing regexp: missing closing ): `(`"},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Validate(%v)", i, tc.rules), func(t *testing.T) {
+			violations := Validate(data, tc.rules)
+			if !cmp.Equal(tc.expectedViolations, violations) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedViolations, violations)
+			}
+		})
+	}
+}
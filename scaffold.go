@@ -0,0 +1,165 @@
+package jsonmanu
+
+import (
+	"regexp"
+	"strings"
+)
+
+// arrayIndexPattern matches the array indices of a path.
+var arrayIndexPattern = regexp.MustCompile(`\[\d+\]`)
+
+// The tiers of the key matching of ScaffoldMappers in order of preference.
+const (
+	keyMatchNone int = iota
+	keyMatchFuzzy
+	keyMatchCaseInsensitive
+	keyMatchExact
+)
+
+// scaffoldLeaf is a leaf path of a sample document with its array indices removed.
+type scaffoldLeaf struct {
+	path string
+	keys []string
+	typ  string
+}
+
+// scaffoldLeaves returns the leaf paths of the data without array indices so that they refer to all the array elements.
+func scaffoldLeaves(data map[string]any) []scaffoldLeaf {
+	var leaves []scaffoldLeaf
+	seen := make(map[string]bool)
+
+	for _, info := range PathInfos(data, PathsOptions{}) {
+		path := arrayIndexPattern.ReplaceAllString(info.Path, "")
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		leaves = append(leaves, scaffoldLeaf{path: path, keys: strings.Split(path, ".")[1:], typ: info.Type})
+	}
+
+	return leaves
+}
+
+// normalizeKey lowercases the key and removes the usual word separators so that i.e. `first_name` matches `FirstName`.
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(key))
+}
+
+// levenshtein returns the edit distance of two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// minInt returns the minimum of the provided integers.
+func minInt(first int, rest ...int) int {
+	result := first
+	for _, i := range rest {
+		if i < result {
+			result = i
+		}
+	}
+
+	return result
+}
+
+// matchKeys returns how well two keys match.
+func matchKeys(srcKey, dstKey string) int {
+	if srcKey == dstKey {
+		return keyMatchExact
+	}
+
+	srcKey, dstKey = normalizeKey(srcKey), normalizeKey(dstKey)
+	if srcKey == dstKey {
+		return keyMatchCaseInsensitive
+	}
+
+	length := len(srcKey)
+	if len(dstKey) > length {
+		length = len(dstKey)
+	}
+
+	// up to one edit per three characters is tolerated
+	if levenshtein(srcKey, dstKey) <= length/3 {
+		return keyMatchFuzzy
+	}
+
+	return keyMatchNone
+}
+
+// commonParents returns the number of the parent keys the two leaves have in common counting from the leaf upwards.
+func commonParents(src, dst scaffoldLeaf) int {
+	count := 0
+	for i, j := len(src.keys)-2, len(dst.keys)-2; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if normalizeKey(src.keys[i]) != normalizeKey(dst.keys[j]) {
+			break
+		}
+		count++
+	}
+
+	return count
+}
+
+// ScaffoldMappers proposes a list of mappers out of a sample source and a sample destination document by matching
+// the keys of their leaves. Keys are matched exactly, then case-insensitively ignoring `_`, `-` and spaces and
+// finally fuzzily. Among the source leaves matching equally well, the one with the most parent keys in common
+// with the destination leaf is preferred.
+//
+// The paths of the mappers refer to all the elements of the arrays and their DstType is the type of the
+// destination leaf unless it is null. Destination leaves without a matching source leaf are skipped.
+//
+// The proposed mappers are meant as a starting point and should be reviewed.
+func ScaffoldMappers(src map[string]any, dst map[string]any) []Mapper {
+	srcLeaves := scaffoldLeaves(src)
+
+	var mappers []Mapper
+	for _, dstLeaf := range scaffoldLeaves(dst) {
+		dstKey := dstLeaf.keys[len(dstLeaf.keys)-1]
+
+		var best *scaffoldLeaf
+		bestMatch, bestParents := keyMatchNone, -1
+		for i, srcLeaf := range srcLeaves {
+			match := matchKeys(srcLeaf.keys[len(srcLeaf.keys)-1], dstKey)
+			if match == keyMatchNone || match < bestMatch {
+				continue
+			}
+
+			parents := commonParents(srcLeaf, dstLeaf)
+			if match > bestMatch || parents > bestParents {
+				best, bestMatch, bestParents = &srcLeaves[i], match, parents
+			}
+		}
+
+		if best == nil {
+			continue
+		}
+
+		mapper := Mapper{SrcJsonPath: best.path, DstJsonPath: dstLeaf.path}
+		if dstLeaf.typ != "null" {
+			mapper.DstType = dstLeaf.typ
+		}
+		mappers = append(mappers, mapper)
+	}
+
+	return mappers
+}
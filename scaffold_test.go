@@ -0,0 +1,63 @@
+package jsonmanu
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScaffoldMappers(t *testing.T) {
+	src := map[string]any{
+		"customer": map[string]any{
+			"first_name": "Friedrich",
+			"LastName":   "Nietzsche",
+			"adress":     "Röcken",
+			"id":         1,
+		},
+		"orders": []any{
+			map[string]any{"id": 10, "total": 15.5, "items": []any{"Book1"}},
+			map[string]any{"id": 11, "total": 5},
+		},
+	}
+	dst := map[string]any{
+		"person": map[string]any{
+			"firstName": "",
+			"lastName":  "",
+			"address":   "",
+			"phone":     nil,
+		},
+		"purchases": []any{
+			map[string]any{"id": 0, "items": []any{}},
+		},
+		"id": nil,
+	}
+
+	expectedMappers := []Mapper{
+		{SrcJsonPath: "$.customer.id", DstJsonPath: "$.id"},
+		{SrcJsonPath: "$.customer.adress", DstJsonPath: "$.person.address", DstType: "string"},
+		{SrcJsonPath: "$.customer.first_name", DstJsonPath: "$.person.firstName", DstType: "string"},
+		{SrcJsonPath: "$.customer.LastName", DstJsonPath: "$.person.lastName", DstType: "string"},
+		{SrcJsonPath: "$.customer.id", DstJsonPath: "$.purchases.id", DstType: "number"},
+		{SrcJsonPath: "$.orders.items", DstJsonPath: "$.purchases.items", DstType: "array"},
+	}
+
+	mappers := ScaffoldMappers(src, dst)
+	if !cmp.Equal(expectedMappers, mappers) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedMappers, mappers)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	testCases := map[[2]string]int{
+		{"", ""}:              0,
+		{"adress", "address"}: 1,
+		{"kitten", "sitting"}: 3,
+		{"abc", ""}:           3,
+	}
+
+	for strs, expected := range testCases {
+		if distance := levenshtein(strs[0], strs[1]); distance != expected {
+			t.Errorf("Expected levenshtein(%v, %v)=%v, but got %v", strs[0], strs[1], expected, distance)
+		}
+	}
+}
@@ -0,0 +1,474 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// schemaValidationError is returned when a value does not comply with a JSON Schema.
+type schemaValidationError struct {
+	// The JSONPath of the invalid value.
+	path string
+
+	// The reason of the failure.
+	reason string
+}
+
+func (err schemaValidationError) Error() string {
+	return fmt.Sprintf("schemaValidationError: %v: %v", err.path, err.reason)
+}
+
+// ErrorCode returns the code of the error.
+func (err schemaValidationError) ErrorCode() string { return ErrCodeSchemaValidation }
+
+// MarshalJSON returns the error in the same form as Error.MarshalJSON.
+func (err schemaValidationError) MarshalJSON() ([]byte, error) { return AsError(err).MarshalJSON() }
+
+// isNumber returns whether the value is of a numeric type. Numerical strings are not considered numbers.
+func isNumber(value any) bool {
+	if gu.IsString(value) {
+		return false
+	}
+
+	_, err := gu.ToFloat64(value)
+
+	return err == nil
+}
+
+// schemaTypeOf returns the JSON Schema type name of the provided value.
+func schemaTypeOf(value any) string {
+	switch {
+	case value == nil:
+		return "null"
+	case gu.IsString(value):
+		return "string"
+	case isNumber(value):
+		return "number"
+	case gu.IsMap(value):
+		return "object"
+	case gu.IsSlice(value):
+		return "array"
+	}
+
+	if _, ok := value.(bool); ok {
+		return "boolean"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+// schemaTypeMatches returns whether the value is of the provided JSON Schema type.
+func schemaTypeMatches(value any, schemaType string) bool {
+	valueType := schemaTypeOf(value)
+
+	if schemaType == "integer" && valueType == "number" {
+		fv, _ := gu.ToFloat64(value)
+		return fv == math.Trunc(fv)
+	}
+
+	return valueType == schemaType
+}
+
+// schemaTypes returns the type(s) declared in the `type` keyword of a schema.
+func schemaTypes(schema map[string]any) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var types []string
+		for _, item := range t {
+			types = append(types, fmt.Sprintf("%v", item))
+		}
+		return types
+	case []string:
+		return t
+	}
+
+	return nil
+}
+
+// validateSchema validates a value against a JSON Schema.
+//
+// Only a subset of the JSON Schema keywords is supported: `type`, `enum`, `const`, `properties`, `required`,
+// `additionalProperties`, `items`, `minItems`, `maxItems`, `minLength`, `maxLength`, `pattern`, `minimum` and `maximum`.
+// Unknown keywords are ignored.
+func validateSchema(value any, schema map[string]any, path string) error {
+	if schema == nil {
+		return nil
+	}
+
+	if types := schemaTypes(schema); len(types) > 0 {
+		matches := false
+		for _, t := range types {
+			if schemaTypeMatches(value, t) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return schemaValidationError{path, fmt.Sprintf("expected type '%v' but got '%v'", strings.Join(types, "|"), schemaTypeOf(value))}
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, item := range enum {
+			if schemaValuesEqual(value, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return schemaValidationError{path, fmt.Sprintf("value %#v is not one of %v", value, enum)}
+		}
+	}
+
+	if constValue, ok := schema["const"]; ok && !schemaValuesEqual(value, constValue) {
+		return schemaValidationError{path, fmt.Sprintf("value %#v is not equal to %#v", value, constValue)}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return validateSchemaObject(v, schema, path)
+	case []any:
+		return validateSchemaArray(v, schema, path)
+	case string:
+		return validateSchemaString(v, schema, path)
+	}
+
+	if isNumber(value) {
+		return validateSchemaNumber(value, schema, path)
+	}
+
+	return nil
+}
+
+// schemaValuesEqual compares two values treating all numeric types as equal if their values are equal.
+func schemaValuesEqual(val1, val2 any) bool {
+	if isNumber(val1) && isNumber(val2) {
+		fval1, _ := gu.ToFloat64(val1)
+		fval2, _ := gu.ToFloat64(val2)
+		return fval1 == fval2
+	}
+
+	return reflect.DeepEqual(val1, val2)
+}
+
+// schemaInt reads an integer keyword out of a schema.
+func schemaInt(schema map[string]any, keyword string) (int, bool) {
+	value, ok := schema[keyword]
+	if !ok || !isNumber(value) {
+		return 0, false
+	}
+
+	fv, _ := gu.ToFloat64(value)
+
+	return int(fv), true
+}
+
+func validateSchemaObject(value map[string]any, schema map[string]any, path string) error {
+	for _, key := range toStringSlice(schema["required"]) {
+		if _, ok := value[key]; !ok {
+			return schemaValidationError{path, fmt.Sprintf("missing required property '%v'", key)}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		item := value[key]
+		itemPath := path + "." + key
+
+		if propertySchema, ok := properties[key]; ok {
+			if err := validateSchema(item, toSchema(propertySchema), itemPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return schemaValidationError{path, fmt.Sprintf("additional property '%v' is not allowed", key)}
+			}
+		case map[string]any:
+			if err := validateSchema(item, additional, itemPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateSchemaArray(value []any, schema map[string]any, path string) error {
+	if minItems, ok := schemaInt(schema, "minItems"); ok && len(value) < minItems {
+		return schemaValidationError{path, fmt.Sprintf("expected at least %v items but got %v", minItems, len(value))}
+	}
+
+	if maxItems, ok := schemaInt(schema, "maxItems"); ok && len(value) > maxItems {
+		return schemaValidationError{path, fmt.Sprintf("expected at most %v items but got %v", maxItems, len(value))}
+	}
+
+	if itemsSchema, ok := schema["items"].(map[string]any); ok {
+		for i, item := range value {
+			if err := validateSchema(item, itemsSchema, fmt.Sprintf("%v[%v]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateSchemaString(value string, schema map[string]any, path string) error {
+	// the length of a string is the number of its characters, not of its bytes
+	length := utf8.RuneCountInString(value)
+
+	if minLength, ok := schemaInt(schema, "minLength"); ok && length < minLength {
+		return schemaValidationError{path, fmt.Sprintf("expected length of at least %v but got %v", minLength, length)}
+	}
+
+	if maxLength, ok := schemaInt(schema, "maxLength"); ok && length > maxLength {
+		return schemaValidationError{path, fmt.Sprintf("expected length of at most %v but got %v", maxLength, length)}
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return schemaValidationError{path, fmt.Sprintf("invalid pattern '%v'", pattern)}
+		}
+		if !re.MatchString(value) {
+			return schemaValidationError{path, fmt.Sprintf("value %#v does not match pattern '%v'", value, pattern)}
+		}
+	}
+
+	return nil
+}
+
+func validateSchemaNumber(value any, schema map[string]any, path string) error {
+	fv, _ := gu.ToFloat64(value)
+
+	if minimum, ok := schema["minimum"]; ok && isNumber(minimum) {
+		fmin, _ := gu.ToFloat64(minimum)
+		if fv < fmin {
+			return schemaValidationError{path, fmt.Sprintf("value %v is less than minimum %v", value, minimum)}
+		}
+	}
+
+	if maximum, ok := schema["maximum"]; ok && isNumber(maximum) {
+		fmax, _ := gu.ToFloat64(maximum)
+		if fv > fmax {
+			return schemaValidationError{path, fmt.Sprintf("value %v is greater than maximum %v", value, maximum)}
+		}
+	}
+
+	return nil
+}
+
+// toSchema casts a value to a schema map if possible, otherwise it returns nil.
+func toSchema(value any) map[string]any {
+	schema, _ := value.(map[string]any)
+	return schema
+}
+
+// toStringSlice converts a slice of any type to a slice of strings.
+func toStringSlice(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		var result []string
+		for _, item := range v {
+			result = append(result, fmt.Sprintf("%v", item))
+		}
+		return result
+	}
+
+	return nil
+}
+
+// ValidateSchema validates the provided data against a JSON Schema.
+//
+// The `schema` is expected in the form of an unmarshalled JSON Schema document. Only a subset of the JSON Schema
+// keywords is supported: `type`, `enum`, `const`, `properties`, `required`, `additionalProperties`, `items`, `minItems`,
+// `maxItems`, `minLength`, `maxLength`, `pattern`, `minimum` and `maximum`.
+//
+// It returns the first validation error found, if any.
+func ValidateSchema(data any, schema map[string]any) error {
+	return validateSchema(data, schema, "$")
+}
+
+// PutValidated works like Put but it validates the updated data against the provided JSON Schema.
+//
+// If the updated data turns out invalid the changes are reverted in place, so that the `data` remains untouched, and
+// the validation error is returned. Only the objects and the arrays along the JSONPath are copied, shallowly, to be
+// able to revert them.
+func PutValidated(data map[string]any, jsonPath string, value any, schema map[string]any) error {
+	if data == nil {
+		return dataValidationError{data: data, errorType: dataValidationErrorNotMap}
+	}
+
+	j := newJournal()
+	if err := j.recordPath(data, jsonPath); err != nil {
+		return err
+	}
+
+	if err := Put(data, jsonPath, value); err != nil {
+		j.restore()
+		return err
+	}
+
+	if err := ValidateSchema(data, schema); err != nil {
+		j.restore()
+		return err
+	}
+
+	return nil
+}
+
+// schemaAllowsType returns whether the schema allows values of the type, either explicitly or by not restricting them.
+func schemaAllowsType(schema map[string]any, schemaType string) bool {
+	types := schemaTypes(schema)
+	for _, t := range types {
+		if t == schemaType {
+			return true
+		}
+	}
+
+	return len(types) == 0
+}
+
+// subSchema returns the sub-schema of the values found at the JSONPath, which is nil if the schema does not restrict
+// them. An error is returned if the schema does not allow the objects and the arrays the JSONPath goes through.
+func subSchema(schema map[string]any, jsonPath string) (map[string]any, error) {
+	nodes, err := parseJsonPath(jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "$"
+	for _, n := range nodes {
+		if schema == nil {
+			return nil, nil
+		}
+		if !schemaAllowsType(schema, "object") {
+			return nil, schemaValidationError{path, fmt.Sprintf("expected type '%v' but got 'object'", strings.Join(schemaTypes(schema), "|"))}
+		}
+
+		name := n.getName()
+		if property, ok := toSchema(schema["properties"])[name]; ok {
+			schema = toSchema(property)
+		} else if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+			return nil, schemaValidationError{path, fmt.Sprintf("additional property '%v' is not allowed", name)}
+		} else {
+			schema = toSchema(schema["additionalProperties"])
+		}
+		path += "." + name
+
+		if !isArrayNode(n) || schema == nil {
+			continue
+		}
+		if !schemaAllowsType(schema, "array") {
+			return nil, schemaValidationError{path, fmt.Sprintf("expected type '%v' but got 'array'", strings.Join(schemaTypes(schema), "|"))}
+		}
+		schema = toSchema(schema["items"])
+	}
+
+	return schema, nil
+}
+
+// ensureSchemaNode returns the sub-schema of the provided schema for the given node of the JSONPath, creating it if
+// not present.
+func ensureSchemaNode(schema map[string]any, n nodeDataAccessor, jsonPath string) (map[string]any, error) {
+	if schemaType, ok := schema["type"]; ok && schemaType != "object" {
+		return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Node '%v' conflicts with type '%v'", n.getName(), schemaType), Path: jsonPath, Segment: n.getName()}
+	}
+	schema["type"] = "object"
+
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		properties = map[string]any{}
+		schema["properties"] = properties
+	}
+
+	property, ok := properties[n.getName()].(map[string]any)
+	if !ok {
+		property = map[string]any{}
+		properties[n.getName()] = property
+	}
+
+	if !isArrayNode(n) {
+		return property, nil
+	}
+
+	if schemaType, ok := property["type"]; ok && schemaType != "array" {
+		return nil, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Node '%v' conflicts with type '%v'", n.getName(), schemaType), Path: jsonPath, Segment: n.getName()}
+	}
+	property["type"] = "array"
+
+	items, ok := property["items"].(map[string]any)
+	if !ok {
+		items = map[string]any{}
+		property["items"] = items
+	}
+
+	return items, nil
+}
+
+// SchemaFromMappers generates a JSON Schema describing the shape of the destination data produced by the provided mappers.
+//
+// The objects and arrays are derived from the destination JSONPaths of the mappers whereas the types of the leaves
+// are defined by their DstType. Leaves without DstType are allowed to be of any type.
+//
+// An error is returned if a destination JSONPath cannot be parsed or if two mappers describe conflicting shapes.
+func SchemaFromMappers(mappers []Mapper) (map[string]any, error) {
+	schema := map[string]any{"type": "object"}
+
+	for i, mapper := range mappers {
+		if err := validateMapper(mapper); err != nil {
+			return nil, fmt.Errorf("Mapper[%v]: %w", i, err)
+		}
+
+		// appending to an array is described the same way as the rest of its elements
+		nodes, err := parseJsonPath(strings.ReplaceAll(mapper.DstJsonPath, "[+]", "[*]"))
+		if err != nil {
+			return nil, fmt.Errorf("Mapper[%v]: %w", i, err)
+		}
+
+		current := schema
+		for _, n := range nodes {
+			if n.getName() == "*" {
+				return nil, fmt.Errorf("Mapper[%v]: %w", i, &Error{Code: ErrCodeInvalidPath, Message: "Wildcard not allowed in destination path.", Path: mapper.DstJsonPath, Segment: "*"})
+			}
+
+			if current, err = ensureSchemaNode(current, n, mapper.DstJsonPath); err != nil {
+				return nil, fmt.Errorf("Mapper[%v]: %w", i, err)
+			}
+		}
+
+		if mapper.DstType == "" {
+			continue
+		}
+
+		if schemaType, ok := current["type"]; ok && schemaType != mapper.DstType {
+			return nil, fmt.Errorf("Mapper[%v]: %w", i, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Type '%v' conflicts with type '%v'", mapper.DstType, schemaType), Path: mapper.DstJsonPath})
+		}
+		current["type"] = mapper.DstType
+	}
+
+	return schema, nil
+}
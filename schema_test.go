@@ -0,0 +1,312 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+var bookSchema = map[string]any{
+	"type":     "object",
+	"required": []any{"book"},
+	"properties": map[string]any{
+		"book": map[string]any{
+			"type":                 "object",
+			"required":             []any{"author"},
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"author": map[string]any{"type": "string", "minLength": 2, "maxLength": 20, "pattern": "^[A-Z]"},
+				"price":  map[string]any{"type": "number", "minimum": 0, "maximum": 100},
+				"year":   map[string]any{"type": "integer"},
+				"genre":  map[string]any{"enum": []any{"philosophy", "novel"}},
+				"format": map[string]any{"const": "paperback"},
+				"tags": map[string]any{
+					"type":     "array",
+					"minItems": 1,
+					"maxItems": 2,
+					"items":    map[string]any{"type": "string"},
+				},
+				"isbn": map[string]any{"type": []any{"string", "null"}},
+			},
+		},
+	},
+}
+
+type ValidateSchemaTestCase struct {
+	data                 any
+	expectedErrorMessage string
+}
+
+func TestValidateSchema(t *testing.T) {
+	testCases := []ValidateSchemaTestCase{
+		{
+			data: map[string]any{
+				"book": map[string]any{
+					"author": "Nietzsche",
+					"price":  15.5,
+					"year":   1883,
+					"genre":  "philosophy",
+					"format": "paperback",
+					"tags":   []any{"classic"},
+					"isbn":   nil,
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			data:                 []any{},
+			expectedErrorMessage: "schemaValidationError: $: expected type 'object' but got 'array'",
+		},
+		{
+			data:                 map[string]any{},
+			expectedErrorMessage: "schemaValidationError: $: missing required property 'book'",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "title": "Book1"}},
+			expectedErrorMessage: "schemaValidationError: $.book: additional property 'title' is not allowed",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": 1}},
+			expectedErrorMessage: "schemaValidationError: $.book.author: expected type 'string' but got 'number'",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "N"}},
+			expectedErrorMessage: "schemaValidationError: $.book.author: expected length of at least 2 but got 1",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Ω"}},
+			expectedErrorMessage: "schemaValidationError: $.book.author: expected length of at least 2 but got 1",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Zoë Ångström Ørstéd"}},
+			expectedErrorMessage: "",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "nietzsche"}},
+			expectedErrorMessage: "schemaValidationError: $.book.author: value \"nietzsche\" does not match pattern '^[A-Z]'",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "price": 150}},
+			expectedErrorMessage: "schemaValidationError: $.book.price: value 150 is greater than maximum 100",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "price": -1}},
+			expectedErrorMessage: "schemaValidationError: $.book.price: value -1 is less than minimum 0",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "year": 1883.5}},
+			expectedErrorMessage: "schemaValidationError: $.book.year: expected type 'integer' but got 'number'",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "genre": "poetry"}},
+			expectedErrorMessage: "schemaValidationError: $.book.genre: value \"poetry\" is not one of [philosophy novel]",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "format": "hardcover"}},
+			expectedErrorMessage: "schemaValidationError: $.book.format: value \"hardcover\" is not equal to \"paperback\"",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "tags": []any{}}},
+			expectedErrorMessage: "schemaValidationError: $.book.tags: expected at least 1 items but got 0",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "tags": []any{"a", "b", "c"}}},
+			expectedErrorMessage: "schemaValidationError: $.book.tags: expected at most 2 items but got 3",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "tags": []any{"a", 1}}},
+			expectedErrorMessage: "schemaValidationError: $.book.tags[1]: expected type 'string' but got 'number'",
+		},
+		{
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche", "isbn": 1}},
+			expectedErrorMessage: "schemaValidationError: $.book.isbn: expected type 'string|null' but got 'number'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ValidateSchema(%v)=%v", i, tc.data, tc.expectedErrorMessage), func(t *testing.T) {
+			err := ValidateSchema(tc.data, bookSchema)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+		})
+	}
+}
+
+type PutValidatedTestCase struct {
+	jsonPath             string
+	data                 map[string]any
+	value                any
+	expectedErrorMessage string
+	expectedUpdatedData  map[string]any
+}
+
+func TestPutValidated(t *testing.T) {
+	testCases := []PutValidatedTestCase{
+		{
+			jsonPath:             "$.book.price",
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+			value:                10,
+			expectedErrorMessage: "",
+			expectedUpdatedData:  map[string]any{"book": map[string]any{"author": "Nietzsche", "price": 10}},
+		},
+		{
+			jsonPath:             "$.book.price",
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+			value:                "10",
+			expectedErrorMessage: "schemaValidationError: $.book.price: expected type 'number' but got 'string'",
+			expectedUpdatedData:  map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+		},
+		{
+			jsonPath:             "book.price",
+			data:                 map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+			value:                10,
+			expectedErrorMessage: "JSONPath should start with '$.'",
+			expectedUpdatedData:  map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - PutValidated(%v, %v, %v)=%v", i, tc.data, tc.jsonPath, tc.value, tc.expectedErrorMessage), func(t *testing.T) {
+			err := PutValidated(tc.data, tc.jsonPath, tc.value, bookSchema)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedUpdatedData, tc.data) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedUpdatedData), gu.Prettify(tc.data))
+			}
+		})
+	}
+}
+
+func TestPutValidatedInPlace(t *testing.T) {
+	book := map[string]any{"author": "Nietzsche", "price": 10}
+	data := map[string]any{"book": book}
+
+	if err := PutValidated(data, "$.book.price", 20, bookSchema); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if book["price"] != 20 {
+		t.Errorf("Expected the referenced book to be updated, but got '%v'", book)
+	}
+
+	err := PutValidated(data, "$.book.price", 200, bookSchema)
+	if ErrorCode(err) != ErrCodeSchemaValidation {
+		t.Fatalf("Expected a schema validation error, but got '%v'", err)
+	}
+	if book["price"] != 20 || data["book"].(map[string]any)["price"] != 20 {
+		t.Errorf("Expected the referenced book to be restored, but got '%v'", book)
+	}
+}
+
+type SchemaFromMappersTestCase struct {
+	mappers              []Mapper
+	expectedSchema       map[string]any
+	expectedErrorMessage string
+}
+
+func TestSchemaFromMappers(t *testing.T) {
+	testCases := []SchemaFromMappersTestCase{
+		{
+			mappers: []Mapper{
+				{DstJsonPath: "$.info.authors", DstType: "array"},
+				{DstJsonPath: "$.info.count", DstType: "integer"},
+				{DstJsonPath: "$.books[*].title", DstType: "string"},
+				{DstJsonPath: "$.books[*].price"},
+			},
+			expectedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"info": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"authors": map[string]any{"type": "array"},
+							"count":   map[string]any{"type": "integer"},
+						},
+					},
+					"books": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"title": map[string]any{"type": "string"},
+								"price": map[string]any{},
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			mappers: []Mapper{
+				{DstJsonPath: "$.info", DstType: "string"},
+				{DstJsonPath: "$.info.count", DstType: "integer"},
+			},
+			expectedSchema:       nil,
+			expectedErrorMessage: "Mapper[1]: Node 'count' conflicts with type 'string'",
+		},
+		{
+			mappers: []Mapper{
+				{DstJsonPath: "$.info.count", DstType: "integer"},
+				{DstJsonPath: "$.info.count", DstType: "string"},
+			},
+			expectedSchema:       nil,
+			expectedErrorMessage: "Mapper[1]: Type 'string' conflicts with type 'integer'",
+		},
+		{
+			mappers: []Mapper{
+				{DstJsonPath: "$..count", DstType: "integer"},
+			},
+			expectedSchema:       nil,
+			expectedErrorMessage: "Mapper[0]: Reccursive descent not allowed in destination path.",
+		},
+		{
+			mappers: []Mapper{
+				{DstJsonPath: "$.info.*", DstType: "integer"},
+			},
+			expectedSchema:       nil,
+			expectedErrorMessage: "Mapper[0]: Wildcard not allowed in destination path.",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - SchemaFromMappers(%v)=%v", i, tc.mappers, tc.expectedErrorMessage), func(t *testing.T) {
+			schema, err := SchemaFromMappers(tc.mappers)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedSchema, schema) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedSchema), gu.Prettify(schema))
+			}
+		})
+	}
+}
+
+func TestSchemaFromMappersValidatesMapOutput(t *testing.T) {
+	src := map[string]any{
+		"library": map[string]any{
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": "15"},
+			},
+		},
+	}
+	dst := map[string]any{}
+	mappers := []Mapper{
+		{SrcJsonPath: "$.library.books.author", DstJsonPath: "$.info.authors", DstType: "array"},
+		{SrcJsonPath: "$.library.books.price", DstJsonPath: "$.info.prices", DstType: "array"},
+	}
+
+	schema, err := SchemaFromMappers(mappers)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	Map(src, dst, mappers)
+
+	if err := ValidateSchema(dst, schema); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
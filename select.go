@@ -0,0 +1,244 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"sort"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// isPluralJsonPath returns whether the nodes of a JSONPath resolve to multiple values grouped in an array rather
+// than a single value that happens to be an array.
+func isPluralJsonPath(data map[string]any, nodes []nodeDataAccessor) bool {
+	if len(nodes) == 0 {
+		return false
+	}
+
+	for _, n := range nodes {
+		if n.getName() == "" || n.getName() == "*" || isArrayNode(n) {
+			return true
+		}
+	}
+
+	parent, err := walkNodes(data, nodes[:len(nodes)-1])
+
+	return err == nil && gu.IsSlice(parent)
+}
+
+// Select projects the values retrieved by the provided JSONPaths into a new object keyed by the provided keys.
+//
+// If any of the JSONPaths resolves to multiple values (i.e. it contains array nodes, wildcards or recursive descent,
+// or it descends through an array) then an array of objects is returned instead, where the i-th object holds the
+// i-th value of each plural path. Single valued paths are repeated in every object.
+//
+// Keys whose JSONPath cannot be resolved are omitted. An error is returned if any of the JSONPaths is invalid.
+func Select(data map[string]any, projection map[string]string) (any, error) {
+	keys := make([]string, 0, len(projection))
+	for key := range projection {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make(map[string]any, len(keys))
+	plural := make(map[string]bool, len(keys))
+	length := -1
+
+	for _, key := range keys {
+		nodes, err := parseJsonPath(projection[key])
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", key, err)
+		}
+
+		value, err := walkNodes(data, nodes)
+		if err != nil {
+			continue
+		}
+		values[key] = value
+
+		if isPluralJsonPath(data, nodes) && gu.IsSlice(value) {
+			plural[key] = true
+			if l := len(value.([]any)); l > length {
+				length = l
+			}
+		}
+	}
+
+	if length == -1 {
+		return values, nil
+	}
+
+	result := make([]any, length)
+	for i := range result {
+		item := make(map[string]any, len(keys))
+		for key, value := range values {
+			if !plural[key] {
+				item[key] = value
+			} else if i < len(value.([]any)) {
+				item[key] = value.([]any)[i]
+			}
+		}
+		result[i] = item
+	}
+
+	return result, nil
+}
+
+// extractedLeaf is a branch of the data selected as a whole by Extract.
+type extractedLeaf struct {
+	value any
+}
+
+// extractedArray holds the selected elements of an array by their original index.
+type extractedArray map[int]any
+
+// extractInto adds the value of the provided location to the selection of the node and returns the updated node.
+func extractInto(node any, location []any, value any) any {
+	if _, ok := node.(extractedLeaf); ok {
+		return node
+	}
+
+	if len(location) == 0 {
+		return extractedLeaf{value: value}
+	}
+
+	switch step := location[0].(type) {
+	case string:
+		obj, ok := node.(map[string]any)
+		if !ok {
+			obj = make(map[string]any)
+		}
+		obj[step] = extractInto(obj[step], location[1:], value)
+		return obj
+	case int:
+		arr, ok := node.(extractedArray)
+		if !ok {
+			arr = make(extractedArray)
+		}
+		arr[step] = extractInto(arr[step], location[1:], value)
+		return arr
+	}
+
+	return node
+}
+
+// extracted converts the selection of the node to the resulting document. The selected elements of an array keep
+// their relative order.
+func extracted(node any) any {
+	switch n := node.(type) {
+	case extractedLeaf:
+		return deepCopy(n.value)
+	case map[string]any:
+		for key, value := range n {
+			n[key] = extracted(value)
+		}
+		return n
+	case extractedArray:
+		indices := make([]int, 0, len(n))
+		for i := range n {
+			indices = append(indices, i)
+		}
+		sort.Ints(indices)
+
+		items := make([]any, 0, len(indices))
+		for _, i := range indices {
+			items = append(items, extracted(n[i]))
+		}
+		return items
+	}
+
+	return node
+}
+
+// Extract returns a new document holding only the branches matched by the provided JSONPaths with their original
+// nesting, i.e. `$.store.books[?(@.price < 10)].title` results in `{"store": {"books": [{"title": ...}]}}`, which is
+// useful for building sparse responses out of a client supplied field mask. The matched elements of an array keep
+// their relative order but not their indices. The matched values are deep copies.
+//
+// Paths without matches are ignored. An error is returned if any of the JSONPaths is invalid.
+func Extract(data map[string]any, paths []string) (map[string]any, error) {
+	var root any = make(map[string]any)
+
+	for _, jsonPath := range paths {
+		matches, err := findMatches(data, jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", jsonPath, err)
+		}
+
+		for _, m := range matches {
+			root = extractInto(root, m.location, m.value)
+		}
+	}
+
+	return extracted(root).(map[string]any), nil
+}
+
+// validateSelection checks that the values of a selection are booleans or nested selections.
+func validateSelection(selection map[string]any, path string) error {
+	for _, key := range sortedKeys(selection) {
+		keyPath := joinSpecPath(path, key)
+
+		switch s := selection[key].(type) {
+		case bool:
+		case map[string]any:
+			if err := validateSelection(s, keyPath); err != nil {
+				return err
+			}
+		default:
+			return &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Invalid selection of '%v': expected a boolean or an object", keyPath), Segment: keyPath}
+		}
+	}
+
+	return nil
+}
+
+// project applies the selection on the value. It returns false if the value cannot hold the selected keys.
+func project(value any, selection map[string]any) (any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any)
+		for key, s := range selection {
+			child, ok := v[key]
+			if !ok {
+				continue
+			}
+
+			switch s := s.(type) {
+			case bool:
+				if s {
+					result[key] = deepCopy(child)
+				}
+			case map[string]any:
+				if projected, ok := project(child, s); ok {
+					result[key] = projected
+				}
+			}
+		}
+		return result, true
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			if projected, ok := project(item, selection); ok {
+				items = append(items, projected)
+			}
+		}
+		return items, true
+	}
+
+	return nil, false
+}
+
+// Project shapes the data after a nested selection in the manner of GraphQL selection sets, i.e.
+// `{"book": {"title": true, "author": {"name": true}}}`, where a key selects its whole value if true or the keys of
+// its nested selection if an object. Nested selections apply on each element of arrays. Selected keys missing from
+// the data are omitted as well as the elements of arrays which are not objects.
+//
+// The selected values are deep copies. An error is returned if the selection holds anything but booleans and objects.
+func Project(data map[string]any, selection map[string]any) (map[string]any, error) {
+	if err := validateSelection(selection, ""); err != nil {
+		return nil, err
+	}
+
+	result, _ := project(data, selection)
+
+	return result.(map[string]any), nil
+}
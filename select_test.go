@@ -0,0 +1,206 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type SelectTestCase struct {
+	projection           map[string]string
+	expectedResult       any
+	expectedErrorMessage string
+}
+
+func TestSelect(t *testing.T) {
+	data := map[string]any{
+		"book": map[string]any{"title": "Book1", "author": "Nietzsche", "tags": []any{"classic", "philosophy"}},
+		"store": map[string]any{
+			"name": "Store1",
+			"books": []any{
+				map[string]any{"title": "Book1", "price": 15},
+				map[string]any{"title": "Book2", "price": 5},
+				map[string]any{"title": "Book3"},
+			},
+		},
+	}
+
+	testCases := []SelectTestCase{
+		{
+			projection: map[string]string{"title": "$.book.title", "by": "$.book.author", "tags": "$.book.tags", "isbn": "$.book.isbn"},
+			expectedResult: map[string]any{
+				"title": "Book1",
+				"by":    "Nietzsche",
+				"tags":  []any{"classic", "philosophy"},
+			},
+		},
+		{
+			projection: map[string]string{"title": "$.store.books.title", "store": "$.store.name"},
+			expectedResult: []any{
+				map[string]any{"title": "Book1", "store": "Store1"},
+				map[string]any{"title": "Book2", "store": "Store1"},
+				map[string]any{"title": "Book3", "store": "Store1"},
+			},
+		},
+		{
+			projection: map[string]string{"title": "$.store.books[0,1].title", "price": "$.store.books[?(@.price)].price"},
+			expectedResult: []any{
+				map[string]any{"title": "Book1", "price": 15},
+				map[string]any{"title": "Book2", "price": 5},
+			},
+		},
+		{
+			projection: map[string]string{"price": "$..price", "title": "$.store.books[*].title"},
+			expectedResult: []any{
+				map[string]any{"title": "Book1", "price": 15},
+				map[string]any{"title": "Book2", "price": 5},
+				map[string]any{"title": "Book3"},
+			},
+		},
+		{
+			projection:           map[string]string{"title": "book.title"},
+			expectedErrorMessage: "title: JSONPath should start with '$.'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Select(%v)=%v", i, tc.projection, tc.expectedErrorMessage), func(t *testing.T) {
+			result, err := Select(data, tc.projection)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(tc.expectedResult), gu.Prettify(result))
+			}
+		})
+	}
+}
+
+type ExtractTestCase struct {
+	paths                []string
+	expectedResult       map[string]any
+	expectedErrorMessage string
+}
+
+func TestExtract(t *testing.T) {
+	data := map[string]any{
+		"book": map[string]any{"title": "Book1", "author": "Nietzsche"},
+		"store": map[string]any{
+			"name": "Store1",
+			"books": []any{
+				map[string]any{"title": "Book1", "price": 15, "isbn": "1"},
+				map[string]any{"title": "Book2", "price": 5, "isbn": "2"},
+				map[string]any{"title": "Book3", "price": 8},
+			},
+		},
+	}
+
+	testCases := []ExtractTestCase{
+		{
+			paths:          []string{"$.book.title", "$.store.name"},
+			expectedResult: map[string]any{"book": map[string]any{"title": "Book1"}, "store": map[string]any{"name": "Store1"}},
+		},
+		{
+			paths: []string{"$.store.books[?(@.price < 10)].title", "$.store.books.isbn"},
+			expectedResult: map[string]any{
+				"store": map[string]any{
+					"books": []any{
+						map[string]any{"isbn": "1"},
+						map[string]any{"title": "Book2", "isbn": "2"},
+						map[string]any{"title": "Book3"},
+					},
+				},
+			},
+		},
+		{
+			paths:          []string{"$.book.title", "$.book"},
+			expectedResult: map[string]any{"book": map[string]any{"title": "Book1", "author": "Nietzsche"}},
+		},
+		{
+			paths:          []string{"$..author", "$.missing"},
+			expectedResult: map[string]any{"book": map[string]any{"author": "Nietzsche"}},
+		},
+		{
+			paths:          []string{"$"},
+			expectedResult: data,
+		},
+		{
+			paths:                []string{"$.book."},
+			expectedErrorMessage: "$.book.: JSONPath should not end with '.'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Extract(%v)=%v", i, tc.paths, tc.expectedErrorMessage), func(t *testing.T) {
+			result, err := Extract(data, tc.paths)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedResult), gu.Prettify(result))
+			}
+		})
+	}
+
+	result, _ := Extract(data, []string{"$.book"})
+	result["book"].(map[string]any)["title"] = "changed"
+	if data["book"].(map[string]any)["title"] != "Book1" {
+		t.Errorf("Expected the extracted document not to share values with the data")
+	}
+}
+
+type ProjectTestCase struct {
+	selection            map[string]any
+	expectedResult       map[string]any
+	expectedErrorMessage string
+}
+
+func TestProject(t *testing.T) {
+	data := map[string]any{
+		"book": map[string]any{
+			"title":  "Book1",
+			"author": map[string]any{"name": "Nietzsche", "born": 1844},
+			"tags":   []any{"classic", "philosophy"},
+		},
+		"books": []any{
+			map[string]any{"title": "Book1", "price": 15},
+			"invalid",
+			map[string]any{"title": "Book2", "price": 5},
+		},
+	}
+
+	testCases := []ProjectTestCase{
+		{
+			selection: map[string]any{"book": map[string]any{"title": true, "author": map[string]any{"name": true}, "isbn": true}},
+			expectedResult: map[string]any{
+				"book": map[string]any{"title": "Book1", "author": map[string]any{"name": "Nietzsche"}},
+			},
+		},
+		{
+			selection:      map[string]any{"books": map[string]any{"title": true, "price": false}},
+			expectedResult: map[string]any{"books": []any{map[string]any{"title": "Book1"}, map[string]any{"title": "Book2"}}},
+		},
+		{
+			selection:      map[string]any{"book": map[string]any{"tags": true, "title": map[string]any{"x": true}}},
+			expectedResult: map[string]any{"book": map[string]any{"tags": []any{"classic", "philosophy"}}},
+		},
+		{
+			selection:            map[string]any{"book": map[string]any{"author": map[string]any{"name": 1}}},
+			expectedErrorMessage: "Invalid selection of 'book.author.name': expected a boolean or an object",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Project(%v)=%v", i, tc.selection, tc.expectedErrorMessage), func(t *testing.T) {
+			result, err := Project(data, tc.selection)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if !cmp.Equal(tc.expectedResult, result) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedResult), gu.Prettify(result))
+			}
+		})
+	}
+}
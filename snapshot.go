@@ -0,0 +1,247 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SnapshotID identifies a snapshot of a Document.
+type SnapshotID int
+
+// savedValue is the value found at a location of the document data, given as a list of keys, at the time of a
+// snapshot.
+type savedValue struct {
+	location []string
+	value    any
+	exists   bool
+}
+
+// snapshot holds the values of the locations which were changed after it was taken and before the next one, in the
+// order they were saved. If all is set the saved values cover all the top level keys of the data at the time.
+type snapshot struct {
+	id    SnapshotID
+	saved []savedValue
+	index map[string]bool
+	all   bool
+}
+
+// newSnapshot creates an empty snapshot.
+func newSnapshot(id SnapshotID) *snapshot {
+	return &snapshot{id: id, index: make(map[string]bool)}
+}
+
+// locationKey returns the key of the location in the index of a snapshot.
+func locationKey(location []string) string {
+	return strings.Join(location, "\x00")
+}
+
+// covers returns whether the value of the location, or of one of its ancestors, is saved.
+func (s *snapshot) covers(location []string) bool {
+	for i := 1; i <= len(location); i++ {
+		if s.index[locationKey(location[:i])] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// save saves the value of the location unless it is covered already.
+func (s *snapshot) save(saved savedValue) {
+	if s.covers(saved.location) {
+		return
+	}
+
+	s.saved = append(s.saved, saved)
+	s.index[locationKey(saved.location)] = true
+}
+
+// Snapshot takes a snapshot of the document which can be restored later on. The snapshot is copy-on-write, i.e.
+// nothing is copied when it is taken; the values are copied only the first time they are about to be changed by Put,
+// Delete, Map or a transaction.
+//
+// The copied value is the one of the longest prefix of object keys of the changed JSONPath, i.e. only the `items`
+// array is copied when `$.data.items[0].price` changes. Recursive descents and wildcards right after the root copy
+// the whole data.
+//
+// Changes applied on the data returned by Data are not tracked by the snapshots.
+func (d *Document) Snapshot() SnapshotID {
+	d.lastSnapshotID++
+	d.snapshots = append(d.snapshots, newSnapshot(d.lastSnapshotID))
+
+	return d.lastSnapshotID
+}
+
+// snapshotIndex returns the index of the snapshot with the provided id.
+func (d *Document) snapshotIndex(id SnapshotID) (int, error) {
+	for i, s := range d.snapshots {
+		if s.id == id {
+			return i, nil
+		}
+	}
+
+	return -1, &Error{Code: ErrCodeInvalidOperation, Message: fmt.Sprintf("Unknown snapshot %v", id)}
+}
+
+// Restore reverts the document to the state it was in when the snapshot was taken. The snapshots taken after it are
+// released while the snapshot itself is kept so that it can be restored again.
+//
+// While recording, the reverted changes are recorded as the operations computed by Diff.
+func (d *Document) Restore(id SnapshotID) error {
+	index, err := d.snapshotIndex(id)
+	if err != nil {
+		return err
+	}
+
+	var before map[string]any
+	if d.recording {
+		before = deepCopy(d.data).(map[string]any)
+	}
+	watched := d.watched()
+
+	// the values saved later cover the changes made after the earlier ones so they are restored first
+	for i := len(d.snapshots) - 1; i >= index; i-- {
+		if d.snapshots[i].all {
+			for key := range d.data {
+				if !d.snapshots[i].index[locationKey([]string{key})] {
+					delete(d.data, key)
+				}
+			}
+		}
+		for j := len(d.snapshots[i].saved) - 1; j >= 0; j-- {
+			d.restoreValue(d.snapshots[i].saved[j])
+		}
+	}
+
+	d.snapshots = d.snapshots[:index+1]
+	d.snapshots[index] = newSnapshot(id)
+	d.clearCache()
+
+	if d.recording {
+		d.changeset = append(d.changeset, Diff(before, d.data)...)
+	}
+	d.notify(watched)
+
+	return nil
+}
+
+// Release discards the snapshot freeing the values it holds. The rest of the snapshots can still be restored.
+func (d *Document) Release(id SnapshotID) error {
+	index, err := d.snapshotIndex(id)
+	if err != nil {
+		return err
+	}
+
+	// the previous snapshot needs the values saved by the released one unless it has saved them already
+	if index > 0 {
+		previous := d.snapshots[index-1]
+		previous.all = previous.all || d.snapshots[index].all
+		for _, saved := range d.snapshots[index].saved {
+			previous.save(saved)
+		}
+	}
+
+	d.snapshots = append(d.snapshots[:index:index], d.snapshots[index+1:]...)
+
+	return nil
+}
+
+// restoreValue puts the saved value back to its location, or removes the location if it did not exist.
+func (d *Document) restoreValue(saved savedValue) {
+	parent := d.data
+	for _, key := range saved.location[:len(saved.location)-1] {
+		next, ok := parent[key].(map[string]any)
+		if !ok {
+			return
+		}
+		parent = next
+	}
+
+	key := saved.location[len(saved.location)-1]
+	if saved.exists {
+		parent[key] = saved.value
+	} else {
+		delete(parent, key)
+	}
+}
+
+// changedLocations returns the object keys that lead to the values the JSONPaths may change. It returns false if it
+// cannot tell, i.e. on recursive descent right after the root.
+func changedLocations(jsonPaths []string) ([][]string, bool) {
+	locations := make([][]string, 0, len(jsonPaths))
+
+	for _, jsonPath := range jsonPaths {
+		nodes, err := parseJsonPath(jsonPath)
+		if err != nil || len(nodes) == 0 {
+			return nil, false
+		}
+
+		var location []string
+		for _, n := range nodes {
+			name := n.getName()
+			if name == "" || name == "*" {
+				break
+			}
+			location = append(location, name)
+			// the rest of the path goes through the elements of an array
+			if isArrayNode(n) {
+				break
+			}
+		}
+		if len(location) == 0 {
+			return nil, false
+		}
+
+		locations = append(locations, location)
+	}
+
+	return locations, true
+}
+
+// preserve saves in the latest snapshot the values which the JSONPaths are about to change, unless they are saved
+// already.
+func (d *Document) preserve(jsonPaths ...string) {
+	if len(d.snapshots) == 0 {
+		return
+	}
+
+	locations, ok := changedLocations(jsonPaths)
+	if !ok {
+		d.preserveAll()
+		return
+	}
+
+	for _, location := range locations {
+		d.preserveLocation(location)
+	}
+}
+
+// preserveAll saves in the latest snapshot the values of all the top level keys unless they are saved already.
+func (d *Document) preserveAll() {
+	if len(d.snapshots) == 0 {
+		return
+	}
+
+	d.snapshots[len(d.snapshots)-1].all = true
+	for _, key := range sortedKeys(d.data) {
+		d.preserveLocation([]string{key})
+	}
+}
+
+// preserveLocation saves in the latest snapshot the value of the location unless it is saved already. The location
+// is shortened to its first key which is missing or does not hold an object, since the change creates everything
+// after it.
+func (d *Document) preserveLocation(location []string) {
+	parent := d.data
+	for i, key := range location[:len(location)-1] {
+		next, ok := parent[key].(map[string]any)
+		if !ok {
+			location = location[:i+1]
+			break
+		}
+		parent = next
+	}
+
+	value, exists := parent[location[len(location)-1]]
+	d.snapshots[len(d.snapshots)-1].save(savedValue{location: location, value: deepCopy(value), exists: exists})
+}
@@ -0,0 +1,98 @@
+package jsonmanu
+
+import (
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDocumentSnapshot(t *testing.T) {
+	original := map[string]any{
+		"book":  map[string]any{"author": "Nietzsche", "title": "Book1"},
+		"store": map[string]any{"name": "Store1"},
+	}
+	doc := NewDocument(deepCopy(original).(map[string]any))
+
+	first := doc.Snapshot()
+	doc.Put("$.book.author", "Stirner")
+	doc.Put("$.year", 1844)
+
+	if doc.snapshots[0].covers([]string{"store"}) || doc.snapshots[0].covers([]string{"book", "title"}) {
+		t.Errorf("Expected the unchanged values not to be copied")
+	}
+
+	second := doc.Snapshot()
+	doc.Delete("$.store")
+	doc.Map(map[string]any{"title": "Book2"}, []Mapper{{SrcJsonPath: "$.title", DstJsonPath: "$.book.title"}})
+	afterSecond := deepCopy(doc.Data()).(map[string]any)
+
+	third := doc.Snapshot()
+	doc.Put("$..author", "Unknown")
+	doc.Put("$.extra", true)
+
+	if err := doc.Restore(second); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	expectedData := map[string]any{
+		"book":  map[string]any{"author": "Stirner", "title": "Book1"},
+		"store": map[string]any{"name": "Store1"},
+		"year":  1844,
+	}
+	if !cmp.Equal(expectedData, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(doc.Data()))
+	}
+
+	if err := doc.Restore(third); err == nil {
+		t.Errorf("Expected the later snapshots to be released")
+	}
+
+	doc.Delete("$.store")
+	doc.Put("$.book.title", "Book2")
+	if !cmp.Equal(afterSecond, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(afterSecond), gu.Prettify(doc.Data()))
+	}
+
+	if err := doc.Release(second); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	if err := doc.Restore(first); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	if !cmp.Equal(original, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(original), gu.Prettify(doc.Data()))
+	}
+}
+
+func TestDocumentSnapshotCopiesChangedPaths(t *testing.T) {
+	original := map[string]any{
+		"data": map[string]any{
+			"items": []any{map[string]any{"price": 10}},
+			"meta":  map[string]any{"count": 1, "tags": []any{"a"}},
+		},
+	}
+	doc := NewDocument(deepCopy(original).(map[string]any))
+
+	id := doc.Snapshot()
+	doc.Put("$.data.items[0].price", 20)
+	doc.Put("$.data.meta.count", 2)
+	doc.Put("$.data.meta.owner.name", "Someone")
+	doc.Delete("$.data.meta")
+	doc.Put("$.data.meta.count", 3)
+
+	expectedLocations := [][]string{{"data", "items"}, {"data", "meta", "count"}, {"data", "meta", "owner"}, {"data", "meta"}}
+	var locations [][]string
+	for _, saved := range doc.snapshots[0].saved {
+		locations = append(locations, saved.location)
+	}
+	if !cmp.Equal(expectedLocations, locations) {
+		t.Errorf("Expected the saved locations '%v', but got '%v'", expectedLocations, locations)
+	}
+
+	if err := doc.Restore(id); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	if !cmp.Equal(original, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(original), gu.Prettify(doc.Data()))
+	}
+}
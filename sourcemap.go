@@ -0,0 +1,96 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// SourceMap holds the lines and the columns of the values of a document in the input it was decoded from so that the
+// errors found in the document, i.e. the violations of Validate, can point to the exact location in the original file.
+//
+// The locations refer to the input as it was decoded; the changes applied on the document afterwards are not tracked.
+type SourceMap struct {
+	data map[string]any
+
+	// locations are keyed by the concrete JSONPath of the values in dot notation, i.e. `$.books[0].title`.
+	locations map[string]SpecLocation
+}
+
+// Locate returns the line and the column, both starting from 1, of the value of the provided JSONPath in the input.
+// The members of objects are located by their key. JSONPaths matching multiple values, i.e. `$.books[*].title`, are
+// located at the first one of them.
+//
+// If the value is not found, i.e. a missing required key, it is located at the closest value holding it. The line and
+// the column are 0 if nothing is found.
+func (m *SourceMap) Locate(jsonPath string) (line, column int) {
+	path := jsonPath
+	if matches, err := findMatchesLimit(m.data, jsonPath, 1); err == nil && len(matches) > 0 {
+		path = matches[0].path
+	}
+
+	for p := path; p != ""; p = parentSpecPath(p) {
+		if l, ok := m.locations[p]; ok {
+			return l.Line, l.Column
+		}
+	}
+
+	return 0, 0
+}
+
+// DecodeWithSourceMap works like Decode but it also records the line and the column of every value of the document.
+// It supports the text formats with known positions, i.e. FormatJSON, FormatJSONC and FormatYAML.
+func DecodeWithSourceMap(r io.Reader, format Format) (map[string]any, *SourceMap, error) {
+	if format != FormatJSON && format != FormatJSONC && format != FormatYAML {
+		return nil, nil, &Error{Code: ErrCodeUnsupportedFormat, Message: fmt.Sprintf("Source mapping is not supported for format '%v'", format)}
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := Decode(bytes.NewReader(raw), format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	locations := make(map[string]SpecLocation)
+	for path, l := range specLocations(raw, format) {
+		path = "$." + path
+		locations[path] = SpecLocation{Path: path, Line: l.Line, Column: l.Column}
+	}
+
+	if root, ok := rootLocation(raw, format, locations); ok {
+		locations["$"] = root
+	}
+
+	return data, &SourceMap{data: data, locations: locations}, nil
+}
+
+// rootLocation returns the location of the root of the input, i.e. its opening brace in JSON or its first key in YAML.
+func rootLocation(raw []byte, format Format, locations map[string]SpecLocation) (SpecLocation, bool) {
+	if format == FormatYAML {
+		var root SpecLocation
+		for _, l := range locations {
+			if root.Line == 0 || l.Line < root.Line || (l.Line == root.Line && l.Column < root.Column) {
+				root = l
+			}
+		}
+		root.Path = "$"
+
+		return root, root.Line > 0
+	}
+
+	if format == FormatJSONC {
+		// the comments are blanked out so the locations remain the same
+		raw, _, _ = stripJSONC(raw)
+	}
+
+	offset := len(raw) - len(bytes.TrimLeft(raw, " \t\r\n"))
+	if offset == len(raw) {
+		return SpecLocation{}, false
+	}
+
+	return offsetLocation(raw, offset, "$"), true
+}
@@ -0,0 +1,132 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type SourceMapTestCase struct {
+	jsonPath       string
+	expectedLine   int
+	expectedColumn int
+}
+
+func TestDecodeWithSourceMap(t *testing.T) {
+	inputs := map[Format]string{
+		FormatJSON: `
+{
+  "store": {
+    "name": "Store1",
+    "books": [
+      {"title": "Book1", "price": 15},
+      {"title": "Book2", "price": "20"}
+    ]
+  }
+}`,
+		FormatJSONC: `// the store
+{
+  "store": {
+    "name": "Store1", /* the name */
+    "books": [
+      {"title": "Book1", "price": 15},
+      {"title": "Book2", "price": "20"},
+    ],
+  },
+}`,
+		FormatYAML: `# the store
+store:
+  name: Store1
+  books:
+    - title: Book1
+      price: 15
+    - {title: Book2,
+       price: "20"}
+`,
+	}
+
+	expected := map[Format][]SourceMapTestCase{
+		FormatJSON: {
+			{jsonPath: "$", expectedLine: 2, expectedColumn: 1},
+			{jsonPath: "$.store", expectedLine: 3, expectedColumn: 3},
+			{jsonPath: "$.store.name", expectedLine: 4, expectedColumn: 5},
+			{jsonPath: "$.store.books[1]", expectedLine: 7, expectedColumn: 7},
+			{jsonPath: "$.store.books[1].price", expectedLine: 7, expectedColumn: 26},
+			{jsonPath: "$.store.books[*].title", expectedLine: 6, expectedColumn: 8},
+			{jsonPath: "$.store.books[?(@.price == '20')]", expectedLine: 7, expectedColumn: 7},
+			{jsonPath: "$.store.books[0].author", expectedLine: 6, expectedColumn: 7},
+			{jsonPath: "$.owner", expectedLine: 2, expectedColumn: 1},
+		},
+		FormatJSONC: {
+			{jsonPath: "$", expectedLine: 2, expectedColumn: 1},
+			{jsonPath: "$.store.name", expectedLine: 4, expectedColumn: 5},
+			{jsonPath: "$.store.books[1].price", expectedLine: 7, expectedColumn: 26},
+		},
+		FormatYAML: {
+			{jsonPath: "$", expectedLine: 2, expectedColumn: 1},
+			{jsonPath: "$.store", expectedLine: 2, expectedColumn: 1},
+			{jsonPath: "$.store.name", expectedLine: 3, expectedColumn: 3},
+			{jsonPath: "$.store.books[0]", expectedLine: 5, expectedColumn: 7},
+			{jsonPath: "$.store.books[1].price", expectedLine: 8, expectedColumn: 8},
+			{jsonPath: "$.store.books[0].author", expectedLine: 5, expectedColumn: 7},
+		},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatJSONC, FormatYAML} {
+		_, sourceMap, err := DecodeWithSourceMap(strings.NewReader(inputs[format]), format)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, tc := range expected[format] {
+			t.Run(fmt.Sprintf("%v - Locate(%v)=(%v, %v)", format, tc.jsonPath, tc.expectedLine, tc.expectedColumn), func(t *testing.T) {
+				line, column := sourceMap.Locate(tc.jsonPath)
+				if line != tc.expectedLine || column != tc.expectedColumn {
+					t.Errorf("Expected (%v, %v), but got (%v, %v)", tc.expectedLine, tc.expectedColumn, line, column)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeWithSourceMapViolations(t *testing.T) {
+	input := "{\n  \"items\": [\n    {\"price\": 10},\n    {\"price\": -1}\n  ]\n}\n"
+
+	data, sourceMap, err := DecodeWithSourceMap(strings.NewReader(input), FormatJSON)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	min := 0.0
+	violations := Validate(data, []Rule{{JsonPath: "$.items[*].price", Min: &min}})
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 violation, but got %v", violations)
+	}
+
+	if line, column := sourceMap.Locate(violations[0].Path); line != 4 || column != 6 {
+		t.Errorf("Expected (4, 6), but got (%v, %v)", line, column)
+	}
+}
+
+func TestDecodeWithSourceMapErrors(t *testing.T) {
+	testCases := []struct {
+		input                string
+		format               Format
+		expectedErrorMessage string
+	}{
+		{input: "{}", format: FormatMsgPack, expectedErrorMessage: "Source mapping is not supported for format 'msgpack'"},
+		{input: "- a\n", format: FormatYAML, expectedErrorMessage: "Document root should be an object, got []interface {}"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - DecodeWithSourceMap(%v, %v)=%v", i, tc.input, tc.format, tc.expectedErrorMessage), func(t *testing.T) {
+			data, sourceMap, err := DecodeWithSourceMap(strings.NewReader(tc.input), tc.format)
+			if err == nil || err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if data != nil || sourceMap != nil {
+				t.Errorf("Expected no result, but got '%v' and '%v'", data, sourceMap)
+			}
+		})
+	}
+}
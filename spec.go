@@ -0,0 +1,253 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecLocation is the location of an entry of a mapping configuration.
+type SpecLocation struct {
+
+	// Path is the path of the entry within the configuration, i.e. `mappers[2].transformations[0].type`.
+	Path string
+
+	// Line is the line of the entry in the file the configuration was loaded from starting from 1. It is 0 if unknown.
+	Line int
+
+	// Column is the column of the entry in the file the configuration was loaded from starting from 1. It is 0 if unknown.
+	Column int
+}
+
+func (l SpecLocation) String() string {
+	if l.Line == 0 {
+		return l.Path
+	}
+
+	return fmt.Sprintf("%v (line %v, column %v)", l.Path, l.Line, l.Column)
+}
+
+// SpecError is an error caused by an entry of a mapping configuration along with the location of the entry.
+// It can be retrieved out of the returned errors with errors.As.
+type SpecError struct {
+
+	// Location is the location of the offending entry.
+	Location SpecLocation
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (err *SpecError) Error() string { return err.Err.Error() }
+
+func (err *SpecError) Unwrap() error { return err.Err }
+
+// joinSpecPath returns the path of the key of the entry of the provided path.
+func joinSpecPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// parentSpecPath returns the path of the entry holding the entry of the provided path.
+func parentSpecPath(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+
+	return ""
+}
+
+// offsetLocation returns the location of the byte offset of the raw input.
+func offsetLocation(raw []byte, offset int, path string) SpecLocation {
+	before := raw[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(before, '\n')
+
+	return SpecLocation{Path: path, Line: line, Column: column}
+}
+
+// jsonSpecLocations returns the locations of all the entries of a JSON input. Object entries are located by their key.
+// The locations found before a syntax error are returned.
+func jsonSpecLocations(raw []byte) map[string]SpecLocation {
+	locations := make(map[string]SpecLocation)
+	dec := json.NewDecoder(bytes.NewReader(raw))
+
+	// the decoder's offset is the end of the previous token so the separators are skipped
+	nextOffset := func() int {
+		offset := int(dec.InputOffset())
+		for offset < len(raw) && strings.IndexByte(" \t\r\n,:", raw[offset]) >= 0 {
+			offset++
+		}
+		return offset
+	}
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		offset := nextOffset()
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if _, ok := locations[path]; !ok && path != "" {
+			locations[path] = offsetLocation(raw, offset, path)
+		}
+
+		switch token {
+		case json.Delim('{'):
+			for dec.More() {
+				keyOffset := nextOffset()
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				keyPath := joinSpecPath(path, fmt.Sprintf("%v", key))
+				locations[keyPath] = offsetLocation(raw, keyOffset, keyPath)
+				if err := walk(keyPath); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(fmt.Sprintf("%v[%v]", path, i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+
+		return err
+	}
+	walk("")
+
+	return locations
+}
+
+// yamlSpecLocations returns the locations of all the entries of a YAML input. Mapping entries are located by their key.
+func yamlSpecLocations(raw []byte) map[string]SpecLocation {
+	locations := make(map[string]SpecLocation)
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return locations
+	}
+
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if _, ok := locations[path]; !ok && path != "" {
+			locations[path] = SpecLocation{Path: path, Line: node.Line, Column: node.Column}
+		}
+
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i]
+				keyPath := joinSpecPath(path, key.Value)
+				locations[keyPath] = SpecLocation{Path: keyPath, Line: key.Line, Column: key.Column}
+				walk(node.Content[i+1], keyPath)
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				walk(child, fmt.Sprintf("%v[%v]", path, i))
+			}
+		}
+	}
+	walk(&doc, "")
+
+	return locations
+}
+
+// specLocations returns the locations of all the entries of an input of the provided format if it is supported.
+func specLocations(raw []byte, format Format) map[string]SpecLocation {
+	switch format {
+	case FormatJSON:
+		return jsonSpecLocations(raw)
+	case FormatJSONC:
+		// the comments are blanked out so the locations remain the same
+		if stripped, _, err := stripJSONC(raw); err == nil {
+			return jsonSpecLocations(stripped)
+		}
+	case FormatYAML:
+		return yamlSpecLocations(raw)
+	}
+
+	return nil
+}
+
+// location returns the location of the entry of the provided path. If the entry itself has no known position,
+// i.e. a field left out, the position of the closest entry holding it is used instead.
+func (c MappingConfig) location(path string) SpecLocation {
+	location := SpecLocation{Path: path}
+
+	for p := path; p != ""; p = parentSpecPath(p) {
+		if l, ok := c.Locations[p]; ok {
+			location.Line, location.Column = l.Line, l.Column
+			break
+		}
+	}
+
+	return location
+}
+
+// specError returns the error of the mapper configuration of the provided path located at its offending field,
+// if known, or else at the mapper configuration itself.
+func (c MappingConfig) specError(mapperPath string, err error) *SpecError {
+	path := mapperPath
+
+	var fieldErr *SpecError
+	if errors.As(err, &fieldErr) {
+		path = joinSpecPath(path, fieldErr.Location.Path)
+	}
+
+	return &SpecError{Location: c.location(path), Err: err}
+}
+
+// MapperLocation returns the location of the mapper configuration the mapper of the provided index, as returned by
+// ToMappers, originates from. The mappers instantiated from a recipe are located at the configuration referring to it.
+func (c MappingConfig) MapperLocation(index int) (SpecLocation, bool) {
+	count := 0
+	for i, mapperConfig := range c.Mappers {
+		n := 1
+		if mapperConfig.Recipe != "" {
+			recipe, err := findRecipe(mapperConfig.Recipe, c.Recipes)
+			if err != nil {
+				return SpecLocation{}, false
+			}
+			n = len(recipe.Mappers)
+		}
+
+		if index < count+n {
+			return c.location(fmt.Sprintf("mappers[%v]", i)), true
+		}
+		count += n
+	}
+
+	return SpecLocation{}, false
+}
+
+// Locate wraps an error returned by Map with the mappers of the configuration in a SpecError so that it points to
+// the mapper configuration that caused it. Errors not caused by a mapper are returned as they are.
+func (c MappingConfig) Locate(err error) error {
+	var mapperErr *MapperError
+	if !errors.As(err, &mapperErr) {
+		return err
+	}
+
+	location, ok := c.MapperLocation(mapperErr.Index)
+	if !ok {
+		return err
+	}
+
+	return &SpecError{Location: location, Err: err}
+}
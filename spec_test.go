@@ -0,0 +1,97 @@
+package jsonmanu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type SpecErrorTestCase struct {
+	input            string
+	format           Format
+	expectedLocation SpecLocation
+}
+
+func TestToMappersSpecError(t *testing.T) {
+	testCases := []SpecErrorTestCase{
+		{
+			input:            "mappers:\n  - src: $.a\n    dst: $.b\n  - src: $.c\n    dst: $.d\n    transformations:\n      - type: number\n      - type: upper\n",
+			format:           FormatYAML,
+			expectedLocation: SpecLocation{Path: "mappers[1].transformations[1].type", Line: 8, Column: 9},
+		},
+		{
+			input:            "{\n  \"mappers\": [\n    {\"src\": \"$.a\", \"dst\": \"$.b\", \"timeout\": \"soon\"}\n  ]\n}",
+			format:           FormatJSON,
+			expectedLocation: SpecLocation{Path: "mappers[0].timeout", Line: 3, Column: 34},
+		},
+		{
+			input:            "mappers:\n  - recipe: unknown\n",
+			format:           FormatYAML,
+			expectedLocation: SpecLocation{Path: "mappers[0].recipe", Line: 2, Column: 5},
+		},
+		{
+			input:            "mappers = [{src = '$.a', dst = '$.b', timeout = 'soon'}]",
+			format:           FormatTOML,
+			expectedLocation: SpecLocation{Path: "mappers[0].timeout"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - ToMappers()=%v", i, tc.expectedLocation), func(t *testing.T) {
+			config, err := LoadMappingConfig(strings.NewReader(tc.input), tc.format)
+			if err != nil {
+				t.Fatalf("Expected no error, but got '%v'", err)
+			}
+
+			_, err = config.ToMappers()
+			var specErr *SpecError
+			if !errors.As(err, &specErr) {
+				t.Fatalf("Expected a SpecError, but got '%#v'", err)
+			}
+			if specErr.Location != tc.expectedLocation {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedLocation, specErr.Location)
+			}
+		})
+	}
+}
+
+func TestMappingConfigLocate(t *testing.T) {
+	input := `{
+  "recipes": [{"name": "copy", "mappers": [{"src": "$.a", "dst": "$.b"}, {"src": "$.c", "dst": "$.d"}]}],
+  "mappers": [
+    {"recipe": "copy"},
+    {"src": "$.missing", "dst": "$.e"}
+  ]
+}`
+
+	config, err := LoadMappingConfig(strings.NewReader(input), FormatJSON)
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	mappers, err := config.ToMappers()
+	if err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	errs := Map(map[string]any{"a": 1, "c": 2}, map[string]any{}, mappers)
+	if len(errs) != 1 {
+		t.Fatalf("Expected one error, but got '%v'", errs)
+	}
+
+	err = config.Locate(errs[0])
+	var specErr *SpecError
+	if !errors.As(err, &specErr) {
+		t.Fatalf("Expected a SpecError, but got '%#v'", err)
+	}
+	if expected := (SpecLocation{Path: "mappers[1]", Line: 5, Column: 5}); specErr.Location != expected {
+		t.Errorf("Expected '%v', but got '%v'", expected, specErr.Location)
+	}
+	if err.Error() != errs[0].Error() {
+		t.Errorf("Expected '%v', but got '%v'", errs[0], err)
+	}
+
+	if err := config.Locate(errors.New("other")); err.Error() != "other" {
+		t.Errorf("Expected the error unchanged, but got '%#v'", err)
+	}
+}
@@ -0,0 +1,76 @@
+package jsonmanu
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// statsLargestArrays is the number of the largest arrays reported by Stats.
+const statsLargestArrays = 10
+
+// ArrayStats describes an array found in data.
+type ArrayStats struct {
+
+	// Path is the JSONPath of the array, i.e. `$.store.books`.
+	Path string `json:"path"`
+
+	// Length is the number of the elements of the array.
+	Length int `json:"length"`
+}
+
+// DocStats holds the statistics of a document.
+type DocStats struct {
+
+	// Counts holds the number of the values per JSON type, i.e. `object`, `array`, `string` etc. The root is included.
+	Counts map[string]int `json:"counts"`
+
+	// MaxDepth is the maximum nesting level of the values, where the values of the root are at level 1.
+	MaxDepth int `json:"maxDepth"`
+
+	// LargestArrays holds up to the 10 largest arrays in descending order of length.
+	LargestArrays []ArrayStats `json:"largestArrays"`
+
+	// Size is the approximate size in bytes of the document serialized as JSON.
+	Size int `json:"size"`
+}
+
+// collect walks the value recursively and updates the stats.
+func (s *DocStats) collect(value any, path string, depth int) {
+	s.Counts[schemaTypeOf(value)]++
+	if depth > s.MaxDepth {
+		s.MaxDepth = depth
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			s.collect(v[key], path+"."+key, depth+1)
+		}
+	case []any:
+		s.LargestArrays = append(s.LargestArrays, ArrayStats{Path: path, Length: len(v)})
+		for i, item := range v {
+			s.collect(item, fmt.Sprintf("%v[%v]", path, i), depth+1)
+		}
+	}
+}
+
+// Stats reports the number of the values by type, the maximum depth, the largest arrays and the approximate
+// serialized size of the data in order to help diagnose performance problems of queries.
+func Stats(data map[string]any) DocStats {
+	stats := DocStats{Counts: make(map[string]int)}
+	stats.collect(data, "$", 0)
+
+	sort.SliceStable(stats.LargestArrays, func(i, j int) bool {
+		return stats.LargestArrays[i].Length > stats.LargestArrays[j].Length
+	})
+	if len(stats.LargestArrays) > statsLargestArrays {
+		stats.LargestArrays = stats.LargestArrays[:statsLargestArrays]
+	}
+
+	if raw, err := json.Marshal(data); err == nil {
+		stats.Size = len(raw)
+	}
+
+	return stats
+}
@@ -0,0 +1,46 @@
+package jsonmanu
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStats(t *testing.T) {
+	data := map[string]any{
+		"store": map[string]any{
+			"books": []any{
+				map[string]any{"title": "Book1", "tags": []any{"a"}},
+				map[string]any{"title": "Book2", "price": 15, "available": true},
+			},
+			"owner": nil,
+		},
+		"ids": []any{1, 2, 3},
+	}
+
+	expectedStats := DocStats{
+		Counts: map[string]int{"object": 4, "array": 3, "string": 3, "number": 4, "boolean": 1, "null": 1},
+		// $.store.books[0].tags[0]
+		MaxDepth: 5,
+		LargestArrays: []ArrayStats{
+			{Path: "$.ids", Length: 3},
+			{Path: "$.store.books", Length: 2},
+			{Path: "$.store.books[0].tags", Length: 1},
+		},
+		Size: len(`{"ids":[1,2,3],"store":{"books":[{"tags":["a"],"title":"Book1"},{"available":true,"price":15,"title":"Book2"}],"owner":null}}`),
+	}
+
+	stats := Stats(data)
+	if !cmp.Equal(expectedStats, stats) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedStats, stats)
+	}
+
+	items := make([]any, 12)
+	for i := range items {
+		items[i] = []any{}
+	}
+	stats = Stats(map[string]any{"items": items})
+	if len(stats.LargestArrays) != 10 || stats.LargestArrays[0].Path != "$.items" {
+		t.Errorf("Expected the 10 largest arrays starting with '$.items', but got '%v'", stats.LargestArrays)
+	}
+}
@@ -0,0 +1,44 @@
+package jsonmanu
+
+import (
+	"text/template"
+
+	gu "github.com/antavelos/go-utils"
+)
+
+// Exists returns whether the provided JSONPath can be resolved in the document.
+func (d *Document) Exists(jsonPath string) bool {
+	_, err := d.Get(jsonPath)
+	return err == nil
+}
+
+// Count returns the number of values the provided JSONPath resolves to in the document.
+//
+// An array result counts as many as its elements, a non resolvable path counts as zero and any other value as one.
+func (d *Document) Count(jsonPath string) int {
+	value, err := d.Get(jsonPath)
+	if err != nil {
+		return 0
+	}
+
+	if gu.IsSlice(value) {
+		return len(value.([]any))
+	}
+
+	return 1
+}
+
+// FuncMap returns template functions bound to the document so that templates can query it directly:
+//
+//   - `jget PATH` returns the value the path resolves to. A non resolvable path fails the template execution.
+//   - `jexists PATH` returns whether the path can be resolved.
+//   - `jcount PATH` returns the number of values the path resolves to.
+//
+// The returned map can be converted to html/template.FuncMap as well.
+func (d *Document) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"jget":    d.Get,
+		"jexists": d.Exists,
+		"jcount":  d.Count,
+	}
+}
@@ -0,0 +1,64 @@
+package jsonmanu
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"text/template"
+)
+
+type FuncMapTestCase struct {
+	template             string
+	expectedOutput       string
+	expectedErrorMessage string
+}
+
+func TestDocumentFuncMap(t *testing.T) {
+	doc := NewDocument(map[string]any{
+		"store": map[string]any{
+			"name": "Store1",
+			"books": []any{
+				map[string]any{"author": "Nietzsche", "price": 15},
+				map[string]any{"author": "Stirner", "price": 5},
+			},
+		},
+	})
+
+	testCases := []FuncMapTestCase{
+		{
+			template:       `{{ jget "$.store.name" }}`,
+			expectedOutput: "Store1",
+		},
+		{
+			template:       `{{ range jget "$.store.books[?(@.price > 10)].author" }}{{ . }};{{ end }}`,
+			expectedOutput: "Nietzsche;",
+		},
+		{
+			template:       `{{ jexists "$.store.name" }} {{ jexists "$.store.owner" }}`,
+			expectedOutput: "true false",
+		},
+		{
+			template:       `{{ jcount "$.store.books" }} {{ jcount "$.store.name" }} {{ jcount "$.store.owner" }}`,
+			expectedOutput: "2 1 0",
+		},
+		{
+			template:             `{{ jget "$.store.owner" }}`,
+			expectedErrorMessage: "template: test:1:3: executing \"test\" at <jget \"$.store.owner\">: error calling jget: dataValidationError: Source key not found: 'owner'",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - %v", i, tc.template), func(t *testing.T) {
+			tmpl := template.Must(template.New("test").Funcs(doc.FuncMap()).Parse(tc.template))
+
+			var buf bytes.Buffer
+			err := tmpl.Execute(&buf, nil)
+			if (err == nil && len(tc.expectedErrorMessage) > 0) || (err != nil && err.Error() != tc.expectedErrorMessage) {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err == nil && buf.String() != tc.expectedOutput {
+				t.Errorf("Expected '%v', but got '%v'", tc.expectedOutput, buf.String())
+			}
+		})
+	}
+}
@@ -191,3 +191,385 @@ func (t NumberTransformer) Transform(value any) (any, error) {
 
 	return fv, nil
 }
+
+// ZipTransformer converts an object of arrays to an array of objects, i.e. `{"names": ["A", "B"], "ages": [1, 2]}`
+// to `[{"name": "A", "age": 1}, {"name": "B", "age": 2}]`.
+type ZipTransformer struct {
+
+	// Fields maps the keys of the arrays to the keys of the fields of the produced objects, i.e. `names` to `name`.
+	// If empty the keys of the arrays are used as they are, otherwise the arrays not found in it are ignored.
+	Fields map[string]string
+}
+
+// ZipTransformer Transform applies the zip transformation.
+//
+// It expects an object whose values are arrays.
+//
+// The i-th object of the returned array holds the i-th element of each array. Arrays shorter than the longest one
+// leave the corresponding field out of the last objects.
+func (t ZipTransformer) Transform(value any) (any, error) {
+	valueMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("Value is not an object.")
+	}
+
+	result := []any{}
+	for _, key := range sortedKeys(valueMap) {
+		field := key
+		if len(t.Fields) > 0 {
+			if field, ok = t.Fields[key]; !ok {
+				continue
+			}
+		}
+
+		items, ok := valueMap[key].([]any)
+		if !ok {
+			return nil, fmt.Errorf("Value of key '%v' is not an array.", key)
+		}
+
+		for i, item := range items {
+			if i == len(result) {
+				result = append(result, map[string]any{})
+			}
+			result[i].(map[string]any)[field] = item
+		}
+	}
+
+	return result, nil
+}
+
+// UnzipTransformer converts an array of objects to an object of arrays, i.e. `[{"name": "A", "age": 1}, {"name": "B", "age": 2}]`
+// to `{"names": ["A", "B"], "ages": [1, 2]}`. It is the reverse of ZipTransformer.
+type UnzipTransformer struct {
+
+	// Fields maps the keys of the fields of the objects to the keys of the produced arrays, i.e. `name` to `names`.
+	// If empty the keys of the fields are used as they are, otherwise the fields not found in it are ignored.
+	Fields map[string]string
+}
+
+// UnzipTransformer Transform applies the unzip transformation.
+//
+// It expects an array of objects so it should be used with Transformation.AsArray.
+//
+// Objects missing a field contribute a null to the corresponding array so that all the arrays have the same length.
+func (t UnzipTransformer) Transform(value any) (any, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, errors.New("Value is not an array.")
+	}
+
+	fields := make(map[string]string)
+	for i, item := range items {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("Element %v is not an object.", i)
+		}
+
+		for key := range itemMap {
+			if len(t.Fields) == 0 {
+				fields[key] = key
+			} else if arrayKey, ok := t.Fields[key]; ok {
+				fields[key] = arrayKey
+			}
+		}
+	}
+
+	result := make(map[string]any, len(fields))
+	for key, arrayKey := range fields {
+		array := make([]any, len(items))
+		for i, item := range items {
+			array[i] = item.(map[string]any)[key]
+		}
+		result[arrayKey] = array
+	}
+
+	return result, nil
+}
+
+// PivotTransformer converts an array of key/value pair objects to an object, i.e. `[{"key": "color", "value": "red"}]`
+// to `{"color": "red"}`.
+type PivotTransformer struct {
+
+	// KeyField is the field of the pair objects holding the key. It defaults to `key`.
+	KeyField string
+
+	// ValueField is the field of the pair objects holding the value. It defaults to `value`.
+	ValueField string
+}
+
+// pairFields returns the key and value fields of pair objects applying the defaults.
+func pairFields(keyField, valueField string) (string, string) {
+	if keyField == "" {
+		keyField = "key"
+	}
+	if valueField == "" {
+		valueField = "value"
+	}
+
+	return keyField, valueField
+}
+
+// PivotTransformer Transform applies the pivot transformation.
+//
+// It expects an array of objects with a string key so it should be used with Transformation.AsArray.
+//
+// If a key occurs more than once the last value wins.
+func (t PivotTransformer) Transform(value any) (any, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, errors.New("Value is not an array.")
+	}
+
+	keyField, valueField := pairFields(t.KeyField, t.ValueField)
+
+	result := make(map[string]any, len(items))
+	for i, item := range items {
+		pair, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("Element %v is not an object.", i)
+		}
+
+		key, ok := pair[keyField].(string)
+		if !ok {
+			return nil, fmt.Errorf("Element %v has no string '%v' field.", i, keyField)
+		}
+
+		result[key] = pair[valueField]
+	}
+
+	return result, nil
+}
+
+// UnpivotTransformer converts an object to an array of key/value pair objects, i.e. `{"color": "red"}`
+// to `[{"key": "color", "value": "red"}]`. It is the reverse of PivotTransformer.
+type UnpivotTransformer struct {
+
+	// KeyField is the field of the pair objects holding the key. It defaults to `key`.
+	KeyField string
+
+	// ValueField is the field of the pair objects holding the value. It defaults to `value`.
+	ValueField string
+}
+
+// UnpivotTransformer Transform applies the unpivot transformation.
+//
+// It expects an object. The pairs are sorted by key.
+func (t UnpivotTransformer) Transform(value any) (any, error) {
+	valueMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("Value is not an object.")
+	}
+
+	keyField, valueField := pairFields(t.KeyField, t.ValueField)
+
+	result := make([]any, 0, len(valueMap))
+	for _, key := range sortedKeys(valueMap) {
+		result = append(result, map[string]any{keyField: key, valueField: valueMap[key]})
+	}
+
+	return result, nil
+}
+
+// The key cases supported by RenameKeysTransformer.
+const (
+	KeyCaseSnake = "snake"
+	KeyCaseCamel = "camel"
+)
+
+// RenameKeysTransformer renames all the keys of an object recursively, i.e. from `first_name` to `firstName`.
+type RenameKeysTransformer struct {
+
+	// TrimPrefix is a prefix to be removed from the keys, i.e. `_`.
+	TrimPrefix string
+
+	// Case is the case the keys are converted to. It can be one of KeyCaseSnake and KeyCaseCamel.
+	// The keys remain as they are if it is empty.
+	Case string
+
+	// Func is an optional custom renaming applied on the keys last.
+	Func func(key string) string
+}
+
+// rename returns the new name of a key.
+func (t RenameKeysTransformer) rename(key string) string {
+	key = strings.TrimPrefix(key, t.TrimPrefix)
+
+	switch t.Case {
+	case KeyCaseSnake:
+		key = CamelToSnake(key)
+	case KeyCaseCamel:
+		key = SnakeToCamel(key)
+	}
+
+	if t.Func != nil {
+		key = t.Func(key)
+	}
+
+	return key
+}
+
+// RenameKeysTransformer Transform applies the rename keys transformation.
+//
+// It expects an object. The nested objects, including the ones within arrays, are renamed as well.
+//
+// If two keys of the same object are renamed to the same key only one of them is kept.
+func (t RenameKeysTransformer) Transform(value any) (any, error) {
+	if !gu.IsMap(value) {
+		return nil, errors.New("Value is not an object.")
+	}
+
+	if t.Case != "" && t.Case != KeyCaseSnake && t.Case != KeyCaseCamel {
+		return nil, fmt.Errorf("Unknown key case '%v'.", t.Case)
+	}
+
+	return ConvertKeys(value.(map[string]any), t.rename, ConvertKeysOptions{}), nil
+}
+
+// IndexTransformer picks an element of an array value by its index.
+type IndexTransformer struct {
+
+	// N is the index of the element. A negative one counts from the end of the array, i.e. -1 is the last element.
+	N int
+}
+
+// IndexTransformer Transform applies the index transformation.
+//
+// It expects an array value so the transformation should be configured with AsArray, otherwise it applies on each
+// element of the array.
+//
+// The index must be within the length of the array value.
+func (t IndexTransformer) Transform(value any) (any, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, errors.New("Value is not an array.")
+	}
+
+	index := t.N
+	if index < 0 {
+		index += len(items)
+	}
+
+	if index < 0 || index >= len(items) {
+		return nil, errors.New("Index out of bound.")
+	}
+
+	return items[index], nil
+}
+
+// PickTransformer keeps only the provided keys of an object value, i.e. `id` and `name` out of a user.
+type PickTransformer struct {
+
+	// Keys are the keys to be kept. The ones missing from the value are ignored.
+	Keys []string
+}
+
+// PickTransformer Transform applies the pick transformation.
+//
+// It expects an object value. The object is copied so that the source data remains untouched.
+func (t PickTransformer) Transform(value any) (any, error) {
+	if !gu.IsMap(value) {
+		return nil, errors.New("Value is not an object.")
+	}
+
+	valueMap := value.(map[string]any)
+	result := make(map[string]any, len(t.Keys))
+	for _, key := range t.Keys {
+		if v, ok := valueMap[key]; ok {
+			result[key] = v
+		}
+	}
+
+	return result, nil
+}
+
+// OmitTransformer removes the provided keys of an object value, i.e. `password` out of a user.
+type OmitTransformer struct {
+
+	// Keys are the keys to be removed. The ones missing from the value are ignored.
+	Keys []string
+}
+
+// OmitTransformer Transform applies the omit transformation.
+//
+// It expects an object value. The object is copied so that the source data remains untouched.
+func (t OmitTransformer) Transform(value any) (any, error) {
+	if !gu.IsMap(value) {
+		return nil, errors.New("Value is not an object.")
+	}
+
+	valueMap := value.(map[string]any)
+	result := make(map[string]any, len(valueMap))
+	for key, v := range valueMap {
+		result[key] = v
+	}
+	for _, key := range t.Keys {
+		delete(result, key)
+	}
+
+	return result, nil
+}
+
+// The values ParseBoolTransformer recognizes by default, compared case-insensitively.
+var (
+	defaultTrueValues  = []string{"true", "t", "yes", "y", "1", "on"}
+	defaultFalseValues = []string{"false", "f", "no", "n", "0", "off"}
+)
+
+// ParseBoolTransformer converts assorted truthy and falsy values, i.e. `Y`, `no` or `1`, to booleans.
+type ParseBoolTransformer struct {
+
+	// TrueValues are the values converted to true. They default to `true`, `t`, `yes`, `y`, `1` and `on`.
+	TrueValues []string
+
+	// FalseValues are the values converted to false. They default to `false`, `f`, `no`, `n`, `0` and `off`.
+	FalseValues []string
+
+	// UseDefault makes the unrecognized values convert to Default instead of causing an error.
+	UseDefault bool
+
+	// Default is the value the unrecognized values convert to if UseDefault is set. If nil they convert to nil so
+	// that the result is true, false or unknown.
+	Default *bool
+}
+
+// ParseBoolTransformer Transform applies the parse bool transformation.
+//
+// It expects a string, a number or a boolean value. The strings are compared with the recognized values
+// case-insensitively and after trimming their surrounding spaces whereas booleans are returned as they are.
+func (t ParseBoolTransformer) Transform(value any) (any, error) {
+	trueValues, falseValues := t.TrueValues, t.FalseValues
+	if trueValues == nil {
+		trueValues = defaultTrueValues
+	}
+	if falseValues == nil {
+		falseValues = defaultFalseValues
+	}
+
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+
+	if gu.IsString(value) || isNumber(value) {
+		s := strings.TrimSpace(fmt.Sprintf("%v", value))
+		for _, v := range trueValues {
+			if strings.EqualFold(s, v) {
+				return true, nil
+			}
+		}
+		for _, v := range falseValues {
+			if strings.EqualFold(s, v) {
+				return false, nil
+			}
+		}
+	}
+
+	if !t.UseDefault {
+		return nil, fmt.Errorf("Couldn't convert %#v to boolean.", value)
+	}
+
+	if t.Default == nil {
+		return nil, nil
+	}
+
+	return *t.Default, nil
+}
@@ -2,6 +2,7 @@ package jsonmanu
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -316,3 +317,447 @@ func TestNumberTransformer(t *testing.T) {
 		})
 	}
 }
+
+func TestZipTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              ZipTransformer{},
+			value:                    "invalid",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an object.",
+		},
+		{
+			transformer:              ZipTransformer{},
+			value:                    map[string]any{"names": []any{"A"}, "count": 1},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value of key 'count' is not an array.",
+		},
+		{
+			transformer: ZipTransformer{},
+			value:       map[string]any{"name": []any{"A", "B"}, "age": []any{1}},
+			expectedTransformedValue: []any{
+				map[string]any{"name": "A", "age": 1},
+				map[string]any{"name": "B"},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			transformer: ZipTransformer{Fields: map[string]string{"names": "name", "ages": "age"}},
+			value:       map[string]any{"names": []any{"A", "B"}, "ages": []any{1, 2}, "count": 2},
+			expectedTransformedValue: []any{
+				map[string]any{"name": "A", "age": 1},
+				map[string]any{"name": "B", "age": 2},
+			},
+			expectedErrorMessage: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("ZipTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestUnzipTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              UnzipTransformer{},
+			value:                    "invalid",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an array.",
+		},
+		{
+			transformer:              UnzipTransformer{},
+			value:                    []any{map[string]any{"name": "A"}, "B"},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Element 1 is not an object.",
+		},
+		{
+			transformer: UnzipTransformer{},
+			value:       []any{map[string]any{"name": "A", "age": 1}, map[string]any{"name": "B"}},
+			expectedTransformedValue: map[string]any{
+				"name": []any{"A", "B"},
+				"age":  []any{1, nil},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			transformer: UnzipTransformer{Fields: map[string]string{"name": "names"}},
+			value:       []any{map[string]any{"name": "A", "age": 1}, map[string]any{"name": "B", "age": 2}},
+			expectedTransformedValue: map[string]any{
+				"names": []any{"A", "B"},
+			},
+			expectedErrorMessage: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("UnzipTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestPivotTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              PivotTransformer{},
+			value:                    map[string]any{},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an array.",
+		},
+		{
+			transformer:              PivotTransformer{},
+			value:                    []any{map[string]any{"key": "color", "value": "red"}, 1},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Element 1 is not an object.",
+		},
+		{
+			transformer:              PivotTransformer{},
+			value:                    []any{map[string]any{"key": 1, "value": "red"}},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Element 0 has no string 'key' field.",
+		},
+		{
+			transformer: PivotTransformer{},
+			value: []any{
+				map[string]any{"key": "color", "value": "red"},
+				map[string]any{"key": "size"},
+				map[string]any{"key": "color", "value": "blue"},
+			},
+			expectedTransformedValue: map[string]any{"color": "blue", "size": nil},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              PivotTransformer{KeyField: "name", ValueField: "val"},
+			value:                    []any{map[string]any{"name": "color", "val": "red"}},
+			expectedTransformedValue: map[string]any{"color": "red"},
+			expectedErrorMessage:     "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("PivotTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestUnpivotTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              UnpivotTransformer{},
+			value:                    []any{},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an object.",
+		},
+		{
+			transformer: UnpivotTransformer{},
+			value:       map[string]any{"size": 10, "color": "red"},
+			expectedTransformedValue: []any{
+				map[string]any{"key": "color", "value": "red"},
+				map[string]any{"key": "size", "value": 10},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			transformer:              UnpivotTransformer{KeyField: "name", ValueField: "val"},
+			value:                    map[string]any{"color": "red"},
+			expectedTransformedValue: []any{map[string]any{"name": "color", "val": "red"}},
+			expectedErrorMessage:     "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("UnpivotTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestRenameKeysTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              RenameKeysTransformer{Case: KeyCaseCamel},
+			value:                    "first_name",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an object.",
+		},
+		{
+			transformer:              RenameKeysTransformer{Case: "kebab"},
+			value:                    map[string]any{},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Unknown key case 'kebab'.",
+		},
+		{
+			transformer: RenameKeysTransformer{Case: KeyCaseCamel},
+			value: map[string]any{
+				"first_name": "Friedrich",
+				"home-town":  "Röcken",
+				"books":      []any{map[string]any{"publication_year": 1883}, "the_title"},
+			},
+			expectedTransformedValue: map[string]any{
+				"firstName": "Friedrich",
+				"homeTown":  "Röcken",
+				"books":     []any{map[string]any{"publicationYear": 1883}, "the_title"},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			transformer: RenameKeysTransformer{Case: KeyCaseSnake},
+			value: map[string]any{
+				"firstName":  "Friedrich",
+				"userID":     1,
+				"HTTPServer": map[string]any{"Port2Use": 80},
+			},
+			expectedTransformedValue: map[string]any{
+				"first_name":  "Friedrich",
+				"user_id":     1,
+				"http_server": map[string]any{"port2_use": 80},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			transformer:              RenameKeysTransformer{TrimPrefix: "_", Func: strings.ToUpper},
+			value:                    map[string]any{"_id": 1, "name": "Book1"},
+			expectedTransformedValue: map[string]any{"ID": 1, "NAME": "Book1"},
+			expectedErrorMessage:     "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("RenameKeysTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestIndexTransformer(t *testing.T) {
+	cases := []TransformerTestCase{
+		{
+			transformer:              IndexTransformer{N: 0},
+			value:                    "Book1",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an array.",
+		},
+		{
+			transformer:              IndexTransformer{N: 1},
+			value:                    []any{"Book1", "Book2", "Book3"},
+			expectedTransformedValue: "Book2",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IndexTransformer{N: -1},
+			value:                    []any{"Book1", "Book2", "Book3"},
+			expectedTransformedValue: "Book3",
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              IndexTransformer{N: 3},
+			value:                    []any{"Book1", "Book2", "Book3"},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Index out of bound.",
+		},
+		{
+			transformer:              IndexTransformer{N: -4},
+			value:                    []any{"Book1", "Book2", "Book3"},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Index out of bound.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("IndexTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
+
+func TestPickOmitTransformers(t *testing.T) {
+	user := map[string]any{"id": 1, "name": "Friedrich", "password": "secret"}
+
+	cases := []TransformerTestCase{
+		{
+			transformer:              PickTransformer{Keys: []string{"id"}},
+			value:                    []any{user},
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an object.",
+		},
+		{
+			transformer:              PickTransformer{Keys: []string{"id", "name", "email"}},
+			value:                    user,
+			expectedTransformedValue: map[string]any{"id": 1, "name": "Friedrich"},
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              OmitTransformer{Keys: []string{"id"}},
+			value:                    "Friedrich",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Value is not an object.",
+		},
+		{
+			transformer:              OmitTransformer{Keys: []string{"password", "email"}},
+			value:                    user,
+			expectedTransformedValue: map[string]any{"id": 1, "name": "Friedrich"},
+			expectedErrorMessage:     "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%T.transform(%v)=%v", tc.transformer, tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+
+	if len(user) != 3 {
+		t.Errorf("Expected the value to remain untouched, but got '%#v'", user)
+	}
+}
+
+func TestParseBoolTransformer(t *testing.T) {
+	no := false
+
+	cases := []TransformerTestCase{
+		{
+			transformer:              ParseBoolTransformer{},
+			value:                    " Yes ",
+			expectedTransformedValue: true,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ParseBoolTransformer{},
+			value:                    "N",
+			expectedTransformedValue: false,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ParseBoolTransformer{},
+			value:                    1,
+			expectedTransformedValue: true,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ParseBoolTransformer{},
+			value:                    false,
+			expectedTransformedValue: false,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ParseBoolTransformer{},
+			value:                    "maybe",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert \"maybe\" to boolean.",
+		},
+		{
+			transformer:              ParseBoolTransformer{UseDefault: true},
+			value:                    "maybe",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ParseBoolTransformer{UseDefault: true, Default: &no},
+			value:                    []any{"yes"},
+			expectedTransformedValue: false,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ParseBoolTransformer{TrueValues: []string{"ja"}, FalseValues: []string{"nein"}},
+			value:                    "JA",
+			expectedTransformedValue: true,
+			expectedErrorMessage:     "",
+		},
+		{
+			transformer:              ParseBoolTransformer{TrueValues: []string{"ja"}, FalseValues: []string{"nein"}},
+			value:                    "yes",
+			expectedTransformedValue: nil,
+			expectedErrorMessage:     "Couldn't convert \"yes\" to boolean.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("ParseBoolTransformer.transform(%v)=%v", tc.value, tc.expectedTransformedValue), func(t *testing.T) {
+			transformedValue, err := tc.transformer.Transform(tc.value)
+
+			if err == nil && len(tc.expectedErrorMessage) > 0 {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err)
+			}
+			if err != nil && err.Error() != tc.expectedErrorMessage {
+				t.Errorf("Expected error message '%#v', but got '%#v'", tc.expectedErrorMessage, err.Error())
+			}
+			if !cmp.Equal(tc.expectedTransformedValue, transformedValue) {
+				t.Errorf("Expected '%#v', but got '%#v'", tc.expectedTransformedValue, transformedValue)
+			}
+		})
+	}
+}
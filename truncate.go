@@ -0,0 +1,74 @@
+package jsonmanu
+
+import (
+	"fmt"
+)
+
+// TruncateOptions holds the limits of Truncate. A zero limit stands for no limit.
+type TruncateOptions struct {
+
+	// MaxArrayLength is the maximum number of the elements of an array. The first ones are kept.
+	MaxArrayLength int
+
+	// MaxStringLength is the maximum number of the characters of a string. The first ones are kept.
+	MaxStringLength int
+}
+
+// Truncation records a value shortened by Truncate.
+type Truncation struct {
+
+	// Path is the JSONPath of the value, i.e. `$.books[0].summary`.
+	Path string `json:"path"`
+
+	// Length is the length of the value before truncation.
+	Length int `json:"length"`
+}
+
+// truncator truncates values recording the truncations.
+type truncator struct {
+	opts        TruncateOptions
+	truncations []Truncation
+}
+
+// truncate returns a truncated copy of the value found at the provided path.
+func (t *truncator) truncate(value any, path string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for _, key := range sortedKeys(v) {
+			result[key] = t.truncate(v[key], path+"."+key)
+		}
+		return result
+	case []any:
+		items := v
+		if t.opts.MaxArrayLength > 0 && len(v) > t.opts.MaxArrayLength {
+			t.truncations = append(t.truncations, Truncation{Path: path, Length: len(v)})
+			items = v[:t.opts.MaxArrayLength]
+		}
+		result := make([]any, len(items))
+		for i, item := range items {
+			result[i] = t.truncate(item, fmt.Sprintf("%v[%v]", path, i))
+		}
+		return result
+	case string:
+		runes := []rune(v)
+		if t.opts.MaxStringLength > 0 && len(runes) > t.opts.MaxStringLength {
+			t.truncations = append(t.truncations, Truncation{Path: path, Length: len(runes)})
+			return string(runes[:t.opts.MaxStringLength])
+		}
+	}
+
+	return value
+}
+
+// Truncate returns a smaller preview of the data where the arrays and the strings are capped to the provided lengths,
+// along with the truncations that occured so that a UI or a log can show where the data were shortened.
+//
+// The data remain untouched. The truncations are listed in the order they are found, visiting the object keys in
+// lexicographical order.
+func Truncate(data map[string]any, opts TruncateOptions) (map[string]any, []Truncation) {
+	t := &truncator{opts: opts}
+	result, _ := t.truncate(data, "$").(map[string]any)
+
+	return result, t.truncations
+}
@@ -0,0 +1,68 @@
+package jsonmanu
+
+import (
+	"fmt"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+type TruncateTestCase struct {
+	opts                TruncateOptions
+	expectedData        map[string]any
+	expectedTruncations []Truncation
+}
+
+func TestTruncate(t *testing.T) {
+	data := map[string]any{
+		"title": "Thus Spoke Zarathustra",
+		"books": []any{
+			map[string]any{"summary": "Übermensch", "tags": []any{"a", "b", "c"}},
+			map[string]any{"summary": "Short"},
+			map[string]any{"summary": "Dropped"},
+		},
+		"count": 3,
+	}
+	original := deepCopy(data)
+
+	testCases := []TruncateTestCase{
+		{
+			opts:                TruncateOptions{},
+			expectedData:        data,
+			expectedTruncations: nil,
+		},
+		{
+			opts: TruncateOptions{MaxArrayLength: 2, MaxStringLength: 5},
+			expectedData: map[string]any{
+				"title": "Thus ",
+				"books": []any{
+					map[string]any{"summary": "Überm", "tags": []any{"a", "b"}},
+					map[string]any{"summary": "Short"},
+				},
+				"count": 3,
+			},
+			expectedTruncations: []Truncation{
+				{Path: "$.books", Length: 3},
+				{Path: "$.books[0].summary", Length: 10},
+				{Path: "$.books[0].tags", Length: 3},
+				{Path: "$.title", Length: 22},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("(%v) - Truncate(%+v)", i, tc.opts), func(t *testing.T) {
+			result, truncations := Truncate(data, tc.opts)
+			if !cmp.Equal(tc.expectedData, result) {
+				t.Errorf("Expected '%s', but got '%s'", gu.Prettify(tc.expectedData), gu.Prettify(result))
+			}
+			if !cmp.Equal(tc.expectedTruncations, truncations) {
+				t.Errorf("Expected truncations '%#v', but got '%#v'", tc.expectedTruncations, truncations)
+			}
+			if !cmp.Equal(original, data) {
+				t.Errorf("Expected the data to remain '%s', but got '%s'", gu.Prettify(original), gu.Prettify(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package jsonmanu
+
+// errTxnClosed is returned by the operations of a transaction after it is committed or rolled back.
+var errTxnClosed = &Error{Code: ErrCodeInvalidOperation, Message: "Transaction is already closed"}
+
+// Txn is a set of mutations of a Document which are applied all together on Commit or not at all on Rollback.
+//
+// The mutations are applied on a copy of the document data which replaces the data of the document on Commit, so a
+// failing Put or Delete leaves the document untouched. It is not safe for concurrent use.
+type Txn struct {
+	doc *Document
+
+	data map[string]any
+
+	changeset Changeset
+
+	closed bool
+}
+
+// Begin starts a transaction on the document. The changes applied on the document itself before the transaction is
+// committed are overwritten by it.
+func (d *Document) Begin() *Txn {
+	return &Txn{doc: d, data: deepCopy(d.data).(map[string]any)}
+}
+
+// Get retrieves a value out of the document as it is described in the provided JSONPath including the mutations of
+// the transaction.
+func (t *Txn) Get(jsonPath string) (any, error) {
+	if t.closed {
+		return nil, errTxnClosed
+	}
+
+	return Get(t.data, jsonPath)
+}
+
+// Put updates the document as it is described in the provided JSONPath with a new value once the transaction is
+// committed.
+func (t *Txn) Put(jsonPath string, value any) error {
+	if t.closed {
+		return errTxnClosed
+	}
+
+	op := putOperation(t.data, jsonPath, value)
+
+	if err := Put(t.data, jsonPath, value); err != nil {
+		return err
+	}
+
+	t.changeset = append(t.changeset, op)
+
+	return nil
+}
+
+// Delete removes the branch(es) of the document as it is described in the provided JSONPath once the transaction is
+// committed.
+func (t *Txn) Delete(jsonPath string) error {
+	if t.closed {
+		return errTxnClosed
+	}
+
+	old, oldErr := Get(t.data, jsonPath)
+	old = deepCopy(old)
+
+	if err := Delete(t.data, jsonPath); err != nil {
+		return err
+	}
+
+	if oldErr == nil {
+		t.changeset = append(t.changeset, Operation{Path: jsonPath, Op: OperationDelete, Old: old, OldExists: true})
+	}
+
+	return nil
+}
+
+// Commit applies the mutations of the transaction on the document at once and closes the transaction.
+//
+// The data map of the document is kept and only its contents are replaced, and its query cache is cleared. While
+// recording, the mutations are recorded in their order and the subscriptions are notified once for all of them.
+func (t *Txn) Commit() error {
+	if t.closed {
+		return errTxnClosed
+	}
+	t.closed = true
+
+	d := t.doc
+	watched := d.watched()
+
+	jsonPaths := make([]string, len(t.changeset))
+	for i, op := range t.changeset {
+		jsonPaths[i] = op.Path
+	}
+	d.preserve(jsonPaths...)
+
+	for key := range d.data {
+		delete(d.data, key)
+	}
+	for key, value := range t.data {
+		d.data[key] = value
+	}
+	// The changes applied on the document since Begin are overwritten too, so the cache is dropped as a whole rather
+	// than invalidated for the paths of the changeset.
+	d.clearCache()
+
+	if d.recording {
+		d.changeset = append(d.changeset, t.changeset...)
+	}
+	d.notify(watched)
+
+	return nil
+}
+
+// Rollback discards the mutations of the transaction and closes it. Rolling back a closed transaction has no effect,
+// so it can be deferred right after Begin.
+func (t *Txn) Rollback() {
+	t.closed = true
+	t.data = nil
+	t.changeset = nil
+}
+
+// Update runs the function within a transaction of the document which is committed if the function returns no error
+// and rolled back otherwise.
+func (d *Document) Update(fn func(txn *Txn) error) error {
+	txn := d.Begin()
+	defer txn.Rollback()
+
+	if err := fn(txn); err != nil {
+		return err
+	}
+
+	return txn.Commit()
+}
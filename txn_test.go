@@ -0,0 +1,119 @@
+package jsonmanu
+
+import (
+	"errors"
+	"testing"
+
+	gu "github.com/antavelos/go-utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTxnCommit(t *testing.T) {
+	doc := NewDocument(map[string]any{
+		"book": map[string]any{"author": "Nietzsche", "title": "Book1"},
+	})
+	data := doc.Data()
+
+	var notifications []notification
+	doc.Subscribe("$.book", func(old, new any) { notifications = append(notifications, notification{old, new}) })
+
+	doc.StartRecording()
+	txn := doc.Begin()
+	txn.Put("$.book.author", "Stirner")
+	txn.Delete("$.book.title")
+
+	if value, _ := doc.Get("$.book.author"); value != "Nietzsche" {
+		t.Errorf("Expected the document untouched before commit, but got '%v'", value)
+	}
+	if value, _ := txn.Get("$.book.author"); value != "Stirner" {
+		t.Errorf("Expected the transaction to see its own changes, but got '%v'", value)
+	}
+
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+	changeset := doc.StopRecording()
+
+	expectedData := map[string]any{"book": map[string]any{"author": "Stirner"}}
+	if !cmp.Equal(expectedData, data) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(data))
+	}
+
+	expectedChangeset := Changeset{
+		{Path: "$.book.author", Op: OperationPut, Old: "Nietzsche", OldExists: true, New: "Stirner"},
+		{Path: "$.book.title", Op: OperationDelete, Old: "Book1", OldExists: true},
+	}
+	if !cmp.Equal(expectedChangeset, changeset) {
+		t.Errorf("Expected '%#v', but got '%#v'", expectedChangeset, changeset)
+	}
+
+	expectedNotifications := []notification{{
+		map[string]any{"author": "Nietzsche", "title": "Book1"},
+		map[string]any{"author": "Stirner"},
+	}}
+	if !cmp.Equal(expectedNotifications, notifications, cmp.AllowUnexported(notification{})) {
+		t.Errorf("Expected '%v', but got '%v'", expectedNotifications, notifications)
+	}
+
+	if err := txn.Put("$.book.year", 1844); !errors.Is(err, errTxnClosed) {
+		t.Errorf("Expected '%v', but got '%v'", errTxnClosed, err)
+	}
+}
+
+func TestTxnCommitClearsQueryCache(t *testing.T) {
+	doc := NewDocument(map[string]any{"book": map[string]any{"author": "Nietzsche", "title": "Book1"}})
+	doc.EnableQueryCache()
+
+	txn := doc.Begin()
+	txn.Put("$.book.author", "Stirner")
+
+	doc.Put("$.book.title", "Book2")
+	if value, _ := doc.Get("$.book.title"); value != "Book2" {
+		t.Fatalf("Expected 'Book2', but got '%v'", value)
+	}
+
+	if err := txn.Commit(); err != nil {
+		t.Fatalf("Expected no error, but got '%v'", err)
+	}
+
+	if value, _ := doc.Get("$.book.title"); value != "Book1" {
+		t.Errorf("Expected the write made since Begin to be overwritten, but got '%v'", value)
+	}
+	if value, _ := doc.Get("$.book.author"); value != "Stirner" {
+		t.Errorf("Expected 'Stirner', but got '%v'", value)
+	}
+}
+
+func TestTxnRollback(t *testing.T) {
+	doc := NewDocument(map[string]any{
+		"book": map[string]any{"author": "Nietzsche", "title": "Book1"},
+	})
+
+	err := doc.Update(func(txn *Txn) error {
+		if err := txn.Put("$.book.author", "Stirner"); err != nil {
+			return err
+		}
+		return txn.Put("$.book.title[0]", "Book2")
+	})
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	expectedData := map[string]any{
+		"book": map[string]any{"author": "Nietzsche", "title": "Book1"},
+	}
+	if !cmp.Equal(expectedData, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(doc.Data()))
+	}
+
+	txn := doc.Begin()
+	txn.Delete("$.book")
+	txn.Rollback()
+
+	if err := txn.Commit(); !errors.Is(err, errTxnClosed) {
+		t.Errorf("Expected '%v', but got '%v'", errTxnClosed, err)
+	}
+	if !cmp.Equal(expectedData, doc.Data()) {
+		t.Errorf("Expected '%#s', but got '%#s'", gu.Prettify(expectedData), gu.Prettify(doc.Data()))
+	}
+}